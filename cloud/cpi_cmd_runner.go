@@ -4,12 +4,17 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	biinstallmanifest "github.com/cloudfoundry/bosh-cli/installation/manifest"
 )
 
+const cpiKillGracePeriod = 10 * time.Second
+
 type CmdInput struct {
 	Method    string        `json:"method"`
 	Arguments []interface{} `json:"arguments"`
@@ -55,6 +60,7 @@ type CPICmdRunner interface {
 type cpiCmdRunner struct {
 	cmdRunner boshsys.CmdRunner
 	cpi       CPI
+	policies  biinstallmanifest.CPIPolicies
 	logger    boshlog.Logger
 	logTag    string
 }
@@ -62,11 +68,13 @@ type cpiCmdRunner struct {
 func NewCPICmdRunner(
 	cmdRunner boshsys.CmdRunner,
 	cpi CPI,
+	policies biinstallmanifest.CPIPolicies,
 	logger boshlog.Logger,
 ) CPICmdRunner {
 	return &cpiCmdRunner{
 		cmdRunner: cmdRunner,
 		cpi:       cpi,
+		policies:  policies,
 		logger:    logger,
 		logTag:    "cpiCmdRunner",
 	}
@@ -94,7 +102,7 @@ func (r *cpiCmdRunner) Run(context CmdContext, method string, args ...interface{
 		UseIsolatedEnv: true,
 		Stdin:          bytes.NewReader(inputBytes),
 	}
-	stdout, stderr, exitCode, err := r.cmdRunner.RunComplexCommand(cmd)
+	stdout, stderr, exitCode, err := r.runCommand(cmd, method)
 	r.logger.Debug(r.logTag, "Exit Code %d when executing external CPI command '%s'\nSTDIN: '%s'\nSTDOUT: '%s'\nSTDERR: '%s'", exitCode, cmdPath, string(inputBytes), stdout, stderr)
 	if err != nil {
 		return CmdOutput{}, bosherr.WrapErrorf(err, "Executing external CPI command: '%s'", cmdPath)
@@ -110,3 +118,30 @@ func (r *cpiCmdRunner) Run(context CmdContext, method string, args ...interface{
 
 	return cmdOutput, err
 }
+
+func (r *cpiCmdRunner) runCommand(cmd boshsys.Command, method string) (string, string, int, error) {
+	timeout := r.policies.For(method).Timeout
+	if timeout == 0 {
+		return r.cmdRunner.RunComplexCommand(cmd)
+	}
+
+	process, err := r.cmdRunner.RunComplexCommandAsync(cmd)
+	if err != nil {
+		return "", "", -1, err
+	}
+
+	resultCh := process.Wait()
+
+	select {
+	case result := <-resultCh:
+		return result.Stdout, result.Stderr, result.ExitStatus, result.Error
+
+	case <-time.After(timeout):
+		err = process.TerminateNicely(cpiKillGracePeriod)
+		if err != nil {
+			r.logger.Warn(r.logTag, "Terminating external CPI command '%s': %s", cmd.Name, err.Error())
+		}
+
+		return "", "", -1, bosherr.Errorf("Timed out calling CPI method '%s' after %s", method, timeout)
+	}
+}
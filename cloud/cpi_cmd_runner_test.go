@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"errors"
 	"io/ioutil"
+	"time"
 
 	. "github.com/cloudfoundry/bosh-cli/cloud"
+	biinstallmanifest "github.com/cloudfoundry/bosh-cli/installation/manifest"
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
 	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -33,7 +36,7 @@ var _ = Describe("CpiCmdRunner", func() {
 
 		cmdRunner = fakesys.NewFakeCmdRunner()
 		logger := boshlog.NewLogger(boshlog.LevelNone)
-		cpiCmdRunner = NewCPICmdRunner(cmdRunner, cpi, logger)
+		cpiCmdRunner = NewCPICmdRunner(cmdRunner, cpi, biinstallmanifest.CPIPolicies{}, logger)
 	})
 
 	Describe("Run", func() {
@@ -113,6 +116,41 @@ var _ = Describe("CpiCmdRunner", func() {
 			})
 		})
 
+		Context("when method has a timeout", func() {
+			BeforeEach(func() {
+				logger := boshlog.NewLogger(boshlog.LevelNone)
+				timeout := 10 * time.Millisecond
+				policies := biinstallmanifest.CPIPolicies{
+					"fake-method": biinstallmanifest.CPIPolicy{Timeout: &timeout},
+				}
+				cpiCmdRunner = NewCPICmdRunner(cmdRunner, cpi, policies, logger)
+			})
+
+			It("returns the result when the command finishes in time", func() {
+				cmdRunner.AddProcess("/jobs/cpi/bin/cpi", &fakesys.FakeProcess{
+					WaitResult: boshsys.Result{Stdout: `{"result":"fake-cid"}`},
+				})
+
+				cmdOutput, err := cpiCmdRunner.Run(context, "fake-method", "fake-argument")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cmdOutput.Result).To(Equal("fake-cid"))
+			})
+
+			It("terminates the command and returns an error when it does not finish in time", func() {
+				process := &fakesys.FakeProcess{
+					TerminatedNicelyCallBack: func(p *fakesys.FakeProcess) {
+						p.WaitCh <- boshsys.Result{Error: errors.New("fake-terminated-error")}
+					},
+				}
+				cmdRunner.AddProcess("/jobs/cpi/bin/cpi", process)
+
+				_, err := cpiCmdRunner.Run(context, "fake-method", "fake-argument")
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Timed out calling CPI method 'fake-method' after 10ms"))
+				Expect(process.TerminatedNicely).To(BeTrue())
+			})
+		})
+
 		Context("when the command runs but fails", func() {
 			BeforeEach(func() {
 				cmdOutput := CmdOutput{
@@ -1,7 +1,10 @@
 package cloud
 
 import (
+	"code.cloudfoundry.org/clock"
+
 	biinstall "github.com/cloudfoundry/bosh-cli/installation"
+	biui "github.com/cloudfoundry/bosh-cli/ui"
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 	boshsys "github.com/cloudfoundry/bosh-utils/system"
@@ -12,20 +15,32 @@ type Factory interface {
 }
 
 type factory struct {
-	fs        boshsys.FileSystem
-	cmdRunner boshsys.CmdRunner
-	logger    boshlog.Logger
+	fs            boshsys.FileSystem
+	cmdRunner     boshsys.CmdRunner
+	cpiRecordPath string
+	cpiReplayPath string
+	stage         biui.Stage
+	timeService   clock.Clock
+	logger        boshlog.Logger
 }
 
 func NewFactory(
 	fs boshsys.FileSystem,
 	cmdRunner boshsys.CmdRunner,
+	cpiRecordPath string,
+	cpiReplayPath string,
+	stage biui.Stage,
+	timeService clock.Clock,
 	logger boshlog.Logger,
 ) Factory {
 	return &factory{
-		fs:        fs,
-		cmdRunner: cmdRunner,
-		logger:    logger,
+		fs:            fs,
+		cmdRunner:     cmdRunner,
+		cpiRecordPath: cpiRecordPath,
+		cpiReplayPath: cpiReplayPath,
+		stage:         stage,
+		timeService:   timeService,
+		logger:        logger,
 	}
 }
 
@@ -43,6 +58,19 @@ func (f *factory) NewCloud(installation biinstall.Installation, directorID strin
 		return nil, bosherr.Errorf("Installed CPI job '%s' does not contain the required executable '%s'", cpiJob.Name, cmdPath)
 	}
 
-	cpiCmdRunner := NewCPICmdRunner(f.cmdRunner, cpi, f.logger)
+	cpiPolicies := installation.Manifest().CPIPolicies
+
+	var cpiCmdRunner CPICmdRunner
+	if len(f.cpiReplayPath) > 0 {
+		cpiCmdRunner = NewReplayCPICmdRunner(f.fs, f.cpiReplayPath, f.logger)
+	} else {
+		cpiCmdRunner = NewCPICmdRunner(f.cmdRunner, cpi, cpiPolicies, f.logger)
+	}
+
+	if len(f.cpiRecordPath) > 0 {
+		cpiCmdRunner = NewRecordingCPICmdRunner(cpiCmdRunner, f.fs, f.cpiRecordPath, f.logger)
+	}
+	cpiCmdRunner = NewRetryingCPICmdRunner(cpiCmdRunner, cpiPolicies, f.stage, f.timeService, f.logger)
+
 	return NewCloud(cpiCmdRunner, directorID, f.logger), nil
 }
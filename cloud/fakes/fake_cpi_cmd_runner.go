@@ -8,6 +8,9 @@ type FakeCPICmdRunner struct {
 	RunInputs    []RunInput
 	RunCmdOutput bicloud.CmdOutput
 	RunErr       error
+
+	// RunCmdOutputs are returned one per call before falling back to RunCmdOutput
+	RunCmdOutputs []bicloud.CmdOutput
 }
 
 type RunInput struct {
@@ -26,5 +29,12 @@ func (r *FakeCPICmdRunner) Run(context bicloud.CmdContext, method string, args .
 		Method:    method,
 		Arguments: args,
 	})
+
+	if len(r.RunCmdOutputs) > 0 {
+		cmdOutput := r.RunCmdOutputs[0]
+		r.RunCmdOutputs = r.RunCmdOutputs[1:]
+		return cmdOutput, r.RunErr
+	}
+
 	return r.RunCmdOutput, r.RunErr
 }
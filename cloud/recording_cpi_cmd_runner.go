@@ -0,0 +1,111 @@
+package cloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+)
+
+// CmdRecording is a single CPI request and response pair
+type CmdRecording struct {
+	Request  CmdInput  `json:"request"`
+	Response CmdOutput `json:"response"`
+	Error    string    `json:"error,omitempty"`
+}
+
+type recordingCPICmdRunner struct {
+	cpiCmdRunner CPICmdRunner
+	fs           boshsys.FileSystem
+	recordPath   string
+	logger       boshlog.Logger
+	logTag       string
+
+	nextIndex int
+}
+
+// NewRecordingCPICmdRunner writes every CPI call made through cpiCmdRunner
+// into recordPath so that it can be served back by NewReplayCPICmdRunner
+func NewRecordingCPICmdRunner(
+	cpiCmdRunner CPICmdRunner,
+	fs boshsys.FileSystem,
+	recordPath string,
+	logger boshlog.Logger,
+) CPICmdRunner {
+	return &recordingCPICmdRunner{
+		cpiCmdRunner: cpiCmdRunner,
+		fs:           fs,
+		recordPath:   recordPath,
+		logger:       logger,
+		logTag:       "recordingCPICmdRunner",
+	}
+}
+
+func (r *recordingCPICmdRunner) Run(context CmdContext, method string, args ...interface{}) (CmdOutput, error) {
+	cmdOutput, runErr := r.cpiCmdRunner.Run(context, method, args...)
+
+	recording := CmdRecording{
+		Request: CmdInput{
+			Method:    method,
+			Arguments: args,
+			Context:   context,
+		},
+		Response: cmdOutput,
+	}
+	if runErr != nil {
+		recording.Error = runErr.Error()
+	}
+
+	// CPI call already took effect so failing to record it must not fail the call
+	err := r.record(recording)
+	if err != nil {
+		r.logger.Warn(r.logTag, "Failed to record CPI call '%s': %s", method, err.Error())
+	}
+
+	return cmdOutput, runErr
+}
+
+func (r *recordingCPICmdRunner) record(recording CmdRecording) error {
+	if r.nextIndex == 0 {
+		err := r.fs.MkdirAll(r.recordPath, 0700)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Creating CPI recording directory '%s'", r.recordPath)
+		}
+
+		// Continue numbering after recordings left by previous runs
+		existing, err := r.fs.Glob(filepath.Join(r.recordPath, "*.json"))
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Listing CPI recordings in '%s'", r.recordPath)
+		}
+
+		r.nextIndex = len(existing) + 1
+	}
+
+	recordingBytes, err := json.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		return bosherr.WrapError(err, "Marshalling CPI recording")
+	}
+
+	recordingPath := filepath.Join(r.recordPath, fmt.Sprintf("%04d-%s.json", r.nextIndex, recording.Request.Method))
+
+	// Requests may include VM credentials so file must never be readable by others
+	file, err := r.fs.OpenFile(recordingPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Creating CPI recording '%s'", recordingPath)
+	}
+
+	defer file.Close()
+
+	_, err = file.Write(recordingBytes)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing CPI recording '%s'", recordingPath)
+	}
+
+	r.nextIndex++
+
+	return nil
+}
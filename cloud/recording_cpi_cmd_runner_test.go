@@ -0,0 +1,119 @@
+package cloud_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+
+	. "github.com/cloudfoundry/bosh-cli/cloud"
+	fakebicloud "github.com/cloudfoundry/bosh-cli/cloud/fakes"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RecordingCPICmdRunner", func() {
+	var (
+		fakeCPICmdRunner *fakebicloud.FakeCPICmdRunner
+		fs               *fakesys.FakeFileSystem
+		context          CmdContext
+		runner           CPICmdRunner
+	)
+
+	BeforeEach(func() {
+		fakeCPICmdRunner = fakebicloud.NewFakeCPICmdRunner()
+		fs = fakesys.NewFakeFileSystem()
+		context = CmdContext{DirectorID: "fake-director-id"}
+
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		runner = NewRecordingCPICmdRunner(fakeCPICmdRunner, fs, "/recordings", logger)
+	})
+
+	readRecording := func(path string) CmdRecording {
+		recordingBytes, err := fs.ReadFile(path)
+		Expect(err).ToNot(HaveOccurred())
+
+		var recording CmdRecording
+		err = json.Unmarshal(recordingBytes, &recording)
+		Expect(err).ToNot(HaveOccurred())
+
+		return recording
+	}
+
+	It("returns output of wrapped runner", func() {
+		fakeCPICmdRunner.RunCmdOutput = CmdOutput{Result: "fake-vm-cid"}
+
+		cmdOutput, err := runner.Run(context, "create_vm", "fake-agent-id")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cmdOutput).To(Equal(CmdOutput{Result: "fake-vm-cid"}))
+
+		Expect(fakeCPICmdRunner.RunInputs).To(Equal([]fakebicloud.RunInput{
+			{Context: context, Method: "create_vm", Arguments: []interface{}{"fake-agent-id"}},
+		}))
+	})
+
+	It("writes each request and response into a numbered file", func() {
+		fakeCPICmdRunner.RunCmdOutput = CmdOutput{Result: "fake-stemcell-cid", Log: "fake-log"}
+
+		_, err := runner.Run(context, "create_stemcell", "/fake/image", map[string]interface{}{})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = runner.Run(context, "info")
+		Expect(err).ToNot(HaveOccurred())
+
+		recording := readRecording("/recordings/0001-create_stemcell.json")
+		Expect(recording.Request.Method).To(Equal("create_stemcell"))
+		Expect(recording.Request.Arguments).To(Equal([]interface{}{"/fake/image", map[string]interface{}{}}))
+		Expect(recording.Request.Context).To(Equal(context))
+		Expect(recording.Response).To(Equal(CmdOutput{Result: "fake-stemcell-cid", Log: "fake-log"}))
+		Expect(recording.Error).To(BeEmpty())
+
+		Expect(readRecording("/recordings/0002-info.json").Request.Method).To(Equal("info"))
+	})
+
+	It("makes recordings readable only by the current user", func() {
+		_, err := runner.Run(context, "info")
+		Expect(err).ToNot(HaveOccurred())
+
+		stat := fs.GetFileTestStat("/recordings/0001-info.json")
+		Expect(stat.FileMode).To(Equal(os.FileMode(0600)))
+		Expect(stat.Flags).To(Equal(os.O_WRONLY | os.O_CREATE | os.O_EXCL))
+	})
+
+	It("continues numbering after existing recordings", func() {
+		fs.SetGlob("/recordings/*.json", []string{"/recordings/0001-info.json"})
+
+		_, err := runner.Run(context, "info")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fs.FileExists("/recordings/0002-info.json")).To(BeTrue())
+	})
+
+	Context("when wrapped runner fails", func() {
+		BeforeEach(func() {
+			fakeCPICmdRunner.RunErr = errors.New("fake-run-error")
+		})
+
+		It("records and returns the error", func() {
+			_, err := runner.Run(context, "delete_vm", "fake-vm-cid")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("fake-run-error"))
+
+			Expect(readRecording("/recordings/0001-delete_vm.json").Error).To(Equal("fake-run-error"))
+		})
+	})
+
+	Context("when recording fails", func() {
+		BeforeEach(func() {
+			fs.OpenFileErr = errors.New("fake-open-error")
+			fakeCPICmdRunner.RunCmdOutput = CmdOutput{Result: "fake-vm-cid"}
+		})
+
+		It("still returns output of wrapped runner", func() {
+			cmdOutput, err := runner.Run(context, "create_vm")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cmdOutput.Result).To(Equal("fake-vm-cid"))
+		})
+	})
+})
@@ -0,0 +1,89 @@
+package cloud
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+)
+
+type replayCPICmdRunner struct {
+	fs         boshsys.FileSystem
+	recordPath string
+	logger     boshlog.Logger
+	logTag     string
+
+	loaded     bool
+	recordings []CmdRecording
+}
+
+// NewReplayCPICmdRunner serves CPI calls from recordings written by
+// NewRecordingCPICmdRunner in the order they were recorded
+func NewReplayCPICmdRunner(fs boshsys.FileSystem, recordPath string, logger boshlog.Logger) CPICmdRunner {
+	return &replayCPICmdRunner{
+		fs:         fs,
+		recordPath: recordPath,
+		logger:     logger,
+		logTag:     "replayCPICmdRunner",
+	}
+}
+
+func (r *replayCPICmdRunner) Run(context CmdContext, method string, args ...interface{}) (CmdOutput, error) {
+	if !r.loaded {
+		err := r.load()
+		if err != nil {
+			return CmdOutput{}, err
+		}
+	}
+
+	if len(r.recordings) == 0 {
+		return CmdOutput{}, bosherr.Errorf("No recorded CPI calls left in '%s' to replay '%s'", r.recordPath, method)
+	}
+
+	recording := r.recordings[0]
+	r.recordings = r.recordings[1:]
+
+	if recording.Request.Method != method {
+		return CmdOutput{}, bosherr.Errorf("Expected recorded CPI call '%s' but found '%s'", method, recording.Request.Method)
+	}
+
+	r.logger.Debug(r.logTag, "Replaying CPI call '%s' with recorded arguments %#v", method, recording.Request.Arguments)
+
+	if len(recording.Error) > 0 {
+		return recording.Response, bosherr.Error(recording.Error)
+	}
+
+	return recording.Response, nil
+}
+
+func (r *replayCPICmdRunner) load() error {
+	paths, err := r.fs.Glob(filepath.Join(r.recordPath, "*.json"))
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Listing CPI recordings in '%s'", r.recordPath)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		recordingBytes, err := r.fs.ReadFile(path)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Reading CPI recording '%s'", path)
+		}
+
+		var recording CmdRecording
+
+		err = json.Unmarshal(recordingBytes, &recording)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Unmarshalling CPI recording '%s'", path)
+		}
+
+		r.recordings = append(r.recordings, recording)
+	}
+
+	r.loaded = true
+
+	return nil
+}
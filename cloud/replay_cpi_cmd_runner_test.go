@@ -0,0 +1,110 @@
+package cloud_test
+
+import (
+	. "github.com/cloudfoundry/bosh-cli/cloud"
+	fakebicloud "github.com/cloudfoundry/bosh-cli/cloud/fakes"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReplayCPICmdRunner", func() {
+	var (
+		fs      *fakesys.FakeFileSystem
+		logger  boshlog.Logger
+		context CmdContext
+		runner  CPICmdRunner
+	)
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+		logger = boshlog.NewLogger(boshlog.LevelNone)
+		context = CmdContext{DirectorID: "fake-director-id"}
+
+		fs.WriteFileString("/recordings/0002-create_vm.json", `{
+			"request": {"method": "create_vm", "arguments": ["fake-agent-id"], "context": {"director_uuid": "fake-director-id"}},
+			"response": {"result": "fake-vm-cid", "log": ""}
+		}`)
+		fs.WriteFileString("/recordings/0001-create_stemcell.json", `{
+			"request": {"method": "create_stemcell", "arguments": ["/fake/image", {}], "context": {"director_uuid": "fake-director-id"}},
+			"response": {"result": "fake-stemcell-cid", "log": ""}
+		}`)
+		fs.WriteFileString("/recordings/0003-delete_vm.json", `{
+			"request": {"method": "delete_vm", "arguments": ["fake-vm-cid"], "context": {"director_uuid": "fake-director-id"}},
+			"response": {"result": null, "log": ""},
+			"error": "fake-delete-error"
+		}`)
+		fs.SetGlob("/recordings/*.json", []string{
+			"/recordings/0002-create_vm.json",
+			"/recordings/0001-create_stemcell.json",
+			"/recordings/0003-delete_vm.json",
+		})
+
+		runner = NewReplayCPICmdRunner(fs, "/recordings", logger)
+	})
+
+	It("serves recorded responses in recorded order", func() {
+		cmdOutput, err := runner.Run(context, "create_stemcell", "/other/image", map[string]interface{}{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cmdOutput.Result).To(Equal("fake-stemcell-cid"))
+
+		cmdOutput, err = runner.Run(context, "create_vm", "other-agent-id")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cmdOutput.Result).To(Equal("fake-vm-cid"))
+	})
+
+	It("returns recorded errors", func() {
+		_, err := runner.Run(context, "create_stemcell")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = runner.Run(context, "create_vm")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = runner.Run(context, "delete_vm", "fake-vm-cid")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("fake-delete-error"))
+	})
+
+	It("returns an error when called method does not match recording", func() {
+		_, err := runner.Run(context, "create_vm")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected recorded CPI call 'create_vm' but found 'create_stemcell'"))
+	})
+
+	It("returns an error when recordings are exhausted", func() {
+		for _, method := range []string{"create_stemcell", "create_vm", "delete_vm"} {
+			runner.Run(context, method)
+		}
+
+		_, err := runner.Run(context, "info")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("No recorded CPI calls left in '/recordings' to replay 'info'"))
+	})
+
+	It("returns an error when recording cannot be parsed", func() {
+		fs.WriteFileString("/recordings/0001-create_stemcell.json", "invalid-json")
+
+		_, err := runner.Run(context, "create_stemcell")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Unmarshalling CPI recording '/recordings/0001-create_stemcell.json'"))
+	})
+
+	It("replays calls recorded by recording runner through cloud", func() {
+		recordFS := fakesys.NewFakeFileSystem()
+		fakeCPICmdRunner := fakebicloud.NewFakeCPICmdRunner()
+		fakeCPICmdRunner.RunCmdOutput = CmdOutput{Result: "fake-disk-cid"}
+
+		recordingCloud := NewCloud(NewRecordingCPICmdRunner(fakeCPICmdRunner, recordFS, "/recordings", logger), "fake-director-id", logger)
+		diskCID, err := recordingCloud.CreateDisk(1024, biproperty.Map{}, "fake-vm-cid")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diskCID).To(Equal("fake-disk-cid"))
+
+		recordFS.SetGlob("/recordings/*.json", []string{"/recordings/0001-create_disk.json"})
+
+		replayCloud := NewCloud(NewReplayCPICmdRunner(recordFS, "/recordings", logger), "fake-director-id", logger)
+		diskCID, err = replayCloud.CreateDisk(1024, biproperty.Map{}, "fake-vm-cid")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(diskCID).To(Equal("fake-disk-cid"))
+	})
+})
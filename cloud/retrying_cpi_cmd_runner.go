@@ -0,0 +1,92 @@
+package cloud
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/clock"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+
+	biinstallmanifest "github.com/cloudfoundry/bosh-cli/installation/manifest"
+	biui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+type retryingCPICmdRunner struct {
+	cpiCmdRunner CPICmdRunner
+	policies     biinstallmanifest.CPIPolicies
+	stage        biui.Stage
+	timeService  clock.Clock
+	logger       boshlog.Logger
+	logTag       string
+}
+
+// NewRetryingCPICmdRunner repeats CPI calls failing with retryable CPI errors
+// according to policies. First attempt runs within stages of callers;
+// once it fails, remaining attempts are reported as a sub-stage of stage.
+func NewRetryingCPICmdRunner(
+	cpiCmdRunner CPICmdRunner,
+	policies biinstallmanifest.CPIPolicies,
+	stage biui.Stage,
+	timeService clock.Clock,
+	logger boshlog.Logger,
+) CPICmdRunner {
+	return &retryingCPICmdRunner{
+		cpiCmdRunner: cpiCmdRunner,
+		policies:     policies,
+		stage:        stage,
+		timeService:  timeService,
+		logger:       logger,
+		logTag:       "retryingCPICmdRunner",
+	}
+}
+
+func (r *retryingCPICmdRunner) Run(context CmdContext, method string, args ...interface{}) (CmdOutput, error) {
+	policy := r.policies.For(method)
+
+	cmdOutput, err := r.cpiCmdRunner.Run(context, method, args...)
+
+	// CPI errors are returned in output to be converted by cloud as usual
+	if !r.shouldRetry(policy, 1, cmdOutput, err) {
+		return cmdOutput, err
+	}
+
+	stageName := fmt.Sprintf("retrying CPI method '%s'", method)
+
+	_ = r.stage.PerformComplex(stageName, func(stage biui.Stage) error {
+		for attempt := 2; ; attempt++ {
+			r.logger.Warn(r.logTag, "Retrying CPI method '%s' in %s after error: %s", method, policy.Backoff, cmdOutput.Error)
+
+			if policy.Backoff > 0 {
+				r.timeService.Sleep(policy.Backoff)
+			}
+
+			attemptName := fmt.Sprintf("Calling CPI method '%s' (attempt %d of %d)", method, attempt, policy.MaxAttempts)
+
+			attemptErr := stage.Perform(attemptName, func() error {
+				cmdOutput, err = r.cpiCmdRunner.Run(context, method, args...)
+				if err != nil {
+					return err
+				}
+
+				if cmdOutput.Error != nil {
+					return NewCPIError(method, *cmdOutput.Error)
+				}
+
+				return nil
+			})
+
+			if !r.shouldRetry(policy, attempt, cmdOutput, err) {
+				return attemptErr
+			}
+		}
+	})
+
+	return cmdOutput, err
+}
+
+func (r *retryingCPICmdRunner) shouldRetry(policy biinstallmanifest.CPICallPolicy, attempt int, cmdOutput CmdOutput, err error) bool {
+	if err != nil || cmdOutput.Error == nil || attempt >= policy.MaxAttempts {
+		return false
+	}
+
+	return policy.IsRetryable(cmdOutput.Error.Type, cmdOutput.Error.OkToRetry)
+}
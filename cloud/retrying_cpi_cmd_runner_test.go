@@ -0,0 +1,143 @@
+package cloud_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	. "github.com/cloudfoundry/bosh-cli/cloud"
+	fakebicloud "github.com/cloudfoundry/bosh-cli/cloud/fakes"
+	biinstallmanifest "github.com/cloudfoundry/bosh-cli/installation/manifest"
+	fakebiui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RetryingCPICmdRunner", func() {
+	var (
+		fakeCPICmdRunner *fakebicloud.FakeCPICmdRunner
+		context          CmdContext
+		policies         biinstallmanifest.CPIPolicies
+		transientError   CmdOutput
+		stage            *fakebiui.FakeStage
+		timeService      *fakeclock.FakeClock
+	)
+
+	BeforeEach(func() {
+		fakeCPICmdRunner = fakebicloud.NewFakeCPICmdRunner()
+		context = CmdContext{DirectorID: "fake-director-id"}
+
+		maxAttempts := 3
+		policies = biinstallmanifest.CPIPolicies{
+			"create_vm": biinstallmanifest.CPIPolicy{
+				MaxAttempts:     &maxAttempts,
+				RetryableErrors: []string{"Bosh::Clouds::VMCreationFailed"},
+			},
+		}
+
+		transientError = CmdOutput{
+			Error: &CmdError{Type: "Bosh::Clouds::VMCreationFailed", Message: "fake-transient-error"},
+		}
+
+		stage = fakebiui.NewFakeStage()
+		timeService = fakeclock.NewFakeClock(time.Now())
+	})
+
+	newRunner := func() CPICmdRunner {
+		return NewRetryingCPICmdRunner(fakeCPICmdRunner, policies, stage, timeService, boshlog.NewLogger(boshlog.LevelNone))
+	}
+
+	It("calls CPI once when method has no retry policy", func() {
+		fakeCPICmdRunner.RunCmdOutput = transientError
+
+		cmdOutput, err := newRunner().Run(context, "attach_disk", "fake-vm-cid", "fake-disk-cid")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cmdOutput).To(Equal(transientError))
+
+		Expect(fakeCPICmdRunner.RunInputs).To(HaveLen(1))
+		Expect(stage.PerformCalls).To(BeEmpty())
+	})
+
+	It("retries retryable CPI errors", func() {
+		fakeCPICmdRunner.RunCmdOutputs = []CmdOutput{transientError}
+		fakeCPICmdRunner.RunCmdOutput = CmdOutput{Result: "fake-vm-cid"}
+
+		cmdOutput, err := newRunner().Run(context, "create_vm", "fake-agent-id")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cmdOutput).To(Equal(CmdOutput{Result: "fake-vm-cid"}))
+
+		Expect(fakeCPICmdRunner.RunInputs).To(Equal([]fakebicloud.RunInput{
+			{Context: context, Method: "create_vm", Arguments: []interface{}{"fake-agent-id"}},
+			{Context: context, Method: "create_vm", Arguments: []interface{}{"fake-agent-id"}},
+		}))
+
+		Expect(stage.PerformCalls).To(HaveLen(1))
+		Expect(stage.PerformCalls[0].Name).To(Equal("retrying CPI method 'create_vm'"))
+		Expect(stage.PerformCalls[0].Error).ToNot(HaveOccurred())
+		Expect(stage.PerformCalls[0].Stage.PerformCalls).To(Equal([]*fakebiui.PerformCall{
+			{Name: "Calling CPI method 'create_vm' (attempt 2 of 3)"},
+		}))
+	})
+
+	It("waits for backoff before retrying", func() {
+		backoff := 10 * time.Second
+		policies["create_vm"] = biinstallmanifest.CPIPolicy{
+			MaxAttempts:     policies["create_vm"].MaxAttempts,
+			Backoff:         &backoff,
+			RetryableErrors: policies["create_vm"].RetryableErrors,
+		}
+
+		fakeCPICmdRunner.RunCmdOutputs = []CmdOutput{transientError}
+		fakeCPICmdRunner.RunCmdOutput = CmdOutput{Result: "fake-vm-cid"}
+
+		go timeService.WaitForWatcherAndIncrement(backoff)
+
+		cmdOutput, err := newRunner().Run(context, "create_vm", "fake-agent-id")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cmdOutput).To(Equal(CmdOutput{Result: "fake-vm-cid"}))
+
+		Expect(fakeCPICmdRunner.RunInputs).To(HaveLen(2))
+	})
+
+	It("returns last CPI error once attempts are exhausted", func() {
+		fakeCPICmdRunner.RunCmdOutput = transientError
+
+		cmdOutput, err := newRunner().Run(context, "create_vm", "fake-agent-id")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cmdOutput).To(Equal(transientError))
+
+		Expect(fakeCPICmdRunner.RunInputs).To(HaveLen(3))
+
+		Expect(stage.PerformCalls).To(HaveLen(1))
+		Expect(stage.PerformCalls[0].Error).To(HaveOccurred())
+		Expect(stage.PerformCalls[0].Error.Error()).To(ContainSubstring("fake-transient-error"))
+
+		attempts := stage.PerformCalls[0].Stage.PerformCalls
+		Expect(attempts).To(HaveLen(2))
+		Expect(attempts[0].Name).To(Equal("Calling CPI method 'create_vm' (attempt 2 of 3)"))
+		Expect(attempts[1].Name).To(Equal("Calling CPI method 'create_vm' (attempt 3 of 3)"))
+	})
+
+	It("does not retry CPI errors of other types", func() {
+		fakeCPICmdRunner.RunCmdOutput = CmdOutput{
+			Error: &CmdError{Type: "Bosh::Clouds::CloudError", Message: "fake-error", OkToRetry: true},
+		}
+
+		cmdOutput, err := newRunner().Run(context, "create_vm", "fake-agent-id")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cmdOutput.Error.Type).To(Equal("Bosh::Clouds::CloudError"))
+
+		Expect(fakeCPICmdRunner.RunInputs).To(HaveLen(1))
+	})
+
+	It("does not retry when running CPI fails", func() {
+		fakeCPICmdRunner.RunErr = errors.New("fake-run-error")
+
+		_, err := newRunner().Run(context, "create_vm", "fake-agent-id")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-run-error"))
+
+		Expect(fakeCPICmdRunner.RunInputs).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	bipkgcache "github.com/cloudfoundry/bosh-cli/installation/pkgcache"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+type CacheLsCmd struct {
+	cache bipkgcache.Cache
+	ui    boshui.UI
+}
+
+func NewCacheLsCmd(cache bipkgcache.Cache, ui boshui.UI) CacheLsCmd {
+	return CacheLsCmd{cache: cache, ui: ui}
+}
+
+func (c CacheLsCmd) Run() error {
+	entries, err := c.cache.List()
+	if err != nil {
+		return err
+	}
+
+	c.ui.PrintTable(cacheEntriesTable(entries))
+
+	return nil
+}
+
+func cacheEntriesTable(entries []bipkgcache.Entry) boshtbl.Table {
+	table := boshtbl.Table{
+		Content: "compiled packages",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Name"),
+			boshtbl.NewHeader("Fingerprint"),
+			boshtbl.NewHeader("Platform"),
+			boshtbl.NewHeader("Size"),
+			boshtbl.NewHeader("Last Used"),
+			boshtbl.NewHeader("Key"),
+		},
+
+		SortBy: []boshtbl.ColumnSort{
+			{Column: 0, Asc: true},
+			{Column: 1, Asc: true},
+		},
+	}
+
+	for _, entry := range entries {
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(entry.Name),
+			boshtbl.NewValueString(entry.Fingerprint),
+			boshtbl.NewValueString(entry.OS + "/" + entry.Arch),
+			boshtbl.NewValueBytes(uint64(entry.Size)),
+			boshtbl.NewValueTime(entry.LastUsedAt),
+			boshtbl.NewValueString(entry.Key),
+		})
+	}
+
+	return table
+}
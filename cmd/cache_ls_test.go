@@ -0,0 +1,87 @@
+package cmd_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	bipkgcache "github.com/cloudfoundry/bosh-cli/installation/pkgcache"
+	fakepkgcache "github.com/cloudfoundry/bosh-cli/installation/pkgcache/fakepkgcache"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("CacheLsCmd", func() {
+	var (
+		cache   *fakepkgcache.FakeCache
+		ui      *fakeui.FakeUI
+		command CacheLsCmd
+	)
+
+	BeforeEach(func() {
+		cache = &fakepkgcache.FakeCache{}
+		ui = &fakeui.FakeUI{}
+		command = NewCacheLsCmd(cache, ui)
+	})
+
+	Describe("Run", func() {
+		It("lists cached compiled packages", func() {
+			lastUsedAt := time.Date(2017, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+			cache.ListReturns([]bipkgcache.Entry{
+				{
+					Key:         "key",
+					Name:        "app",
+					Fingerprint: "app-fp",
+					OS:          "linux",
+					Arch:        "amd64",
+					Size:        1024,
+					LastUsedAt:  lastUsedAt,
+				},
+			}, nil)
+
+			err := command.Run()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Table).To(Equal(boshtbl.Table{
+				Content: "compiled packages",
+
+				Header: []boshtbl.Header{
+					boshtbl.NewHeader("Name"),
+					boshtbl.NewHeader("Fingerprint"),
+					boshtbl.NewHeader("Platform"),
+					boshtbl.NewHeader("Size"),
+					boshtbl.NewHeader("Last Used"),
+					boshtbl.NewHeader("Key"),
+				},
+
+				SortBy: []boshtbl.ColumnSort{
+					{Column: 0, Asc: true},
+					{Column: 1, Asc: true},
+				},
+
+				Rows: [][]boshtbl.Value{
+					{
+						boshtbl.NewValueString("app"),
+						boshtbl.NewValueString("app-fp"),
+						boshtbl.NewValueString("linux/amd64"),
+						boshtbl.NewValueBytes(1024),
+						boshtbl.NewValueTime(lastUsedAt),
+						boshtbl.NewValueString("key"),
+					},
+				},
+			}))
+		})
+
+		It("returns error if listing fails", func() {
+			cache.ListReturns(nil, errors.New("fake-err"))
+
+			err := command.Run()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+})
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/clock"
+
+	bipkgcache "github.com/cloudfoundry/bosh-cli/installation/pkgcache"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+type CachePruneCmd struct {
+	cache       bipkgcache.Cache
+	timeService clock.Clock
+	ui          boshui.UI
+}
+
+func NewCachePruneCmd(cache bipkgcache.Cache, timeService clock.Clock, ui boshui.UI) CachePruneCmd {
+	return CachePruneCmd{cache: cache, timeService: timeService, ui: ui}
+}
+
+func (c CachePruneCmd) Run(opts CachePruneOpts) error {
+	unusedSince := c.timeService.Now().UTC().Add(-time.Duration(opts.UnusedFor))
+
+	entries, err := c.cache.Prune(unusedSince)
+
+	// Show what was removed even if some entries failed to be removed
+	if len(entries) > 0 {
+		c.ui.PrintTable(cacheEntriesTable(entries))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	c.ui.PrintLinef("Removed %d compiled package(s) from cache", len(entries))
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package cmd_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	bipkgcache "github.com/cloudfoundry/bosh-cli/installation/pkgcache"
+	fakepkgcache "github.com/cloudfoundry/bosh-cli/installation/pkgcache/fakepkgcache"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+)
+
+var _ = Describe("CachePruneCmd", func() {
+	var (
+		cache   *fakepkgcache.FakeCache
+		ui      *fakeui.FakeUI
+		now     time.Time
+		command CachePruneCmd
+	)
+
+	BeforeEach(func() {
+		cache = &fakepkgcache.FakeCache{}
+		ui = &fakeui.FakeUI{}
+		now = time.Date(2017, time.January, 2, 3, 4, 5, 0, time.UTC)
+		command = NewCachePruneCmd(cache, fakeclock.NewFakeClock(now), ui)
+	})
+
+	Describe("Run", func() {
+		var (
+			opts CachePruneOpts
+		)
+
+		BeforeEach(func() {
+			opts = CachePruneOpts{}
+		})
+
+		It("removes all cached packages", func() {
+			cache.PruneReturns([]bipkgcache.Entry{{Name: "app"}, {Name: "lib"}}, nil)
+
+			err := command.Run(opts)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(cache.PruneArgsForCall(0)).To(Equal(now))
+
+			Expect(ui.Table.Rows).To(HaveLen(2))
+			Expect(ui.Said).To(Equal([]string{"Removed 2 compiled package(s) from cache"}))
+		})
+
+		It("removes cached packages not used within given duration", func() {
+			opts.UnusedFor = DurationArg(24 * time.Hour)
+
+			err := command.Run(opts)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(cache.PruneArgsForCall(0)).To(Equal(now.Add(-24 * time.Hour)))
+
+			Expect(ui.Table.Rows).To(BeEmpty())
+			Expect(ui.Said).To(Equal([]string{"Removed 0 compiled package(s) from cache"}))
+		})
+
+		It("shows removed packages and returns error if pruning fails", func() {
+			cache.PruneReturns([]bipkgcache.Entry{{Name: "app"}}, errors.New("fake-err"))
+
+			err := command.Run(opts)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+
+			Expect(ui.Table.Rows).To(HaveLen(1))
+		})
+	})
+})
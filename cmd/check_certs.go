@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/cppforlife/go-patch/patch"
+	"gopkg.in/yaml.v2"
+
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+type CheckCertsCmd struct {
+	ui          boshui.UI
+	timeService clock.Clock
+}
+
+func NewCheckCertsCmd(ui boshui.UI, timeService clock.Clock) CheckCertsCmd {
+	return CheckCertsCmd{ui: ui, timeService: timeService}
+}
+
+type checkedCert struct {
+	Name string
+	Cert *x509.Certificate
+}
+
+func (c CheckCertsCmd) Run(opts CheckCertsOpts) error {
+	manifestBytes := opts.Args.Manifest.Bytes
+
+	if !opts.VarsFSStore.IsSet() && len(manifestBytes) == 0 {
+		return bosherr.Error("Expected vars store or manifest to be specified")
+	}
+
+	var certs []checkedCert
+
+	if opts.VarsFSStore.IsSet() {
+		storeCerts, err := c.varsStoreCerts(opts.VarFlags)
+		if err != nil {
+			return err
+		}
+
+		certs = append(certs, storeCerts...)
+	}
+
+	if len(manifestBytes) > 0 {
+		manifestCerts, err := c.manifestCerts(manifestBytes, opts.VarFlags, opts.OpsFlags)
+		if err != nil {
+			return err
+		}
+
+		certs = append(certs, manifestCerts...)
+	}
+
+	now := c.timeService.Now()
+	within := time.Duration(opts.ExpiringWithin)
+
+	var expired, expiring int
+
+	table := boshtbl.Table{
+		Content: "certificates",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Name"),
+			boshtbl.NewHeader("Common Name"),
+			boshtbl.NewHeader("SANs"),
+			boshtbl.NewHeader("Issuer"),
+			boshtbl.NewHeader("Expires"),
+			boshtbl.NewHeader("Days Left"),
+		},
+
+		SortBy: []boshtbl.ColumnSort{{Column: 5, Asc: true}, {Column: 0, Asc: true}},
+
+		Notes: []string{
+			fmt.Sprintf("Certificates expiring within %s are considered expiring", opts.ExpiringWithin),
+		},
+	}
+
+	for _, cert := range certs {
+		notAfter := cert.Cert.NotAfter
+
+		failing := false
+
+		switch {
+		case !now.Before(notAfter):
+			expired++
+			failing = true
+		case !now.Add(within).Before(notAfter):
+			expiring++
+			failing = true
+		}
+
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(cert.Name),
+			boshtbl.NewValueString(cert.Cert.Subject.CommonName),
+			boshtbl.NewValueStrings(c.sans(cert.Cert)),
+			boshtbl.NewValueString(cert.Cert.Issuer.CommonName),
+			boshtbl.NewValueTime(notAfter.UTC()),
+			boshtbl.NewValueFmt(boshtbl.NewValueInt(c.daysLeft(now, notAfter)), failing),
+		})
+	}
+
+	c.ui.PrintTable(table)
+
+	if expired > 0 || expiring > 0 {
+		return bosherr.Errorf(
+			"Expected no certificates to be expired or expiring within %s, but found %d expired and %d expiring",
+			opts.ExpiringWithin, expired, expiring)
+	}
+
+	return nil
+}
+
+func (c CheckCertsCmd) varsStoreCerts(varFlags VarFlags) ([]checkedCert, error) {
+	store := varFlags.VarsFSStore
+	store.Encryptor = varFlags.VarsFSKey.Encryptor(VarsStorePassphraseEnv)
+
+	vars, err := store.load()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	for name := range vars {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var certs []checkedCert
+
+	for _, name := range names {
+		// Vars store entries are named the way they are referenced (e.g. ca.certificate)
+		found, err := c.findCerts(name, ".", vars[name])
+		if err != nil {
+			return nil, err
+		}
+
+		certs = append(certs, found...)
+	}
+
+	return certs, nil
+}
+
+func (c CheckCertsCmd) manifestCerts(manifestBytes []byte, varFlags VarFlags, opsFlags OpsFlags) ([]checkedCert, error) {
+	// Dropping variable definitions avoids generating missing values while checking
+	op := patch.Ops{
+		opsFlags.AsOp(),
+		patch.RemoveOp{Path: patch.MustNewPointerFromString("/variables?")},
+	}
+
+	bytes, err := boshtpl.NewTemplate(manifestBytes).Evaluate(varFlags.AsVariables(), op, boshtpl.EvaluateOpts{})
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Interpolating manifest")
+	}
+
+	var obj interface{}
+
+	err = yaml.Unmarshal(bytes, &obj)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Deserializing manifest")
+	}
+
+	// Manifest entries are named by their paths (e.g. /instance_groups/0/...)
+	return c.findCerts("", "/", obj)
+}
+
+func (c CheckCertsCmd) findCerts(name, sep string, obj interface{}) ([]checkedCert, error) {
+	var certs []checkedCert
+
+	switch typedObj := obj.(type) {
+	case map[interface{}]interface{}:
+		keys := map[string]interface{}{}
+
+		var sortedKeys []string
+
+		for k, v := range typedObj {
+			keyStr := fmt.Sprintf("%v", k)
+			keys[keyStr] = v
+			sortedKeys = append(sortedKeys, keyStr)
+		}
+
+		sort.Strings(sortedKeys)
+
+		for _, k := range sortedKeys {
+			found, err := c.findCerts(c.childName(name, sep, k), sep, keys[k])
+			if err != nil {
+				return nil, err
+			}
+
+			certs = append(certs, found...)
+		}
+
+	case []interface{}:
+		for i, item := range typedObj {
+			found, err := c.findCerts(c.childName(name, sep, fmt.Sprintf("%d", i)), sep, item)
+			if err != nil {
+				return nil, err
+			}
+
+			certs = append(certs, found...)
+		}
+
+	case string:
+		return c.parseCerts(name, typedObj)
+	}
+
+	return certs, nil
+}
+
+func (c CheckCertsCmd) parseCerts(name, str string) ([]checkedCert, error) {
+	var certs []checkedCert
+
+	rest := []byte(str)
+
+	for {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Parsing certificate '%s'", name)
+		}
+
+		certs = append(certs, checkedCert{Name: name, Cert: cert})
+	}
+
+	return certs, nil
+}
+
+func (c CheckCertsCmd) childName(name, sep, key string) string {
+	if sep == "/" || len(name) > 0 {
+		return name + sep + key
+	}
+
+	return key
+}
+
+func (c CheckCertsCmd) sans(cert *x509.Certificate) []string {
+	sans := append([]string{}, cert.DNSNames...)
+
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+
+	return sans
+}
+
+func (c CheckCertsCmd) daysLeft(now, notAfter time.Time) int {
+	return int(math.Floor(notAfter.Sub(now).Hours() / 24))
+}
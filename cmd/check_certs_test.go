@@ -0,0 +1,201 @@
+package cmd_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("CheckCertsCmd", func() {
+	var (
+		fs      *fakesys.FakeFileSystem
+		ui      *fakeui.FakeUI
+		now     time.Time
+		command CheckCertsCmd
+	)
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+		ui = &fakeui.FakeUI{}
+		now = time.Date(2017, time.March, 1, 12, 0, 0, 0, time.UTC)
+		command = NewCheckCertsCmd(ui, fakeclock.NewFakeClock(now))
+	})
+
+	Describe("Run", func() {
+		var (
+			opts CheckCertsOpts
+		)
+
+		generateCert := func(cn string, notAfter time.Time) string {
+			key, err := rsa.GenerateKey(rand.Reader, 1024)
+			Expect(err).ToNot(HaveOccurred())
+
+			template := &x509.Certificate{
+				SerialNumber: big.NewInt(1),
+				Subject:      pkix.Name{CommonName: cn},
+				DNSNames:     []string{cn + ".internal"},
+				IPAddresses:  []net.IP{net.ParseIP("10.0.0.1")},
+				NotBefore:    now.Add(-24 * time.Hour),
+				NotAfter:     notAfter,
+			}
+
+			der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+			Expect(err).ToNot(HaveOccurred())
+
+			return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+		}
+
+		BeforeEach(func() {
+			opts = CheckCertsOpts{ExpiringWithin: DurationArg(30 * 24 * time.Hour)}
+		})
+
+		act := func() error { return command.Run(opts) }
+
+		setStore := func(vars boshtpl.StaticVariables) {
+			bytes, err := yaml.Marshal(vars)
+			Expect(err).ToNot(HaveOccurred())
+
+			fs.WriteFile("/store", bytes)
+
+			opts.VarsFSStore = VarsFSStore{FS: fs}
+
+			err = (&opts.VarsFSStore).UnmarshalFlag("/store")
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		It("lists certificates from vars store", func() {
+			setStore(boshtpl.StaticVariables{
+				"password": "secret",
+				"ca":       map[interface{}]interface{}{"certificate": generateCert("ca", now.Add(365*24*time.Hour))},
+				"leaf":     map[interface{}]interface{}{"certificate": generateCert("leaf", now.Add(100*24*time.Hour+time.Hour))},
+			})
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Table).To(Equal(boshtbl.Table{
+				Content: "certificates",
+
+				Header: []boshtbl.Header{
+					boshtbl.NewHeader("Name"),
+					boshtbl.NewHeader("Common Name"),
+					boshtbl.NewHeader("SANs"),
+					boshtbl.NewHeader("Issuer"),
+					boshtbl.NewHeader("Expires"),
+					boshtbl.NewHeader("Days Left"),
+				},
+
+				SortBy: []boshtbl.ColumnSort{{Column: 5, Asc: true}, {Column: 0, Asc: true}},
+
+				Rows: [][]boshtbl.Value{
+					{
+						boshtbl.NewValueString("ca.certificate"),
+						boshtbl.NewValueString("ca"),
+						boshtbl.NewValueStrings([]string{"ca.internal", "10.0.0.1"}),
+						boshtbl.NewValueString("ca"),
+						boshtbl.NewValueTime(now.Add(365 * 24 * time.Hour)),
+						boshtbl.NewValueFmt(boshtbl.NewValueInt(365), false),
+					},
+					{
+						boshtbl.NewValueString("leaf.certificate"),
+						boshtbl.NewValueString("leaf"),
+						boshtbl.NewValueStrings([]string{"leaf.internal", "10.0.0.1"}),
+						boshtbl.NewValueString("leaf"),
+						boshtbl.NewValueTime(now.Add(100*24*time.Hour + time.Hour)),
+						boshtbl.NewValueFmt(boshtbl.NewValueInt(100), false),
+					},
+				},
+
+				Notes: []string{"Certificates expiring within 30d are considered expiring"},
+			}))
+		})
+
+		It("lists certificates from interpolated manifest by their paths", func() {
+			setStore(boshtpl.StaticVariables{
+				"ca": map[interface{}]interface{}{"certificate": generateCert("ca", now.Add(365*24*time.Hour))},
+			})
+
+			opts.Args.Manifest = FileBytesArg{Bytes: []byte(`
+instance_groups:
+- name: web
+  properties:
+    ca: ((ca.certificate))
+    other: ((missing.certificate))
+variables:
+- name: missing
+  type: certificate
+  options: {is_ca: true, common_name: missing}
+`)}
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Table.Rows).To(HaveLen(2))
+			Expect(ui.Table.Rows[0][0]).To(Equal(boshtbl.NewValueString("ca.certificate")))
+			Expect(ui.Table.Rows[1][0]).To(Equal(boshtbl.NewValueString("/instance_groups/0/properties/ca")))
+
+			Expect(fs.ReadFileString("/store")).ToNot(ContainSubstring("missing"))
+		})
+
+		It("returns an error if certificates are expired or expiring", func() {
+			setStore(boshtpl.StaticVariables{
+				"ok":       map[interface{}]interface{}{"certificate": generateCert("ok", now.Add(31*24*time.Hour))},
+				"expiring": map[interface{}]interface{}{"certificate": generateCert("expiring", now.Add(29*24*time.Hour))},
+				"expired":  map[interface{}]interface{}{"certificate": generateCert("expired", now.Add(-time.Hour))},
+			})
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected no certificates to be expired or expiring within 30d, but found 1 expired and 1 expiring"))
+
+			Expect(ui.Table.Rows).To(HaveLen(3))
+			Expect(ui.Table.Rows[0][5]).To(Equal(boshtbl.NewValueFmt(boshtbl.NewValueInt(-1), true)))
+			Expect(ui.Table.Rows[1][5]).To(Equal(boshtbl.NewValueFmt(boshtbl.NewValueInt(29), true)))
+			Expect(ui.Table.Rows[2][5]).To(Equal(boshtbl.NewValueFmt(boshtbl.NewValueInt(31), false)))
+		})
+
+		It("lists every certificate in a chain", func() {
+			chain := generateCert("leaf", now.Add(40*24*time.Hour)) + generateCert("int", now.Add(50*24*time.Hour))
+
+			setStore(boshtpl.StaticVariables{"chain": chain})
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ui.Table.Rows).To(HaveLen(2))
+		})
+
+		It("returns an error if certificate cannot be parsed", func() {
+			setStore(boshtpl.StaticVariables{
+				"ca": map[interface{}]interface{}{
+					"certificate": "-----BEGIN CERTIFICATE-----\nMIIDtzCCAp+gAwIBAgIJAMZ/qRdR\n-----END CERTIFICATE-----\n",
+				},
+			})
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Parsing certificate 'ca.certificate'"))
+		})
+
+		It("returns an error if neither vars store nor manifest is specified", func() {
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected vars store or manifest to be specified"))
+		})
+	})
+})
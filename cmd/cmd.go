@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"path/filepath"
+	"runtime"
 
 	"github.com/cppforlife/go-patch/patch"
 
@@ -10,15 +11,18 @@ import (
 	"github.com/cloudfoundry/bosh-cli/crypto"
 	boshdir "github.com/cloudfoundry/bosh-cli/director"
 	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	bipkgcache "github.com/cloudfoundry/bosh-cli/installation/pkgcache"
 	boshrel "github.com/cloudfoundry/bosh-cli/release"
 	boshreldir "github.com/cloudfoundry/bosh-cli/releasedir"
 	boshssh "github.com/cloudfoundry/bosh-cli/ssh"
 	bistemcell "github.com/cloudfoundry/bosh-cli/stemcell"
+	bitemplateerb "github.com/cloudfoundry/bosh-cli/templatescompiler/erbrenderer"
 	boshui "github.com/cloudfoundry/bosh-cli/ui"
-	boshuit "github.com/cloudfoundry/bosh-cli/ui/task"
 
 	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+	boshuit "github.com/cloudfoundry/bosh-cli/ui/task"
 	boshcrypto "github.com/cloudfoundry/bosh-utils/crypto"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 	boshfu "github.com/cloudfoundry/bosh-utils/fileutil"
 )
 
@@ -56,6 +60,10 @@ func (c Cmd) Execute() (cmdErr error) {
 		c.deps = c.deps.WithSha2CheckSumming()
 	}
 
+	if c.BoshOpts.AllEnvironmentsOpt || len(c.BoshOpts.EnvironmentsOpt) > 0 {
+		return c.executeInEnvironments()
+	}
+
 	deps := c.deps
 
 	switch opts := c.Opts.(type) {
@@ -67,7 +75,13 @@ func (c Cmd) Execute() (cmdErr error) {
 
 	case *CreateEnvOpts:
 		envProvider := func(manifestPath string, statePath string, vars boshtpl.Variables, op patch.Op) DeploymentPreparer {
-			return NewEnvFactory(deps, manifestPath, statePath, vars, op).Preparer()
+			envOpts := EnvFactoryOpts{
+				ReleaseSignature: opts.ReleaseSignatureFlags,
+				RecordCPI:        opts.RecordCPI,
+				ReplayCPI:        opts.ReplayCPI,
+				FakeCPI:          opts.FakeCPI,
+			}
+			return NewEnvFactory(deps, manifestPath, statePath, vars, op, envOpts).Preparer()
 		}
 
 		stage := boshui.NewStage(deps.UI, deps.Time, deps.Logger)
@@ -75,7 +89,7 @@ func (c Cmd) Execute() (cmdErr error) {
 
 	case *DeleteEnvOpts:
 		envProvider := func(manifestPath string, statePath string, vars boshtpl.Variables, op patch.Op) DeploymentDeleter {
-			return NewEnvFactory(deps, manifestPath, statePath, vars, op).Deleter()
+			return NewEnvFactory(deps, manifestPath, statePath, vars, op, EnvFactoryOpts{FakeCPI: opts.FakeCPI}).Deleter()
 		}
 
 		stage := boshui.NewStage(deps.UI, deps.Time, deps.Logger)
@@ -83,21 +97,21 @@ func (c Cmd) Execute() (cmdErr error) {
 
 	case *AliasEnvOpts:
 		sessionFactory := func(config cmdconf.Config) Session {
-			return NewSessionFromOpts(c.BoshOpts, config, deps.UI, true, false, deps.FS, deps.Logger)
+			return NewSessionFromOpts(c.BoshOpts, config, deps.UI, true, false, deps.FS, deps.Time, deps.Logger)
 		}
 
 		return NewAliasEnvCmd(sessionFactory, c.config(), deps.UI).Run(*opts)
 
 	case *LogInOpts:
 		sessionFactory := func(config cmdconf.Config) Session {
-			return NewSessionFromOpts(c.BoshOpts, config, deps.UI, true, true, deps.FS, deps.Logger)
+			return NewSessionFromOpts(c.BoshOpts, config, deps.UI, true, true, deps.FS, deps.Time, deps.Logger)
 		}
 
 		config := c.config()
 		basicStrategy := NewBasicLoginStrategy(sessionFactory, config, deps.UI)
 		uaaStrategy := NewUAALoginStrategy(sessionFactory, config, deps.UI, deps.Logger)
 
-		sess := NewSessionFromOpts(c.BoshOpts, c.config(), deps.UI, true, true, deps.FS, deps.Logger)
+		sess := NewSessionFromOpts(c.BoshOpts, c.config(), deps.UI, true, true, deps.FS, deps.Time, deps.Logger)
 
 		anonDirector, err := sess.AnonymousDirector()
 		if err != nil {
@@ -108,13 +122,32 @@ func (c Cmd) Execute() (cmdErr error) {
 
 	case *LogOutOpts:
 		config := c.config()
-		sess := NewSessionFromOpts(c.BoshOpts, config, deps.UI, true, true, deps.FS, deps.Logger)
+		sess := NewSessionFromOpts(c.BoshOpts, config, deps.UI, true, true, deps.FS, deps.Time, deps.Logger)
 		return NewLogOutCmd(sess.Environment(), config, deps.UI).Run()
 
 	case *TaskOpts:
-		eventsTaskReporter := boshuit.NewReporter(deps.UI, true)
-		plainTaskReporter := boshuit.NewReporter(deps.UI, false)
-		return NewTaskCmd(eventsTaskReporter, plainTaskReporter, c.director()).Run(*opts)
+		sess := c.session()
+
+		director, err := sess.Director()
+		if err != nil {
+			return err
+		}
+
+		// Offsets are only required to resume; otherwise output is followed without recording them
+		var taskOffsets cmdconf.TaskOffsets
+
+		fsTaskOffsets, err := NewTaskOffsetsFromOpts(c.BoshOpts, deps.FS, deps.Time)
+		if err == nil {
+			taskOffsets = fsTaskOffsets
+		} else if opts.Resume {
+			return err
+		} else {
+			deps.Logger.Error("task", "Loading task offsets: %s", err)
+		}
+
+		eventsTaskReporter := NewTaskReporter(c.BoshOpts, deps.UI, true, deps.Logger)
+		plainTaskReporter := NewTaskReporter(c.BoshOpts, deps.UI, false, deps.Logger)
+		return NewTaskCmd(eventsTaskReporter, plainTaskReporter, taskOffsets, sess.Environment(), director, deps.Time, deps.Logger).Run(*opts)
 
 	case *TasksOpts:
 		return NewTasksCmd(deps.UI, c.director()).Run(*opts)
@@ -124,7 +157,7 @@ func (c Cmd) Execute() (cmdErr error) {
 
 	case *DeploymentOpts:
 		sessionFactory := func(config cmdconf.Config) Session {
-			return NewSessionFromOpts(c.BoshOpts, config, deps.UI, true, false, deps.FS, deps.Logger)
+			return NewSessionFromOpts(c.BoshOpts, config, deps.UI, true, false, deps.FS, deps.Time, deps.Logger)
 		}
 
 		return NewDeploymentCmd(sessionFactory, c.config(), deps.UI).Run()
@@ -144,7 +177,7 @@ func (c Cmd) Execute() (cmdErr error) {
 		releaseDirFactory := func(dir DirOrCWDArg) (boshrel.Reader, boshreldir.ReleaseDir) {
 			releaseReader := relDirProv.NewReleaseReader(dir.Path, c.BoshOpts.Parallel)
 			releaseDir := relDirProv.NewFSReleaseDir(dir.Path, c.BoshOpts.Parallel)
-			return releaseReader, releaseDir
+			return opts.AsReleaseReader(releaseReader, deps.FS), releaseDir
 		}
 
 		releaseWriter := relProv.NewArchiveWriter()
@@ -228,6 +261,18 @@ func (c Cmd) Execute() (cmdErr error) {
 	case *InterpolateOpts:
 		return NewInterpolateCmd(deps.UI).Run(*opts)
 
+	case *RekeyVarsStoreOpts:
+		return NewRekeyVarsStoreCmd().Run(*opts)
+
+	case *RotateVarsOpts:
+		return NewRotateVarsCmd(deps.UI).Run(*opts)
+
+	case *CheckCertsOpts:
+		return NewCheckCertsCmd(deps.UI, deps.Time).Run(*opts)
+
+	case *ValidateOpsOpts:
+		return NewValidateOpsCmd(deps.UI).Run(*opts)
+
 	case *ConfigOpts:
 		return NewConfigCmd(deps.UI, c.director()).Run(*opts)
 
@@ -273,7 +318,26 @@ func (c Cmd) Execute() (cmdErr error) {
 		return NewEventCmd(deps.UI, c.director()).Run(*opts)
 
 	case *InspectReleaseOpts:
-		return NewInspectReleaseCmd(deps.UI, c.director()).Run(*opts)
+		if len(opts.Release) > 0 {
+			return NewInspectReleaseCmd(deps.UI, nil, c.localReleaseReader(opts.Release)).Run(*opts)
+		}
+
+		return NewInspectReleaseCmd(deps.UI, c.director(), nil).Run(*opts)
+
+	case *DiffReleasesOpts:
+		relProv, _ := c.releaseProviders()
+		return NewDiffReleasesCmd(relProv.NewExtractingArchiveReader(), deps.FS, deps.UI).Run(*opts)
+
+	case *SbomOpts:
+		_, relDirProv := c.releaseProviders()
+
+		inventoryFactory := func(dirPath string) boshreldir.Inventory {
+			return relDirProv.NewFSInventory(dirPath)
+		}
+
+		return NewSbomCmd(
+			c.localReleaseReader(opts.Args.Release), inventoryFactory,
+			deps.UUIDGen, deps.Time, deps.FS, deps.UI).Run(*opts)
 
 	case *VMsOpts:
 		return NewVMsCmd(deps.UI, c.director(), c.BoshOpts.Parallel).Run(*opts)
@@ -295,6 +359,9 @@ func (c Cmd) Execute() (cmdErr error) {
 		releaseManager := c.releaseManager(director)
 		return NewDeployCmd(deps.UI, deployment, releaseManager).Run(*opts)
 
+	case *DriftOpts:
+		return NewDriftCmd(deps.UI, c.deployment()).Run(*opts)
+
 	case *StartOpts:
 		return NewStartCmd(deps.UI, c.deployment()).Run(*opts)
 
@@ -352,6 +419,37 @@ func (c Cmd) Execute() (cmdErr error) {
 	case *VendorPackageOpts:
 		return NewVendorPackageCmd(c.releaseDir, deps.UI).Run(*opts)
 
+	case *LintReleaseOpts:
+		return NewLintReleaseCmd(c.releaseLinter(opts.Directory), deps.UI).Run()
+
+	case *ExplainFingerprintOpts:
+		_, relDirProv := c.releaseProviders()
+		explainer := relDirProv.NewFSFingerprintExplainer(opts.Directory.Path, c.BoshOpts.Parallel)
+		return NewExplainFingerprintCmd(explainer, deps.UI).Run(*opts)
+
+	case *CacheLsOpts:
+		return NewCacheLsCmd(c.compiledPackageCache(), deps.UI).Run()
+
+	case *CachePruneOpts:
+		return NewCachePruneCmd(c.compiledPackageCache(), deps.Time, deps.UI).Run(*opts)
+
+	case *SignReleaseOpts:
+		relProv, _ := c.releaseProviders()
+		return NewSignReleaseCmd(relProv.NewArchiveReader(), deps.FS, deps.UI).Run(*opts)
+
+	case *RenderJobOpts:
+		erbRenderer := bitemplateerb.NewERBRenderer(deps.FS, deps.CmdRunner, deps.Logger)
+		return NewRenderJobCmd(erbRenderer, deps.FS, deps.UUIDGen, deps.UI, deps.Logger).Run(*opts)
+
+	case *ValidatePropertiesOpts:
+		relProv, relDirProv := c.releaseProviders()
+
+		releaseDirFactory := func(path string) boshreldir.ReleaseDir {
+			return relDirProv.NewFSReleaseDir(path, c.BoshOpts.Parallel)
+		}
+
+		return NewValidatePropertiesCmd(relProv.NewExtractingArchiveReader(), releaseDirFactory, deps.FS, deps.UI).Run(*opts)
+
 	case *FinalizeReleaseOpts:
 		_, relDirProv := c.releaseProviders()
 		releaseReader := relDirProv.NewReleaseReader(opts.Directory.Path, c.BoshOpts.Parallel)
@@ -359,7 +457,7 @@ func (c Cmd) Execute() (cmdErr error) {
 		return NewFinalizeReleaseCmd(releaseReader, releaseDir, deps.UI).Run(*opts)
 
 	case *CreateReleaseOpts:
-		relProv, relDirProv := c.releaseProviders()
+		relProv, relDirProv := c.reproducibleReleaseProviders(opts.Reproducible)
 
 		releaseDirFactory := func(dir DirOrCWDArg) (boshrel.Reader, boshreldir.ReleaseDir) {
 			releaseReader := relDirProv.NewReleaseReader(dir.Path, c.BoshOpts.Parallel)
@@ -376,7 +474,7 @@ func (c Cmd) Execute() (cmdErr error) {
 		return err
 
 	case *Sha1ifyReleaseOpts:
-		relProv, _ := c.releaseProviders()
+		relProv, _ := c.reproducibleReleaseProviders(opts.Reproducible)
 
 		return NewRedigestReleaseCmd(
 			relProv.NewArchiveReader(),
@@ -388,7 +486,7 @@ func (c Cmd) Execute() (cmdErr error) {
 		).Run(opts.Args)
 
 	case *Sha2ifyReleaseOpts:
-		relProv, _ := c.releaseProviders()
+		relProv, _ := c.reproducibleReleaseProviders(opts.Reproducible)
 
 		return NewRedigestReleaseCmd(
 			relProv.NewArchiveReader(),
@@ -425,6 +523,69 @@ func (c Cmd) Execute() (cmdErr error) {
 		return fmt.Errorf("Unhandled command: %#v", c.Opts)
 	}
 }
+func (c Cmd) executeInEnvironments() error {
+	switch c.Opts.(type) {
+	case *DeploymentsOpts, *StemcellsOpts, *ReleasesOpts, *VMsOpts, *InstancesOpts, *TasksOpts, *LocksOpts:
+	default:
+		return bosherr.Error("Expected --environments or --all-environments to be used with one of " +
+			"'deployments', 'stemcells', 'releases', 'vms', 'instances', 'tasks' or 'locks' commands")
+	}
+
+	// Config is loaded once and only read by parallel runs
+	config := c.config()
+
+	environments := []string(c.BoshOpts.EnvironmentsOpt)
+
+	if c.BoshOpts.AllEnvironmentsOpt {
+		environments = nil
+
+		for _, env := range config.Environments() {
+			if len(env.Alias) > 0 {
+				environments = append(environments, env.Alias)
+			} else {
+				environments = append(environments, env.URL)
+			}
+		}
+	}
+
+	runner := func(environment string, ui boshui.UI) error {
+		envOpts := c.BoshOpts
+		envOpts.EnvironmentOpt = environment
+
+		// Global UI and FS setup already happened; session is built directly
+		// so that parallel runs do not share task offsets or reconfigure deps
+		context := NewSessionContextImpl(envOpts, config, c.deps.FS)
+		taskReporter := boshuit.NewReporter(ui, true)
+		sess := NewSessionImpl(context, ui, taskReporter, false, false, c.deps.Logger)
+
+		director, err := sess.Director()
+		if err != nil {
+			return err
+		}
+
+		switch opts := c.Opts.(type) {
+		case *DeploymentsOpts:
+			return NewDeploymentsCmd(ui, director).Run()
+		case *StemcellsOpts:
+			return NewStemcellsCmd(ui, director).Run()
+		case *ReleasesOpts:
+			return NewReleasesCmd(ui, director).Run()
+		case *VMsOpts:
+			return NewVMsCmd(ui, director, c.BoshOpts.Parallel).Run(*opts)
+		case *InstancesOpts:
+			return NewInstancesCmd(ui, director, c.BoshOpts.Parallel).Run(*opts)
+		case *TasksOpts:
+			return NewTasksCmd(ui, director).Run(*opts)
+		case *LocksOpts:
+			return NewLocksCmd(ui, director).Run()
+		default:
+			return fmt.Errorf("Unhandled command: %#v", c.Opts)
+		}
+	}
+
+	return NewFanOutCmd(c.deps.UI, runner).Run(environments)
+}
+
 func (c Cmd) configureUI() {
 	c.deps.UI.EnableTTY(c.BoshOpts.TTYOpt)
 
@@ -432,7 +593,10 @@ func (c Cmd) configureUI() {
 		c.deps.UI.EnableColor()
 	}
 
-	if c.BoshOpts.JSONOpt {
+	if c.BoshOpts.NDJSONOpt {
+		// Task events are written directly hence remaining output follows on a single line
+		c.deps.UI.EnableCompactJSON()
+	} else if c.BoshOpts.JSONOpt {
 		c.deps.UI.EnableJSON()
 	}
 
@@ -458,6 +622,14 @@ func (c Cmd) configureFS() {
 	c.panicIfErr(err)
 }
 
+func (c Cmd) compiledPackageCache() bipkgcache.Cache {
+	workspaceRootPath, err := c.deps.FS.ExpandPath(filepath.Join("~", ".bosh"))
+	c.panicIfErr(err)
+
+	return bipkgcache.NewFSCache(
+		compiledPackageCachePath(workspaceRootPath), runtime.GOOS, runtime.GOARCH, c.deps.FS, c.deps.Time, c.deps.Logger)
+}
+
 func (c Cmd) config() cmdconf.Config {
 	config, err := cmdconf.NewFSConfigFromPath(c.BoshOpts.ConfigPathOpt, c.deps.FS)
 	c.panicIfErr(err)
@@ -466,7 +638,7 @@ func (c Cmd) config() cmdconf.Config {
 }
 
 func (c Cmd) session() Session {
-	return NewSessionFromOpts(c.BoshOpts, c.config(), c.deps.UI, true, true, c.deps.FS, c.deps.Logger)
+	return NewSessionFromOpts(c.BoshOpts, c.config(), c.deps.UI, true, true, c.deps.FS, c.deps.Time, c.deps.Logger)
 }
 
 func (c Cmd) director() boshdir.Director {
@@ -496,12 +668,24 @@ func (c Cmd) directorAndDeployment() (boshdir.Director, boshdir.Deployment) {
 }
 
 func (c Cmd) releaseProviders() (boshrel.Provider, boshreldir.Provider) {
+	return c.releaseProvidersWithCompressor(c.deps.Compressor)
+}
+
+// reproducibleReleaseProviders build job, package and release tarballs with normalized metadata
+func (c Cmd) reproducibleReleaseProviders(reproducible bool) (boshrel.Provider, boshreldir.Provider) {
+	if reproducible {
+		return c.releaseProvidersWithCompressor(boshrel.NewReproducibleCompressor(c.deps.Compressor, c.deps.FS))
+	}
+	return c.releaseProviders()
+}
+
+func (c Cmd) releaseProvidersWithCompressor(compressor boshfu.Compressor) (boshrel.Provider, boshreldir.Provider) {
 	indexReporter := boshui.NewIndexReporter(c.deps.UI)
 	blobsReporter := boshui.NewBlobsReporter(c.deps.UI)
 	releaseIndexReporter := boshui.NewReleaseIndexReporter(c.deps.UI)
 
 	releaseProvider := boshrel.NewProvider(
-		c.deps.CmdRunner, c.deps.Compressor, c.deps.DigestCalculator, c.deps.FS, c.deps.Logger)
+		c.deps.CmdRunner, compressor, c.deps.DigestCalculator, c.deps.FS, c.deps.Logger)
 
 	releaseDirProvider := boshreldir.NewProvider(
 		indexReporter, releaseIndexReporter, blobsReporter, releaseProvider,
@@ -550,6 +734,24 @@ func (c Cmd) blobsDir(dir DirOrCWDArg) boshreldir.BlobsDir {
 	return relDirProv.NewFSBlobsDir(dir.Path)
 }
 
+// localReleaseReader reads release tarballs with job specs or release directories
+func (c Cmd) localReleaseReader(path string) boshrel.Reader {
+	relProv, _ := c.releaseProviders()
+
+	opts := boshrel.MultiReaderOpts{
+		ArchiveReader:  relProv.NewExtractingArchiveReader(),
+		ManifestReader: relProv.NewManifestReader(),
+		DirReader:      relProv.NewDirReader(path),
+	}
+
+	return boshrel.NewMultiReader(opts, c.deps.FS)
+}
+
+func (c Cmd) releaseLinter(dir DirOrCWDArg) boshreldir.Linter {
+	_, relDirProv := c.releaseProviders()
+	return relDirProv.NewFSLinter(dir.Path)
+}
+
 func (c Cmd) releaseDir(dir DirOrCWDArg) boshreldir.ReleaseDir {
 	_, relDirProv := c.releaseProviders()
 	return relDirProv.NewFSReleaseDir(dir.Path, c.BoshOpts.Parallel)
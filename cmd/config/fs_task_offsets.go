@@ -0,0 +1,167 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	"gopkg.in/yaml.v2"
+)
+
+/*
+environments:
+  https://192.168.50.4:25555:
+    123:
+      offset: 4567
+      updated_at: 2017-01-02T03:04:05Z
+*/
+
+// Offsets of tasks that were not followed to the end (e.g. interrupted CLI)
+// are forgotten after a while since such tasks are unlikely to be resumed
+const fsTaskOffsetsMaxAge = 7 * 24 * time.Hour
+
+var fsTaskOffsetsTmpSuffix uint64
+
+type FSTaskOffsets struct {
+	path        string
+	fs          boshsys.FileSystem
+	timeService clock.Clock
+
+	schema fsTaskOffsetsSchema
+}
+
+type fsTaskOffsetsSchema struct {
+	Environments map[string]map[int]fsTaskOffset `yaml:"environments"`
+}
+
+type fsTaskOffset struct {
+	Offset    int       `yaml:"offset"`
+	UpdatedAt time.Time `yaml:"updated_at"`
+}
+
+func NewFSTaskOffsetsFromPath(path string, fs boshsys.FileSystem, timeService clock.Clock) (*FSTaskOffsets, error) {
+	absPath, err := fs.ExpandPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	offsets := &FSTaskOffsets{path: absPath, fs: fs, timeService: timeService}
+
+	offsets.schema, err = offsets.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return offsets, nil
+}
+
+func (o *FSTaskOffsets) Offset(url string, id int) int {
+	return o.schema.Environments[url][id].Offset
+}
+
+func (o *FSTaskOffsets) SetOffset(url string, id, offset int) error {
+	return o.update(func(schema fsTaskOffsetsSchema) {
+		if schema.Environments[url] == nil {
+			schema.Environments[url] = map[int]fsTaskOffset{}
+		}
+
+		schema.Environments[url][id] = fsTaskOffset{Offset: offset, UpdatedAt: o.timeService.Now().UTC()}
+	})
+}
+
+func (o *FSTaskOffsets) UnsetOffset(url string, id int) error {
+	if _, found := o.schema.Environments[url][id]; !found {
+		return nil
+	}
+
+	return o.update(func(schema fsTaskOffsetsSchema) {
+		delete(schema.Environments[url], id)
+	})
+}
+
+// update applies change on top of offsets currently saved so that
+// offsets of tasks followed by other CLI processes are kept
+func (o *FSTaskOffsets) update(change func(fsTaskOffsetsSchema)) error {
+	schema, err := o.load()
+	if err != nil {
+		// Unreadable offsets cannot be resumed from hence are replaced
+		schema = fsTaskOffsetsSchema{}
+	}
+
+	if schema.Environments == nil {
+		schema.Environments = map[string]map[int]fsTaskOffset{}
+	}
+
+	change(schema)
+
+	staleTime := o.timeService.Now().Add(-fsTaskOffsetsMaxAge)
+
+	for url, offsets := range schema.Environments {
+		for id, offset := range offsets {
+			if offset.UpdatedAt.Before(staleTime) {
+				delete(offsets, id)
+			}
+		}
+
+		if len(offsets) == 0 {
+			delete(schema.Environments, url)
+		}
+	}
+
+	err = o.save(schema)
+	if err != nil {
+		return err
+	}
+
+	o.schema = schema
+
+	return nil
+}
+
+func (o *FSTaskOffsets) load() (fsTaskOffsetsSchema, error) {
+	var schema fsTaskOffsetsSchema
+
+	if !o.fs.FileExists(o.path) {
+		return schema, nil
+	}
+
+	bytes, err := o.fs.ReadFile(o.path)
+	if err != nil {
+		return schema, bosherr.WrapErrorf(err, "Reading task offsets '%s'", o.path)
+	}
+
+	err = yaml.Unmarshal(bytes, &schema)
+	if err != nil {
+		return schema, bosherr.WrapError(err, "Unmarshalling task offsets")
+	}
+
+	return schema, nil
+}
+
+// save writes offsets to a temporary file first so that
+// interrupted writes do not leave truncated offsets behind
+func (o *FSTaskOffsets) save(schema fsTaskOffsetsSchema) error {
+	bytes, err := yaml.Marshal(schema)
+	if err != nil {
+		return bosherr.WrapError(err, "Marshalling task offsets")
+	}
+
+	tmpPath := fmt.Sprintf("%s.%d-%d.tmp", o.path, os.Getpid(), atomic.AddUint64(&fsTaskOffsetsTmpSuffix, 1))
+
+	err = o.fs.WriteFile(tmpPath, bytes)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing task offsets '%s'", tmpPath)
+	}
+
+	err = o.fs.Rename(tmpPath, o.path)
+	if err != nil {
+		_ = o.fs.RemoveAll(tmpPath)
+		return bosherr.WrapErrorf(err, "Renaming task offsets '%s'", tmpPath)
+	}
+
+	return nil
+}
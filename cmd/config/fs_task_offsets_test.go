@@ -0,0 +1,156 @@
+package config_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd/config"
+)
+
+var _ = Describe("FSTaskOffsets", func() {
+	var (
+		fs          *fakesys.FakeFileSystem
+		timeService *fakeclock.FakeClock
+	)
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+		fs.MkdirAll("/config", 0700)
+		timeService = fakeclock.NewFakeClock(time.Date(2017, time.January, 2, 3, 4, 5, 0, time.UTC))
+	})
+
+	newOffsets := func() *FSTaskOffsets {
+		offsets, err := NewFSTaskOffsetsFromPath("/config/task_offsets", fs, timeService)
+		Expect(err).ToNot(HaveOccurred())
+		return offsets
+	}
+
+	It("returns zero offset if file does not exist", func() {
+		Expect(newOffsets().Offset("url", 123)).To(Equal(0))
+	})
+
+	It("saves offsets per environment and task", func() {
+		offsets := newOffsets()
+
+		Expect(offsets.SetOffset("url1", 123, 10)).ToNot(HaveOccurred())
+		Expect(offsets.SetOffset("url2", 123, 20)).ToNot(HaveOccurred())
+
+		offsets = newOffsets()
+		Expect(offsets.Offset("url1", 123)).To(Equal(10))
+		Expect(offsets.Offset("url2", 123)).To(Equal(20))
+		Expect(offsets.Offset("url2", 124)).To(Equal(0))
+
+		Expect(fs.ReadFileString("/config/task_offsets")).To(Equal(`environments:
+  url1:
+    123:
+      offset: 10
+      updated_at: 2017-01-02T03:04:05Z
+  url2:
+    123:
+      offset: 20
+      updated_at: 2017-01-02T03:04:05Z
+`))
+	})
+
+	It("writes offsets to temporary file before renaming it", func() {
+		Expect(newOffsets().SetOffset("url", 123, 10)).ToNot(HaveOccurred())
+
+		Expect(fs.RenameOldPaths).To(HaveLen(1))
+		Expect(fs.RenameOldPaths[0]).To(MatchRegexp(`^/config/task_offsets\.\d+-\d+\.tmp$`))
+		Expect(fs.RenameNewPaths).To(Equal([]string{"/config/task_offsets"}))
+		Expect(fs.FileExists(fs.RenameOldPaths[0])).To(BeFalse())
+	})
+
+	It("keeps offsets saved by others in the meantime", func() {
+		offsets1 := newOffsets()
+		offsets2 := newOffsets()
+
+		Expect(offsets1.SetOffset("url", 123, 10)).ToNot(HaveOccurred())
+		Expect(offsets2.SetOffset("url", 124, 20)).ToNot(HaveOccurred())
+		Expect(offsets2.UnsetOffset("url", 124)).ToNot(HaveOccurred())
+		Expect(offsets1.SetOffset("url", 123, 30)).ToNot(HaveOccurred())
+
+		offsets := newOffsets()
+		Expect(offsets.Offset("url", 123)).To(Equal(30))
+		Expect(offsets.Offset("url", 124)).To(Equal(0))
+	})
+
+	It("forgets offsets that were not updated for a week", func() {
+		offsets := newOffsets()
+
+		Expect(offsets.SetOffset("url1", 123, 10)).ToNot(HaveOccurred())
+
+		timeService.Increment(8 * 24 * time.Hour)
+
+		Expect(offsets.SetOffset("url2", 124, 20)).ToNot(HaveOccurred())
+
+		offsets = newOffsets()
+		Expect(offsets.Offset("url1", 123)).To(Equal(0))
+		Expect(offsets.Offset("url2", 124)).To(Equal(20))
+	})
+
+	It("removes offsets", func() {
+		offsets := newOffsets()
+
+		Expect(offsets.SetOffset("url", 123, 10)).ToNot(HaveOccurred())
+		Expect(offsets.UnsetOffset("url", 123)).ToNot(HaveOccurred())
+		Expect(offsets.UnsetOffset("url", 124)).ToNot(HaveOccurred())
+
+		Expect(newOffsets().Offset("url", 123)).To(Equal(0))
+		Expect(fs.ReadFileString("/config/task_offsets")).To(Equal("environments: {}\n"))
+	})
+
+	It("replaces offsets that cannot be unmarshaled when saving", func() {
+		offsets := newOffsets()
+
+		fs.WriteFileString("/config/task_offsets", "-")
+
+		Expect(offsets.SetOffset("url", 123, 10)).ToNot(HaveOccurred())
+		Expect(newOffsets().Offset("url", 123)).To(Equal(10))
+	})
+
+	It("returns error if expanding path fails", func() {
+		fs.ExpandPathErr = errors.New("fake-err")
+
+		_, err := NewFSTaskOffsetsFromPath("/config/task_offsets", fs, timeService)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+
+	It("returns error if file cannot be unmarshaled", func() {
+		fs.WriteFileString("/config/task_offsets", "-")
+
+		_, err := NewFSTaskOffsetsFromPath("/config/task_offsets", fs, timeService)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Unmarshalling task offsets"))
+	})
+
+	It("returns error if writing file fails", func() {
+		offsets := newOffsets()
+
+		fs.WriteFileError = errors.New("fake-err")
+
+		err := offsets.SetOffset("url", 123, 10)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+
+	It("returns error and keeps saved offsets if renaming file fails", func() {
+		offsets := newOffsets()
+
+		Expect(offsets.SetOffset("url", 123, 10)).ToNot(HaveOccurred())
+
+		fs.RenameError = errors.New("fake-err")
+
+		err := offsets.SetOffset("url", 123, 20)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+
+		Expect(newOffsets().Offset("url", 123)).To(Equal(10))
+	})
+})
@@ -20,3 +20,11 @@ type Environment struct {
 	URL   string
 	Alias string
 }
+
+//go:generate counterfeiter . TaskOffsets
+
+type TaskOffsets interface {
+	Offset(url string, id int) int
+	SetOffset(url string, id, offset int) error
+	UnsetOffset(url string, id int) error
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 	"github.com/cppforlife/go-patch/patch"
 
 	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
@@ -17,10 +18,31 @@ func NewCreateEnvCmd(ui boshui.UI, envProvider func(string, string, boshtpl.Vari
 }
 
 func (c *CreateEnvCmd) Run(stage boshui.Stage, opts CreateEnvOpts) error {
+	cpiFlags := 0
+	for _, path := range []string{opts.RecordCPI, opts.ReplayCPI, opts.FakeCPI} {
+		if len(path) > 0 {
+			cpiFlags++
+		}
+	}
+
+	if cpiFlags > 1 {
+		return bosherr.Error("Expected only one of '--record-cpi', '--replay-cpi' or '--fake-cpi' to be given")
+	}
+
 	c.ui.BeginLinef("Deployment manifest: '%s'\n", opts.Args.Manifest.Path)
 
+	op := opts.OpsFlags.AsOp()
+
+	if opts.StrictOps {
+		op = opts.OpsFlags.AsStrictOp()
+	}
+
 	depPreparer := c.envProvider(
-		opts.Args.Manifest.Path, opts.StatePath, opts.VarFlags.AsVariables(), opts.OpsFlags.AsOp())
+		opts.Args.Manifest.Path, opts.StatePath, opts.VarFlags.AsVariables(), op)
+
+	if opts.Plan {
+		return depPreparer.PlanDeployment(stage, opts.Recreate)
+	}
 
 	return depPreparer.PrepareDeployment(stage, opts.Recreate)
 }
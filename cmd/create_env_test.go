@@ -18,9 +18,6 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
 
-	mock_httpagent "github.com/cloudfoundry/bosh-agent/agentclient/http/mocks"
-	mock_agentclient "github.com/cloudfoundry/bosh-cli/agentclient/mocks"
-	mock_blobstore "github.com/cloudfoundry/bosh-cli/blobstore/mocks"
 	bicloud "github.com/cloudfoundry/bosh-cli/cloud"
 	fakebicloud "github.com/cloudfoundry/bosh-cli/cloud/fakes"
 	mock_cloud "github.com/cloudfoundry/bosh-cli/cloud/mocks"
@@ -36,8 +33,6 @@ import (
 	mock_deployment "github.com/cloudfoundry/bosh-cli/deployment/mocks"
 	bidepltpl "github.com/cloudfoundry/bosh-cli/deployment/template"
 	fakebidepltpl "github.com/cloudfoundry/bosh-cli/deployment/template/templatefakes"
-	fakebivm "github.com/cloudfoundry/bosh-cli/deployment/vm/fakes"
-	mock_vm "github.com/cloudfoundry/bosh-cli/deployment/vm/mocks"
 	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	biinstall "github.com/cloudfoundry/bosh-cli/installation"
 	biinstallmanifest "github.com/cloudfoundry/bosh-cli/installation/manifest"
@@ -88,18 +83,14 @@ var _ = Describe("CreateEnvCmd", func() {
 			releaseManager            biinstall.ReleaseManager
 			mockRegistryServerManager *mock_registry.MockServerManager
 			mockRegistryServer        *mock_registry.MockServer
-			mockAgentClient           *mock_agentclient.MockAgentClient
-			mockAgentClientFactory    *mock_httpagent.MockAgentClientFactory
 			mockCloudFactory          *mock_cloud.MockFactory
 
 			cpiRelease *fakebirel.FakeRelease
 			logger     boshlog.Logger
 
-			mockBlobstoreFactory *mock_blobstore.MockFactory
-			mockBlobstore        *mock_blobstore.MockBlobstore
+			mockInstanceEnvProviderFactory *mock_deployment.MockInstanceEnvProviderFactory
+			mockInstanceEnvProvider        *mock_deployment.MockInstanceEnvProvider
 
-			mockVMManagerFactory       *mock_vm.MockManagerFactory
-			fakeVMManager              *fakebivm.FakeManager
 			fakeStemcellExtractor      *fakebistemcell.FakeExtractor
 			mockStemcellManager        *mock_stemcell.MockManager
 			fakeStemcellManagerFactory *fakebistemcell.FakeManagerFactory
@@ -170,19 +161,11 @@ var _ = Describe("CreateEnvCmd", func() {
 			mockRegistryServerManager = mock_registry.NewMockServerManager(mockCtrl)
 			mockRegistryServer = mock_registry.NewMockServer(mockCtrl)
 
-			mockAgentClientFactory = mock_httpagent.NewMockAgentClientFactory(mockCtrl)
-			mockAgentClient = mock_agentclient.NewMockAgentClient(mockCtrl)
-			mockAgentClientFactory.EXPECT().NewAgentClient(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockAgentClient, nil).AnyTimes()
-
 			mockCloudFactory = mock_cloud.NewMockFactory(mockCtrl)
 
-			mockBlobstoreFactory = mock_blobstore.NewMockFactory(mockCtrl)
-			mockBlobstore = mock_blobstore.NewMockBlobstore(mockCtrl)
-			mockBlobstoreFactory.EXPECT().Create(mbusURL, gomock.Any()).Return(mockBlobstore, nil).AnyTimes()
-
-			mockVMManagerFactory = mock_vm.NewMockManagerFactory(mockCtrl)
-			fakeVMManager = fakebivm.NewFakeManager()
-			mockVMManagerFactory.EXPECT().NewManager(gomock.Any(), mockAgentClient).Return(fakeVMManager).AnyTimes()
+			mockInstanceEnvProviderFactory = mock_deployment.NewMockInstanceEnvProviderFactory(mockCtrl)
+			mockInstanceEnvProvider = mock_deployment.NewMockInstanceEnvProvider(mockCtrl)
+			mockInstanceEnvProviderFactory.EXPECT().NewProvider(gomock.Any(), gomock.Any(), mbusURL, gomock.Any()).Return(mockInstanceEnvProvider).AnyTimes()
 
 			fakeStemcellExtractor = fakebistemcell.NewFakeExtractor()
 			mockStemcellManager = mock_stemcell.NewMockManager(mockCtrl)
@@ -267,7 +250,8 @@ var _ = Describe("CreateEnvCmd", func() {
 				Name: "fake-deployment-name",
 				Jobs: []bideplmanifest.Job{
 					{
-						Name: "fake-job-name",
+						Name:      "fake-job-name",
+						Instances: 1,
 					},
 				},
 				ResourcePools: []bideplmanifest.ResourcePool{
@@ -366,9 +350,7 @@ var _ = Describe("CreateEnvCmd", func() {
 					deploymentRecord,
 					mockCloudFactory,
 					fakeStemcellManagerFactory,
-					mockAgentClientFactory,
-					mockVMManagerFactory,
-					mockBlobstoreFactory,
+					mockInstanceEnvProviderFactory,
 					mockDeployer,
 					deploymentManifestPath,
 					deploymentVars,
@@ -427,10 +409,9 @@ var _ = Describe("CreateEnvCmd", func() {
 				boshDeploymentManifest,
 				cloudStemcell,
 				installationManifest.Registry,
-				fakeVMManager,
-				mockBlobstore,
+				mockInstanceEnvProvider,
 				gomock.Any(),
-			).Do(func(_, _, _, _, _, _ interface{}, stage biui.Stage) {
+			).Do(func(_, _, _, _, _ interface{}, stage biui.Stage) {
 				Expect(fakeStage.SubStages).To(ContainElement(stage))
 			}).Return(mockDeployment, nil).AnyTimes()
 
@@ -708,6 +689,97 @@ var _ = Describe("CreateEnvCmd", func() {
 			})
 		})
 
+		Context("when more than one of --record-cpi, --replay-cpi and --fake-cpi is specified", func() {
+			BeforeEach(func() {
+				defaultCreateEnvOpts.RecordCPI = "/fake-recordings"
+				defaultCreateEnvOpts.FakeCPI = "/fake-cpi"
+			})
+
+			It("returns an error without installing CPI or deploying", func() {
+				expectInstall.Times(0)
+				expectDeploy.Times(0)
+
+				err := command.Run(fakeStage, defaultCreateEnvOpts)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Expected only one of '--record-cpi', '--replay-cpi' or '--fake-cpi' to be given"))
+			})
+		})
+
+		Context("when --plan is specified", func() {
+			BeforeEach(func() {
+				defaultCreateEnvOpts.Plan = true
+			})
+
+			It("shows CPI actions without installing CPI or deploying", func() {
+				expectLegacyMigrate.Times(0)
+				expectInstall.Times(0)
+				expectStemcellUpload.Times(0)
+				expectDeploy.Times(0)
+
+				err := command.Run(fakeStage, defaultCreateEnvOpts)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(stdOut).To(gbytes.Say("create_stemcell"))
+				Expect(stdOut).To(gbytes.Say("Upload stemcell 'fake-stemcell-name/fake-stemcell-version'"))
+				Expect(stdOut).To(gbytes.Say("create_vm"))
+				Expect(stdOut).To(gbytes.Say("Create VM for instance 'fake-job-name/0'"))
+			})
+
+			It("does not create a deployment state", func() {
+				err := command.Run(fakeStage, defaultCreateEnvOpts)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fs.FileExists(deploymentStatePath)).To(BeFalse())
+			})
+
+			It("validates deployment", func() {
+				err := command.Run(fakeStage, defaultCreateEnvOpts)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeStage.PerformCalls[0].Name).To(Equal("validating"))
+			})
+
+			Context("when deployment has not changed", func() {
+				JustBeforeEach(func() {
+					err := setupDeploymentStateService.Save(biconfig.DeploymentState{
+						DirectorID:        directorID,
+						CurrentVMCID:      "fake-vm-cid",
+						CurrentReleaseIDs: []string{"my-release-id-1"},
+						Releases: []biconfig.ReleaseRecord{{
+							ID:      "my-release-id-1",
+							Name:    cpiRelease.Name(),
+							Version: cpiRelease.Version(),
+						}},
+						CurrentStemcellID: "my-stemcellRecordID",
+						Stemcells: []biconfig.StemcellRecord{{
+							ID:      "my-stemcellRecordID",
+							Name:    cloudStemcell.Name(),
+							Version: cloudStemcell.Version(),
+						}},
+						CurrentManifestSHA: manifestSHA,
+					})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("reports that there is nothing to deploy", func() {
+					err := command.Run(fakeStage, defaultCreateEnvOpts)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(stdOut).To(gbytes.Say("No deployment, stemcell or release changes. Nothing to deploy."))
+				})
+
+				It("shows VM recreation if recreate flag is specified", func() {
+					defaultCreateEnvOpts.Recreate = true
+
+					err := command.Run(fakeStage, defaultCreateEnvOpts)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(stdOut).To(gbytes.Say("delete_vm"))
+					Expect(stdOut).To(gbytes.Say("Delete VM 'fake-vm-cid'"))
+					Expect(stdOut).To(gbytes.Say("recreate was requested"))
+				})
+			})
+		})
+
 		Context("when parsing the cpi deployment manifest fails", func() {
 			JustBeforeEach(func() {
 				manifest := bideplmanifest.Manifest{}
@@ -1047,8 +1119,7 @@ var _ = Describe("CreateEnvCmd", func() {
 					boshDeploymentManifest,
 					cloudStemcell,
 					installationManifest.Registry,
-					fakeVMManager,
-					mockBlobstore,
+					mockInstanceEnvProvider,
 					gomock.Any(),
 				).Return(nil, errors.New("fake-deploy-error")).AnyTimes()
 
@@ -55,6 +55,13 @@ func (c CreateReleaseCmd) Run(opts CreateReleaseOpts) (boshrel.Release, error) {
 		}
 	}
 
+	if opts.Reproducible {
+		err = c.verifyReproducible(release)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	dstPath := opts.Tarball.ExpandedPath
 
 	if dstPath != "" {
@@ -115,3 +122,37 @@ func (c CreateReleaseCmd) finalizeRelease(releaseDir boshreldir.ReleaseDir, rele
 
 	return releaseDir.FinalizeRelease(release, opts.Force)
 }
+
+// verifyReproducible checks jobs and packages reused from dev or final builds
+// since only newly built archives are normalized
+func (c CreateReleaseCmd) verifyReproducible(release boshrel.Release) error {
+	for _, job := range release.Jobs() {
+		err := boshrel.VerifyReproducibleArchive(job.ArchivePath(), c.fs)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Expected previously built job '%s' to be reproducible", job.Name())
+		}
+	}
+
+	for _, pkg := range release.Packages() {
+		err := boshrel.VerifyReproducibleArchive(pkg.ArchivePath(), c.fs)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Expected previously built package '%s' to be reproducible", pkg.Name())
+		}
+	}
+
+	for _, compiledPkg := range release.CompiledPackages() {
+		err := boshrel.VerifyReproducibleArchive(compiledPkg.ArchivePath(), c.fs)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Expected previously built compiled package '%s' to be reproducible", compiledPkg.Name())
+		}
+	}
+
+	if lic := release.License(); lic != nil {
+		err := boshrel.VerifyReproducibleArchive(lic.ArchivePath(), c.fs)
+		if err != nil {
+			return bosherr.WrapError(err, "Expected previously built license to be reproducible")
+		}
+	}
+
+	return nil
+}
@@ -1,7 +1,11 @@
 package cmd_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"errors"
+	"time"
 
 	semver "github.com/cppforlife/go-semi-semantic/version"
 	. "github.com/onsi/ginkgo"
@@ -9,7 +13,9 @@ import (
 
 	. "github.com/cloudfoundry/bosh-cli/cmd"
 	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
 	fakerel "github.com/cloudfoundry/bosh-cli/release/releasefakes"
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
 	boshreldir "github.com/cloudfoundry/bosh-cli/releasedir"
 	fakereldir "github.com/cloudfoundry/bosh-cli/releasedir/releasedirfakes"
 	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
@@ -108,6 +114,41 @@ var _ = Describe("CreateReleaseCmd", func() {
 				Expect(err.Error()).To(ContainSubstring("fake-err"))
 			})
 
+			Context("when reproducible", func() {
+				BeforeEach(func() {
+					opts.Reproducible = true
+
+					release.JobsReturns([]*boshjob.Job{
+						boshjob.NewJob(boshres.NewResourceWithBuiltArchive("job", "job-fp", "/job.tgz", "job-sha1")),
+					})
+				})
+
+				It("succeeds if previously built jobs and packages are reproducible", func() {
+					var buf bytes.Buffer
+
+					gzipWriter := gzip.NewWriter(&buf)
+					tarWriter := tar.NewWriter(gzipWriter)
+					Expect(tarWriter.WriteHeader(&tar.Header{Name: "job.MF", Mode: 0644, ModTime: time.Unix(0, 0)})).To(Succeed())
+					Expect(tarWriter.Close()).To(Succeed())
+					Expect(gzipWriter.Close()).To(Succeed())
+
+					fakeFS.WriteFile("/job.tgz", buf.Bytes())
+
+					err := act()
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("returns error if previously built job is not reproducible", func() {
+					fakeFS.WriteFileString("/job.tgz", "job")
+
+					err := act()
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("Expected previously built job 'job' to be reproducible"))
+
+					Expect(fakeWriter.WriteCallCount()).To(Equal(0))
+				})
+			})
+
 			Context("with tarball", func() {
 				BeforeEach(func() {
 					opts.Tarball = FileArg{ExpandedPath: "/tarball-destination.tgz"}
@@ -108,6 +108,27 @@ var _ = Describe("DeleteCmd", func() {
 			})
 		})
 
+		Context("when --plan is specified", func() {
+			It("asks the deleter for a plan instead of deleting", func() {
+				mockDeploymentDeleter.EXPECT().PlanDeleteDeployment(fakeStage).Return(nil)
+				err := newDeleteCmd().Run(fakeStage, bicmd.DeleteEnvOpts{
+					Plan: true,
+					Args: bicmd.DeleteEnvArgs{
+						Manifest: bicmd.FileBytesWithPathArg{Path: deploymentManifestPath},
+					},
+					VarFlags: bicmd.VarFlags{
+						VarKVs: []boshtpl.VarKV{{Name: "key", Value: "value"}},
+					},
+					OpsFlags: bicmd.OpsFlags{
+						OpsFiles: []bicmd.OpsFileArg{
+							{Ops: patch.Ops([]patch.Op{patch.ErrOp{}})},
+						},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+
 		Context("when the deployment deleter returns an error", func() {
 			It("sends the manifest on to the deleter", func() {
 				err := bosherr.Error("boom")
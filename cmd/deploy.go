@@ -29,7 +29,13 @@ func NewDeployCmd(
 func (c DeployCmd) Run(opts DeployOpts) error {
 	tpl := boshtpl.NewTemplate(opts.Args.Manifest.Bytes)
 
-	bytes, err := tpl.Evaluate(opts.VarFlags.AsVariables(), opts.OpsFlags.AsOp(), boshtpl.EvaluateOpts{})
+	op := opts.OpsFlags.AsOp()
+
+	if opts.StrictOps {
+		op = opts.OpsFlags.AsStrictOp()
+	}
+
+	bytes, err := tpl.Evaluate(opts.VarFlags.AsVariables(), op, boshtpl.EvaluateOpts{})
 	if err != nil {
 		return bosherr.WrapErrorf(err, "Evaluating manifest")
 	}
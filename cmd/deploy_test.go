@@ -127,6 +127,29 @@ var _ = Describe("DeployCmd", func() {
 			Expect(bytes).To(Equal([]byte("name: dep\nname1: val1-from-kv\nname2: val2-from-file\nxyz: val\n")))
 		})
 
+		It("does not deploy if strict ops are requested and operations do not apply", func() {
+			opts.Args.Manifest = FileBytesArg{
+				Bytes: []byte("name: dep\n"),
+			}
+
+			opts.OpsFiles = []OpsFileArg{
+				{
+					FilePath: "/ops.yml",
+					Ops: patch.Ops([]patch.Op{
+						patch.RemoveOp{Path: patch.MustNewPointerFromString("/xyz?")},
+					}),
+				},
+			}
+
+			opts.StrictOps = true
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Ops file '/ops.yml' operation [0] (remove /xyz?)"))
+
+			Expect(deployment.UpdateCallCount()).To(Equal(0))
+		})
+
 		It("does not deploy if name specified in the manifest does not match deployment's name", func() {
 			opts.Args.Manifest = FileBytesArg{
 				Bytes: []byte("name: other-name"),
@@ -1,13 +1,10 @@
 package cmd
 
 import (
-	bihttpagent "github.com/cloudfoundry/bosh-agent/agentclient/http"
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
-	bihttpclient "github.com/cloudfoundry/bosh-utils/httpclient"
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 	"github.com/cppforlife/go-patch/patch"
 
-	biblobstore "github.com/cloudfoundry/bosh-cli/blobstore"
 	bicloud "github.com/cloudfoundry/bosh-cli/cloud"
 	biconfig "github.com/cloudfoundry/bosh-cli/config"
 	bicpirel "github.com/cloudfoundry/bosh-cli/cpi/release"
@@ -22,6 +19,7 @@ import (
 
 type DeploymentDeleter interface {
 	DeleteDeployment(stage biui.Stage) (err error)
+	PlanDeleteDeployment(stage biui.Stage) (err error)
 }
 
 func NewDeploymentDeleter(
@@ -31,8 +29,7 @@ func NewDeploymentDeleter(
 	deploymentStateService biconfig.DeploymentStateService,
 	releaseManager boshinst.ReleaseManager,
 	cloudFactory bicloud.Factory,
-	agentClientFactory bihttpagent.AgentClientFactory,
-	blobstoreFactory biblobstore.Factory,
+	instanceEnvProviderFactory bidepl.InstanceEnvProviderFactory,
 	deploymentManagerFactory bidepl.ManagerFactory,
 	deploymentManifestPath string,
 	deploymentVars boshtpl.Variables,
@@ -51,8 +48,7 @@ func NewDeploymentDeleter(
 		deploymentStateService:                  deploymentStateService,
 		releaseManager:                          releaseManager,
 		cloudFactory:                            cloudFactory,
-		agentClientFactory:                      agentClientFactory,
-		blobstoreFactory:                        blobstoreFactory,
+		instanceEnvProviderFactory:              instanceEnvProviderFactory,
 		deploymentManagerFactory:                deploymentManagerFactory,
 		deploymentManifestPath:                  deploymentManifestPath,
 		deploymentVars:                          deploymentVars,
@@ -73,8 +69,7 @@ type deploymentDeleter struct {
 	deploymentStateService                  biconfig.DeploymentStateService
 	releaseManager                          boshinst.ReleaseManager
 	cloudFactory                            bicloud.Factory
-	agentClientFactory                      bihttpagent.AgentClientFactory
-	blobstoreFactory                        biblobstore.Factory
+	instanceEnvProviderFactory              bidepl.InstanceEnvProviderFactory
 	deploymentManagerFactory                bidepl.ManagerFactory
 	deploymentManifestPath                  string
 	deploymentVars                          boshtpl.Variables
@@ -87,6 +82,24 @@ type deploymentDeleter struct {
 	targetProvider                          biinstall.TargetProvider
 }
 
+func (c *deploymentDeleter) PlanDeleteDeployment(stage biui.Stage) error {
+	c.ui.BeginLinef("Deployment state: '%s'\n", c.deploymentStateService.Path())
+
+	if !c.deploymentStateService.Exists() {
+		c.ui.BeginLinef("No deployment state file found.\n")
+		return nil
+	}
+
+	deploymentState, err := c.deploymentStateService.Load()
+	if err != nil {
+		return bosherr.WrapError(err, "Loading deployment state")
+	}
+
+	c.ui.PrintTable(deploymentPlanTable(bidepl.NewDeletePlan(deploymentState)))
+
+	return nil
+}
+
 func (c *deploymentDeleter) DeleteDeployment(stage biui.Stage) (err error) {
 	c.ui.BeginLinef("Deployment state: '%s'\n", c.deploymentStateService.Path())
 
@@ -208,18 +221,14 @@ func (c *deploymentDeleter) deploymentManager(installation biinstall.Installatio
 		return nil, bosherr.WrapError(err, "Creating CPI client from CPI installation")
 	}
 
-	c.logger.Debug(c.logTag, "Creating agent client...")
-
-	agentClient, _ := c.agentClientFactory.NewAgentClient(directorID, installationMbus, caCert)
+	c.logger.Debug(c.logTag, "Creating deployment manager...")
 
-	c.logger.Debug(c.logTag, "Creating blobstore client...")
+	instanceEnvs := c.instanceEnvProviderFactory.NewProvider(cloud, directorID, installationMbus, caCert)
 
-	blobstore, err := c.blobstoreFactory.Create(installationMbus, bihttpclient.CreateDefaultClientInsecureSkipVerify())
+	deploymentManager, err := c.deploymentManagerFactory.NewManager(cloud, instanceEnvs)
 	if err != nil {
-		return nil, bosherr.WrapError(err, "Creating blobstore client")
+		return nil, bosherr.WrapError(err, "Creating deployment manager")
 	}
 
-	c.logger.Debug(c.logTag, "Creating deployment manager...")
-
-	return c.deploymentManagerFactory.NewManager(cloud, agentClient, blobstore), nil
+	return deploymentManager, nil
 }
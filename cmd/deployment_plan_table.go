@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	bidepl "github.com/cloudfoundry/bosh-cli/deployment"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+func deploymentPlanTable(plan bidepl.Plan) boshtbl.Table {
+	table := boshtbl.Table{
+		Content: "CPI actions",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Action"),
+			boshtbl.NewHeader("Description"),
+			boshtbl.NewHeader("Reason"),
+		},
+	}
+
+	for _, step := range plan.Steps {
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(step.Action),
+			boshtbl.NewValueString(step.Description),
+			boshtbl.NewValueString(step.Reason),
+		})
+	}
+
+	return table
+}
@@ -1,19 +1,15 @@
 package cmd
 
 import (
-	bihttpagent "github.com/cloudfoundry/bosh-agent/agentclient/http"
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
-	bihttpclient "github.com/cloudfoundry/bosh-utils/httpclient"
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 	"github.com/cppforlife/go-patch/patch"
 
-	biblobstore "github.com/cloudfoundry/bosh-cli/blobstore"
 	bicloud "github.com/cloudfoundry/bosh-cli/cloud"
 	biconfig "github.com/cloudfoundry/bosh-cli/config"
 	bicpirel "github.com/cloudfoundry/bosh-cli/cpi/release"
 	bidepl "github.com/cloudfoundry/bosh-cli/deployment"
 	bideplmanifest "github.com/cloudfoundry/bosh-cli/deployment/manifest"
-	bivm "github.com/cloudfoundry/bosh-cli/deployment/vm"
 	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	biinstall "github.com/cloudfoundry/bosh-cli/installation"
 	boshinst "github.com/cloudfoundry/bosh-cli/installation"
@@ -33,9 +29,7 @@ func NewDeploymentPreparer(
 	deploymentRecord bidepl.Record,
 	cloudFactory bicloud.Factory,
 	stemcellManagerFactory bistemcell.ManagerFactory,
-	agentClientFactory bihttpagent.AgentClientFactory,
-	vmManagerFactory bivm.ManagerFactory,
-	blobstoreFactory biblobstore.Factory,
+	instanceEnvProviderFactory bidepl.InstanceEnvProviderFactory,
 	deployer bidepl.Deployer,
 	deploymentManifestPath string,
 	deploymentVars boshtpl.Variables,
@@ -58,9 +52,7 @@ func NewDeploymentPreparer(
 		deploymentRecord:                        deploymentRecord,
 		cloudFactory:                            cloudFactory,
 		stemcellManagerFactory:                  stemcellManagerFactory,
-		agentClientFactory:                      agentClientFactory,
-		vmManagerFactory:                        vmManagerFactory,
-		blobstoreFactory:                        blobstoreFactory,
+		instanceEnvProviderFactory:              instanceEnvProviderFactory,
 		deployer:                                deployer,
 		deploymentManifestPath:                  deploymentManifestPath,
 		deploymentVars:                          deploymentVars,
@@ -85,9 +77,7 @@ type DeploymentPreparer struct {
 	deploymentRecord                        bidepl.Record
 	cloudFactory                            bicloud.Factory
 	stemcellManagerFactory                  bistemcell.ManagerFactory
-	agentClientFactory                      bihttpagent.AgentClientFactory
-	vmManagerFactory                        bivm.ManagerFactory
-	blobstoreFactory                        biblobstore.Factory
+	instanceEnvProviderFactory              bidepl.InstanceEnvProviderFactory
 	deployer                                bidepl.Deployer
 	deploymentManifestPath                  string
 	deploymentVars                          boshtpl.Variables
@@ -136,39 +126,7 @@ func (c *DeploymentPreparer) PrepareDeployment(stage biui.Stage, recreate bool)
 		}
 	}()
 
-	var (
-		extractedStemcell    bistemcell.ExtractedStemcell
-		deploymentManifest   bideplmanifest.Manifest
-		installationManifest biinstallmanifest.Manifest
-		manifestSHA          string
-	)
-	err = stage.PerformComplex("validating", func(stage biui.Stage) error {
-		var releaseSetManifest birelsetmanifest.Manifest
-		releaseSetManifest, installationManifest, err = c.releaseSetAndInstallationManifestParser.ReleaseSetAndInstallationManifest(c.deploymentManifestPath, c.deploymentVars, c.deploymentOp)
-		if err != nil {
-			return err
-		}
-
-		for _, releaseRef := range releaseSetManifest.Releases {
-			err = c.releaseFetcher.DownloadAndExtract(releaseRef, stage)
-			if err != nil {
-				return err
-			}
-		}
-
-		err := c.cpiInstaller.ValidateCpiRelease(installationManifest, stage)
-		if err != nil {
-			return err
-		}
-
-		deploymentManifest, manifestSHA, err = c.deploymentManifestParser.GetDeploymentManifest(c.deploymentManifestPath, c.deploymentVars, c.deploymentOp, releaseSetManifest, stage)
-		if err != nil {
-			return err
-		}
-
-		extractedStemcell, err = c.stemcellFetcher.GetStemcell(deploymentManifest, stage)
-		return err
-	})
+	extractedStemcell, deploymentManifest, installationManifest, manifestSHA, err := c.validate(stage)
 	if err != nil {
 		return err
 	}
@@ -206,6 +164,109 @@ func (c *DeploymentPreparer) PrepareDeployment(stage biui.Stage, recreate bool)
 
 }
 
+// PlanDeployment shows CPI actions that PrepareDeployment would take without
+// installing CPI or saving deployment state
+func (c *DeploymentPreparer) PlanDeployment(stage biui.Stage, recreate bool) error {
+	c.ui.BeginLinef("Deployment state: '%s'\n", c.deploymentStateService.Path())
+
+	var deploymentState biconfig.DeploymentState
+
+	// Loading non-existent deployment state saves it with generated director ID
+	stateExists := c.deploymentStateService.Exists()
+	if stateExists {
+		var err error
+
+		deploymentState, err = c.deploymentStateService.Load()
+		if err != nil {
+			return bosherr.WrapError(err, "Loading deployment state")
+		}
+	}
+
+	defer func() {
+		err := c.releaseManager.DeleteAll()
+		if err != nil {
+			c.logger.Warn(c.logTag, "Deleting all extracted releases: %s", err.Error())
+		}
+	}()
+
+	extractedStemcell, deploymentManifest, _, manifestSHA, err := c.validate(stage)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		deleteErr := extractedStemcell.Cleanup()
+		if deleteErr != nil {
+			c.logger.Warn(c.logTag, "Failed to delete extracted stemcell: %s", deleteErr.Error())
+		}
+	}()
+
+	if stateExists {
+		isDeployed, err := c.deploymentRecord.IsDeployed(manifestSHA, c.releaseManager.List(), extractedStemcell)
+		if err != nil {
+			return bosherr.WrapError(err, "Checking if deployment has changed")
+		}
+
+		if isDeployed && !recreate {
+			c.ui.BeginLinef("No deployment, stemcell or release changes. Nothing to deploy.\n")
+			return nil
+		}
+	}
+
+	plan, err := bidepl.NewDeployPlan(
+		deploymentState,
+		deploymentManifest,
+		manifestSHA,
+		extractedStemcell.Manifest(),
+		c.releaseManager.List(),
+		recreate,
+	)
+	if err != nil {
+		return err
+	}
+
+	c.ui.PrintTable(deploymentPlanTable(plan))
+
+	return nil
+}
+
+func (c *DeploymentPreparer) validate(stage biui.Stage) (
+	extractedStemcell bistemcell.ExtractedStemcell,
+	deploymentManifest bideplmanifest.Manifest,
+	installationManifest biinstallmanifest.Manifest,
+	manifestSHA string,
+	err error,
+) {
+	err = stage.PerformComplex("validating", func(stage biui.Stage) error {
+		var releaseSetManifest birelsetmanifest.Manifest
+		releaseSetManifest, installationManifest, err = c.releaseSetAndInstallationManifestParser.ReleaseSetAndInstallationManifest(c.deploymentManifestPath, c.deploymentVars, c.deploymentOp)
+		if err != nil {
+			return err
+		}
+
+		for _, releaseRef := range releaseSetManifest.Releases {
+			err = c.releaseFetcher.DownloadAndExtract(releaseRef, stage)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := c.cpiInstaller.ValidateCpiRelease(installationManifest, stage)
+		if err != nil {
+			return err
+		}
+
+		deploymentManifest, manifestSHA, err = c.deploymentManifestParser.GetDeploymentManifest(c.deploymentManifestPath, c.deploymentVars, c.deploymentOp, releaseSetManifest, stage)
+		if err != nil {
+			return err
+		}
+
+		extractedStemcell, err = c.stemcellFetcher.GetStemcell(deploymentManifest, stage)
+		return err
+	})
+
+	return
+}
+
 func (c *DeploymentPreparer) deploy(
 	installation biinstall.Installation,
 	deploymentState biconfig.DeploymentState,
@@ -227,16 +288,7 @@ func (c *DeploymentPreparer) deploy(
 		return err
 	}
 
-	agentClient, err := c.agentClientFactory.NewAgentClient(deploymentState.DirectorID, installationManifest.Mbus, installationManifest.Cert.CA)
-	if err != nil {
-		return err
-	}
-	vmManager := c.vmManagerFactory.NewManager(cloud, agentClient)
-
-	blobstore, err := c.blobstoreFactory.Create(installationManifest.Mbus, bihttpclient.CreateDefaultClientInsecureSkipVerify())
-	if err != nil {
-		return bosherr.WrapError(err, "Creating blobstore client")
-	}
+	instanceEnvs := c.instanceEnvProviderFactory.NewProvider(cloud, deploymentState.DirectorID, installationManifest.Mbus, installationManifest.Cert.CA)
 
 	err = stage.PerformComplex("deploying", func(deployStage biui.Stage) error {
 		err = c.deploymentRecord.Clear()
@@ -249,8 +301,7 @@ func (c *DeploymentPreparer) deploy(
 			deploymentManifest,
 			cloudStemcell,
 			installationManifest.Registry,
-			vmManager,
-			blobstore,
+			instanceEnvs,
 			deployStage,
 		)
 		if err != nil {
@@ -0,0 +1,345 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+	boshpkg "github.com/cloudfoundry/bosh-cli/release/pkg"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+type DiffReleasesCmd struct {
+	releaseReader boshrel.Reader
+	fs            boshsys.FileSystem
+	ui            boshui.UI
+}
+
+func NewDiffReleasesCmd(releaseReader boshrel.Reader, fs boshsys.FileSystem, ui boshui.UI) DiffReleasesCmd {
+	return DiffReleasesCmd{releaseReader: releaseReader, fs: fs, ui: ui}
+}
+
+func (c DiffReleasesCmd) Run(opts DiffReleasesOpts) error {
+	oldRelease, err := c.releaseReader.Read(opts.Args.Old)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Reading release '%s'", opts.Args.Old)
+	}
+
+	defer oldRelease.CleanUp()
+
+	newRelease, err := c.releaseReader.Read(opts.Args.New)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Reading release '%s'", opts.Args.New)
+	}
+
+	defer newRelease.CleanUp()
+
+	jobsTable := boshtbl.Table{
+		Content: "jobs",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Job"),
+			boshtbl.NewHeader("Change"),
+			boshtbl.NewHeader("Old Fingerprint"),
+			boshtbl.NewHeader("New Fingerprint"),
+		},
+		SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}},
+	}
+
+	propsTable := boshtbl.Table{
+		Content: "job properties",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Job"),
+			boshtbl.NewHeader("Property"),
+			boshtbl.NewHeader("Change"),
+			boshtbl.NewHeader("Old Default"),
+			boshtbl.NewHeader("New Default"),
+		},
+		SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}, {Column: 1, Asc: true}},
+	}
+
+	templatesTable := boshtbl.Table{
+		Content: "job templates",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Job"),
+			boshtbl.NewHeader("Template"),
+			boshtbl.NewHeader("Change"),
+			boshtbl.NewHeader("Diff"),
+		},
+		SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}, {Column: 1, Asc: true}},
+	}
+
+	oldJobs := map[string]*boshjob.Job{}
+
+	for _, job := range oldRelease.Jobs() {
+		oldJobs[job.Name()] = job
+	}
+
+	for _, newJob := range newRelease.Jobs() {
+		oldJob, found := oldJobs[newJob.Name()]
+		delete(oldJobs, newJob.Name())
+
+		switch {
+		case !found:
+			jobsTable.Rows = append(jobsTable.Rows, c.changeRow(newJob.Name(), "added", "", newJob.Fingerprint()))
+
+		case oldJob.Fingerprint() != newJob.Fingerprint():
+			jobsTable.Rows = append(jobsTable.Rows, c.changeRow(newJob.Name(), "changed", oldJob.Fingerprint(), newJob.Fingerprint()))
+
+			propsTable.Rows = append(propsTable.Rows, c.propertyRows(oldJob, newJob)...)
+
+			templateRows, err := c.templateRows(oldJob, newJob)
+			if err != nil {
+				return err
+			}
+
+			templatesTable.Rows = append(templatesTable.Rows, templateRows...)
+		}
+	}
+
+	for _, oldJob := range oldJobs {
+		jobsTable.Rows = append(jobsTable.Rows, c.changeRow(oldJob.Name(), "removed", oldJob.Fingerprint(), ""))
+	}
+
+	pkgsTable := boshtbl.Table{
+		Content: "packages",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Package"),
+			boshtbl.NewHeader("Change"),
+			boshtbl.NewHeader("Old Fingerprint"),
+			boshtbl.NewHeader("New Fingerprint"),
+		},
+		SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}},
+	}
+
+	depsTable := boshtbl.Table{
+		Content: "package dependencies",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Package"),
+			boshtbl.NewHeader("Dependency"),
+			boshtbl.NewHeader("Change"),
+		},
+		SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}, {Column: 1, Asc: true}},
+	}
+
+	oldPkgs := map[string]*boshpkg.Package{}
+
+	for _, pkg := range oldRelease.Packages() {
+		oldPkgs[pkg.Name()] = pkg
+	}
+
+	for _, newPkg := range newRelease.Packages() {
+		oldPkg, found := oldPkgs[newPkg.Name()]
+		delete(oldPkgs, newPkg.Name())
+
+		switch {
+		case !found:
+			pkgsTable.Rows = append(pkgsTable.Rows, c.changeRow(newPkg.Name(), "added", "", newPkg.Fingerprint()))
+
+		case oldPkg.Fingerprint() != newPkg.Fingerprint():
+			pkgsTable.Rows = append(pkgsTable.Rows, c.changeRow(newPkg.Name(), "changed", oldPkg.Fingerprint(), newPkg.Fingerprint()))
+			depsTable.Rows = append(depsTable.Rows, c.dependencyRows(oldPkg, newPkg)...)
+		}
+	}
+
+	for _, oldPkg := range oldPkgs {
+		pkgsTable.Rows = append(pkgsTable.Rows, c.changeRow(oldPkg.Name(), "removed", oldPkg.Fingerprint(), ""))
+	}
+
+	c.ui.PrintTable(jobsTable)
+	c.ui.PrintTable(propsTable)
+	c.ui.PrintTable(templatesTable)
+	c.ui.PrintTable(pkgsTable)
+	c.ui.PrintTable(depsTable)
+
+	return nil
+}
+
+func (c DiffReleasesCmd) changeRow(name, change, oldFp, newFp string) []boshtbl.Value {
+	return []boshtbl.Value{
+		boshtbl.NewValueString(name),
+		boshtbl.NewValueString(change),
+		boshtbl.NewValueString(oldFp),
+		boshtbl.NewValueString(newFp),
+	}
+}
+
+func (c DiffReleasesCmd) propertyRows(oldJob, newJob *boshjob.Job) [][]boshtbl.Value {
+	var rows [][]boshtbl.Value
+
+	row := func(name, change string, oldDef, newDef interface{}) []boshtbl.Value {
+		return []boshtbl.Value{
+			boshtbl.NewValueString(newJob.Name()),
+			boshtbl.NewValueString(name),
+			boshtbl.NewValueString(change),
+			boshtbl.NewValueInterface(oldDef),
+			boshtbl.NewValueInterface(newDef),
+		}
+	}
+
+	for name, newProp := range newJob.Properties {
+		oldProp, found := oldJob.Properties[name]
+
+		switch {
+		case !found:
+			rows = append(rows, row(name, "added", nil, newProp.Default))
+		case !reflect.DeepEqual(oldProp.Default, newProp.Default):
+			rows = append(rows, row(name, "default changed", oldProp.Default, newProp.Default))
+		}
+	}
+
+	for name, oldProp := range oldJob.Properties {
+		if _, found := newJob.Properties[name]; !found {
+			rows = append(rows, row(name, "removed", oldProp.Default, nil))
+		}
+	}
+
+	return rows
+}
+
+// templateRows compares template sources and monit files of extracted jobs
+func (c DiffReleasesCmd) templateRows(oldJob, newJob *boshjob.Job) ([][]boshtbl.Value, error) {
+	var rows [][]boshtbl.Value
+
+	row := func(name, change, diff string) []boshtbl.Value {
+		return []boshtbl.Value{
+			boshtbl.NewValueString(newJob.Name()),
+			boshtbl.NewValueString(name),
+			boshtbl.NewValueString(change),
+			boshtbl.NewValueString(diff),
+		}
+	}
+
+	oldFiles := c.jobFiles(oldJob)
+	newFiles := c.jobFiles(newJob)
+
+	var names []string
+
+	for name := range oldFiles {
+		names = append(names, name)
+	}
+
+	for name := range newFiles {
+		if _, found := oldFiles[name]; !found {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		oldPath, oldFound := oldFiles[name]
+		newPath, newFound := newFiles[name]
+
+		switch {
+		case !oldFound:
+			rows = append(rows, row(name, "added", ""))
+		case !newFound:
+			rows = append(rows, row(name, "removed", ""))
+		default:
+			oldContents, err := c.readFile(oldPath)
+			if err != nil {
+				return nil, err
+			}
+
+			newContents, err := c.readFile(newPath)
+			if err != nil {
+				return nil, err
+			}
+
+			if oldContents != newContents {
+				rows = append(rows, row(name, "changed", c.contentsDiff(oldContents, newContents)))
+			}
+
+			if oldJob.Templates[name] != newJob.Templates[name] {
+				change := fmt.Sprintf("destination changed from '%s' to '%s'", oldJob.Templates[name], newJob.Templates[name])
+				rows = append(rows, row(name, change, ""))
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+func (c DiffReleasesCmd) jobFiles(job *boshjob.Job) map[string]string {
+	files := map[string]string{}
+
+	for src := range job.Templates {
+		files[src] = filepath.Join(job.ExtractedPath(), "templates", src)
+	}
+
+	monitPath := filepath.Join(job.ExtractedPath(), "monit")
+
+	if c.fs.FileExists(monitPath) {
+		files["monit"] = monitPath
+	}
+
+	return files
+}
+
+func (c DiffReleasesCmd) readFile(path string) (string, error) {
+	contents, err := c.fs.ReadFileString(path)
+	if err != nil {
+		return "", bosherr.WrapErrorf(err, "Reading '%s'", path)
+	}
+
+	return contents, nil
+}
+
+// contentsDiff returns changed lines with few surrounding lines
+func (c DiffReleasesCmd) contentsDiff(oldContents, newContents string) string {
+	allLines := diffLines(strings.Split(oldContents, "\n"), strings.Split(newContents, "\n"))
+
+	var lines []string
+
+	for _, line := range diffChangedLines(allLines) {
+		switch line[1] {
+		case "added":
+			lines = append(lines, "+ "+line[0].(string))
+		case "removed":
+			lines = append(lines, "- "+line[0].(string))
+		default:
+			lines = append(lines, "  "+line[0].(string))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (c DiffReleasesCmd) dependencyRows(oldPkg, newPkg *boshpkg.Package) [][]boshtbl.Value {
+	var rows [][]boshtbl.Value
+
+	row := func(dep, change string) []boshtbl.Value {
+		return []boshtbl.Value{
+			boshtbl.NewValueString(newPkg.Name()),
+			boshtbl.NewValueString(dep),
+			boshtbl.NewValueString(change),
+		}
+	}
+
+	oldDeps := map[string]bool{}
+
+	for _, dep := range oldPkg.DependencyNames() {
+		oldDeps[dep] = true
+	}
+
+	for _, dep := range newPkg.DependencyNames() {
+		if !oldDeps[dep] {
+			rows = append(rows, row(dep, "added"))
+		}
+		delete(oldDeps, dep)
+	}
+
+	for dep := range oldDeps {
+		rows = append(rows, row(dep, "removed"))
+	}
+
+	return rows
+}
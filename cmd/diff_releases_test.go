@@ -0,0 +1,242 @@
+package cmd_test
+
+import (
+	"errors"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+	boshpkg "github.com/cloudfoundry/bosh-cli/release/pkg"
+	fakerel "github.com/cloudfoundry/bosh-cli/release/releasefakes"
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("DiffReleasesCmd", func() {
+	var (
+		releaseReader *fakerel.FakeReader
+		fs            *fakesys.FakeFileSystem
+		ui            *fakeui.FakeUI
+		command       DiffReleasesCmd
+	)
+
+	BeforeEach(func() {
+		releaseReader = &fakerel.FakeReader{}
+		fs = fakesys.NewFakeFileSystem()
+		ui = &fakeui.FakeUI{}
+		command = NewDiffReleasesCmd(releaseReader, fs, ui)
+	})
+
+	Describe("Run", func() {
+		var (
+			opts       DiffReleasesOpts
+			oldRelease *fakerel.FakeRelease
+			newRelease *fakerel.FakeRelease
+		)
+
+		BeforeEach(func() {
+			opts = DiffReleasesOpts{Args: DiffReleasesArgs{Old: "/old.tgz", New: "/new.tgz"}}
+
+			oldWeb := boshjob.NewExtractedJob(boshres.NewResource("web", "web-fp1", nil), "/old/web", fs)
+			oldWeb.Templates = map[string]string{"ctl.erb": "bin/ctl", "config.erb": "config/config.yml"}
+			oldWeb.Properties = map[string]boshjob.PropertyDefinition{
+				"port":    {Default: 80},
+				"removed": {},
+				"same":    {Default: "val"},
+			}
+
+			newWeb := boshjob.NewExtractedJob(boshres.NewResource("web", "web-fp2", nil), "/new/web", fs)
+			newWeb.Templates = map[string]string{"ctl.erb": "bin/ctl", "config.erb": "config/web.yml", "new.erb": "new"}
+			newWeb.Properties = map[string]boshjob.PropertyDefinition{
+				"port":  {Default: 8080},
+				"added": {Default: true},
+				"same":  {Default: "val"},
+			}
+
+			fs.WriteFileString("/old/web/templates/ctl.erb", "line1\nline2\nline3\nline4\nline5\nline6\nline7")
+			fs.WriteFileString("/new/web/templates/ctl.erb", "line1\nline2\nline3\nline4\nline5\nline6\nchanged")
+			fs.WriteFileString("/old/web/templates/config.erb", "config")
+			fs.WriteFileString("/new/web/templates/config.erb", "config")
+			fs.WriteFileString("/new/web/templates/new.erb", "new")
+
+			oldRelease = &fakerel.FakeRelease{}
+			oldRelease.JobsReturns([]*boshjob.Job{
+				oldWeb,
+				boshjob.NewJob(boshres.NewResource("same", "same-fp", nil)),
+				boshjob.NewJob(boshres.NewResource("gone", "gone-fp", nil)),
+			})
+			oldRelease.PackagesReturns([]*boshpkg.Package{
+				boshpkg.NewPackage(boshres.NewResource("app", "app-fp1", nil), []string{"lib-a", "lib-b"}),
+				boshpkg.NewPackage(boshres.NewResource("old-pkg", "old-fp", nil), nil),
+			})
+
+			newRelease = &fakerel.FakeRelease{}
+			newRelease.JobsReturns([]*boshjob.Job{
+				newWeb,
+				boshjob.NewJob(boshres.NewResource("same", "same-fp", nil)),
+				boshjob.NewJob(boshres.NewResource("worker", "worker-fp", nil)),
+			})
+			newRelease.PackagesReturns([]*boshpkg.Package{
+				boshpkg.NewPackage(boshres.NewResource("app", "app-fp2", nil), []string{"lib-b", "lib-c"}),
+				boshpkg.NewPackage(boshres.NewResource("new-pkg", "new-fp", nil), nil),
+			})
+
+			releaseReader.ReadStub = func(path string) (boshrel.Release, error) {
+				if path == "/old.tgz" {
+					return oldRelease, nil
+				}
+				return newRelease, nil
+			}
+		})
+
+		act := func() error { return command.Run(opts) }
+
+		It("shows job changes", func() {
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Tables[0].Content).To(Equal("jobs"))
+			Expect(ui.Tables[0].Rows).To(ConsistOf(
+				[]boshtbl.Value{
+					boshtbl.NewValueString("web"),
+					boshtbl.NewValueString("changed"),
+					boshtbl.NewValueString("web-fp1"),
+					boshtbl.NewValueString("web-fp2"),
+				},
+				[]boshtbl.Value{
+					boshtbl.NewValueString("worker"),
+					boshtbl.NewValueString("added"),
+					boshtbl.NewValueString(""),
+					boshtbl.NewValueString("worker-fp"),
+				},
+				[]boshtbl.Value{
+					boshtbl.NewValueString("gone"),
+					boshtbl.NewValueString("removed"),
+					boshtbl.NewValueString("gone-fp"),
+					boshtbl.NewValueString(""),
+				},
+			))
+		})
+
+		It("shows job property changes", func() {
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Tables[1].Content).To(Equal("job properties"))
+			Expect(ui.Tables[1].Rows).To(ConsistOf(
+				[]boshtbl.Value{
+					boshtbl.NewValueString("web"),
+					boshtbl.NewValueString("port"),
+					boshtbl.NewValueString("default changed"),
+					boshtbl.NewValueInterface(80),
+					boshtbl.NewValueInterface(8080),
+				},
+				[]boshtbl.Value{
+					boshtbl.NewValueString("web"),
+					boshtbl.NewValueString("added"),
+					boshtbl.NewValueString("added"),
+					boshtbl.NewValueInterface(nil),
+					boshtbl.NewValueInterface(true),
+				},
+				[]boshtbl.Value{
+					boshtbl.NewValueString("web"),
+					boshtbl.NewValueString("removed"),
+					boshtbl.NewValueString("removed"),
+					boshtbl.NewValueInterface(nil),
+					boshtbl.NewValueInterface(nil),
+				},
+			))
+		})
+
+		It("shows template content and destination changes", func() {
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Tables[2].Content).To(Equal("job templates"))
+			Expect(ui.Tables[2].Rows).To(Equal([][]boshtbl.Value{
+				{
+					boshtbl.NewValueString("web"),
+					boshtbl.NewValueString("config.erb"),
+					boshtbl.NewValueString("destination changed from 'config/config.yml' to 'config/web.yml'"),
+					boshtbl.NewValueString(""),
+				},
+				{
+					boshtbl.NewValueString("web"),
+					boshtbl.NewValueString("ctl.erb"),
+					boshtbl.NewValueString("changed"),
+					boshtbl.NewValueString("  line5\n  line6\n- line7\n+ changed"),
+				},
+				{
+					boshtbl.NewValueString("web"),
+					boshtbl.NewValueString("new.erb"),
+					boshtbl.NewValueString("added"),
+					boshtbl.NewValueString(""),
+				},
+			}))
+		})
+
+		It("shows package and package dependency changes", func() {
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Tables[3].Content).To(Equal("packages"))
+			Expect(ui.Tables[3].Rows).To(ConsistOf(
+				[]boshtbl.Value{
+					boshtbl.NewValueString("app"),
+					boshtbl.NewValueString("changed"),
+					boshtbl.NewValueString("app-fp1"),
+					boshtbl.NewValueString("app-fp2"),
+				},
+				[]boshtbl.Value{
+					boshtbl.NewValueString("new-pkg"),
+					boshtbl.NewValueString("added"),
+					boshtbl.NewValueString(""),
+					boshtbl.NewValueString("new-fp"),
+				},
+				[]boshtbl.Value{
+					boshtbl.NewValueString("old-pkg"),
+					boshtbl.NewValueString("removed"),
+					boshtbl.NewValueString("old-fp"),
+					boshtbl.NewValueString(""),
+				},
+			))
+
+			Expect(ui.Tables[4].Content).To(Equal("package dependencies"))
+			Expect(ui.Tables[4].Rows).To(ConsistOf(
+				[]boshtbl.Value{
+					boshtbl.NewValueString("app"),
+					boshtbl.NewValueString("lib-c"),
+					boshtbl.NewValueString("added"),
+				},
+				[]boshtbl.Value{
+					boshtbl.NewValueString("app"),
+					boshtbl.NewValueString("lib-a"),
+					boshtbl.NewValueString("removed"),
+				},
+			))
+		})
+
+		It("cleans up both releases", func() {
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(oldRelease.CleanUpCallCount()).To(Equal(1))
+			Expect(newRelease.CleanUpCallCount()).To(Equal(1))
+		})
+
+		It("returns error if reading release fails", func() {
+			releaseReader.ReadStub = nil
+			releaseReader.ReadReturns(nil, errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+			Expect(err.Error()).To(ContainSubstring("Reading release '/old.tgz'"))
+		})
+	})
+})
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/cppforlife/go-patch/patch"
+	"gopkg.in/yaml.v2"
+
+	boshdir "github.com/cloudfoundry/bosh-cli/director"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+const driftRedacted = "<redacted>"
+
+// Keys whose values are redacted similarly to how Director redacts diffs
+var driftRedactedKeys = []string{"properties", "env"}
+
+// Redacted values are compared by digest and the digest is dropped before printing
+var driftRedactedDigestRegexp = regexp.MustCompile(`<redacted:[0-9a-f]+>`)
+
+type DriftCmd struct {
+	ui         boshui.UI
+	deployment boshdir.Deployment
+}
+
+func NewDriftCmd(ui boshui.UI, deployment boshdir.Deployment) DriftCmd {
+	return DriftCmd{ui: ui, deployment: deployment}
+}
+
+func (c DriftCmd) Run(opts DriftOpts) error {
+	tpl := boshtpl.NewTemplate(opts.Args.Manifest.Bytes)
+
+	// Drift is a read-only check hence missing variables are not generated
+	vars := opts.VarFlags.AsReadOnlyVariables()
+
+	bytes, err := tpl.Evaluate(vars, opts.OpsFlags.AsOp(), boshtpl.EvaluateOpts{})
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Evaluating manifest")
+	}
+
+	manifest, err := boshdir.NewManifestFromBytes(bytes)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Parsing manifest")
+	}
+
+	if manifest.Name != c.deployment.Name() {
+		errMsg := "Expected manifest to specify deployment name '%s' but was '%s'"
+		return bosherr.Errorf(errMsg, c.deployment.Name(), manifest.Name)
+	}
+
+	deployedManifest, err := c.deployment.Manifest()
+	if err != nil {
+		return err
+	}
+
+	if len(deployedManifest) == 0 {
+		return bosherr.Errorf("Expected deployment '%s' to be deployed", c.deployment.Name())
+	}
+
+	// Deployed manifest keeps placeholders that were not interpolated by CLI,
+	// so interpolate it with the same variables to compare like with like
+	deployedBytes, err := boshtpl.NewTemplate([]byte(deployedManifest)).Evaluate(
+		vars, patch.Ops{}, boshtpl.EvaluateOpts{})
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Evaluating deployed manifest")
+	}
+
+	manifestLines, err := c.manifestDiff(deployedBytes, bytes, opts.NoRedact)
+	if err != nil {
+		return err
+	}
+
+	// Diffing deployed manifest against itself leaves only changes introduced by latest configs
+	configsDiff, err := c.deployment.Diff([]byte(deployedManifest), opts.NoRedact)
+	if err != nil {
+		return err
+	}
+
+	manifestDrifted := c.hasChanges(manifestLines)
+	configsDrifted := c.hasChanges(configsDiff.Diff)
+
+	if manifestDrifted {
+		c.ui.PrintLinef("Manifest differs from deployed manifest:")
+		NewDiff(manifestLines).Print(c.ui)
+	} else {
+		c.ui.PrintLinef("Manifest matches deployed manifest")
+	}
+
+	if configsDrifted {
+		c.ui.PrintLinef("Latest configs differ from configs used by deployment:")
+		NewDiff(configsDiff.Diff).Print(c.ui)
+	} else {
+		c.ui.PrintLinef("Deployment uses latest configs")
+	}
+
+	if manifestDrifted || configsDrifted {
+		return bosherr.Errorf("Expected deployment '%s' to match manifest and latest configs", c.deployment.Name())
+	}
+
+	return nil
+}
+
+func (c DriftCmd) hasChanges(lines [][]interface{}) bool {
+	for _, line := range lines {
+		if len(line) > 1 {
+			if lineMod, _ := line[1].(string); lineMod == "added" || lineMod == "removed" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// manifestDiff compares manifests ignoring formatting and key order
+// and returns changed lines with few surrounding lines in Director diff format
+func (c DriftCmd) manifestDiff(from, to []byte, noRedact bool) ([][]interface{}, error) {
+	fromLines, err := c.normalizedLines(from, noRedact)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Normalizing deployed manifest")
+	}
+
+	toLines, err := c.normalizedLines(to, noRedact)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Normalizing manifest")
+	}
+
+	lines := diffChangedLines(diffLines(fromLines, toLines))
+
+	for _, line := range lines {
+		if text, ok := line[0].(string); ok {
+			line[0] = driftRedactedDigestRegexp.ReplaceAllString(text, driftRedacted)
+		}
+	}
+
+	return lines, nil
+}
+
+func (c DriftCmd) normalizedLines(bytes []byte, noRedact bool) ([]string, error) {
+	var doc interface{}
+
+	err := yaml.Unmarshal(bytes, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if !noRedact {
+		doc = driftRedact(doc, false)
+	}
+
+	bytes, err = yaml.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(strings.TrimSuffix(string(bytes), "\n"), "\n"), nil
+}
+
+func driftRedact(obj interface{}, redact bool) interface{} {
+	switch typedObj := obj.(type) {
+	case map[interface{}]interface{}:
+		for k, v := range typedObj {
+			keyRedact := redact
+
+			for _, key := range driftRedactedKeys {
+				if k == key {
+					keyRedact = true
+				}
+			}
+
+			typedObj[k] = driftRedact(v, keyRedact)
+		}
+
+		return typedObj
+
+	case []interface{}:
+		for i, v := range typedObj {
+			typedObj[i] = driftRedact(v, redact)
+		}
+
+		return typedObj
+
+	default:
+		if redact {
+			return driftRedactedDigest(obj)
+		}
+
+		return obj
+	}
+}
+
+func driftRedactedDigest(obj interface{}) string {
+	digest := sha256.Sum256([]byte(fmt.Sprintf("%T:%v", obj, obj)))
+	return fmt.Sprintf("<redacted:%x>", digest[:8])
+}
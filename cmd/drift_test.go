@@ -0,0 +1,195 @@
+package cmd_test
+
+import (
+	"errors"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	"github.com/cppforlife/go-patch/patch"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	boshdir "github.com/cloudfoundry/bosh-cli/director"
+	fakedir "github.com/cloudfoundry/bosh-cli/director/directorfakes"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+)
+
+var _ = Describe("DriftCmd", func() {
+	var (
+		ui         *fakeui.FakeUI
+		deployment *fakedir.FakeDeployment
+		command    DriftCmd
+	)
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		deployment = &fakedir.FakeDeployment{
+			NameStub: func() string { return "dep" },
+		}
+		command = NewDriftCmd(ui, deployment)
+	})
+
+	Describe("Run", func() {
+		var (
+			opts DriftOpts
+		)
+
+		BeforeEach(func() {
+			opts = DriftOpts{
+				Args: DriftArgs{
+					Manifest: FileBytesArg{Bytes: []byte("name: dep\nproperties: {password: ((pass))}\nstemcells: [{os: ((os))}]\n")},
+				},
+			}
+
+			deployment.ManifestReturns("stemcells:\n- os: ubuntu\nname: dep\nproperties:\n  password: ((pass))\n", nil)
+		})
+
+		act := func() error { return command.Run(opts) }
+
+		It("succeeds when interpolated manifest matches deployed manifest regardless of formatting", func() {
+			opts.VarKVs = []boshtpl.VarKV{{Name: "os", Value: "ubuntu"}}
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Said).To(Equal([]string{
+				"Manifest matches deployed manifest",
+				"Deployment uses latest configs",
+			}))
+		})
+
+		It("interpolates deployed manifest with the same variables before comparing", func() {
+			opts.VarKVs = []boshtpl.VarKV{{Name: "os", Value: "ubuntu"}, {Name: "pass", Value: "fake-pass"}}
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Said).To(ContainElement("Manifest matches deployed manifest"))
+		})
+
+		It("does not generate missing variables into vars store", func() {
+			fs := fakesys.NewFakeFileSystem()
+
+			opts.VarKVs = []boshtpl.VarKV{{Name: "os", Value: "ubuntu"}}
+			opts.VarsFSStore = VarsFSStore{FS: fs}
+			Expect(opts.VarsFSStore.UnmarshalFlag("/vars.yml")).ToNot(HaveOccurred())
+
+			opts.Args.Manifest = FileBytesArg{
+				Bytes: []byte("name: dep\nproperties: {password: ((pass))}\nstemcells: [{os: ((os))}]\nvariables: [{name: pass, type: password}]\n"),
+			}
+			deployment.ManifestReturns("name: dep\nproperties: {password: ((pass))}\nstemcells: [{os: ubuntu}]\nvariables: [{name: pass, type: password}]\n", nil)
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Said).To(ContainElement("Manifest matches deployed manifest"))
+			Expect(fs.FileExists("/vars.yml")).To(BeFalse())
+		})
+
+		It("returns error and shows redacted diff when manifest differs", func() {
+			opts.VarKVs = []boshtpl.VarKV{{Name: "os", Value: "ubuntu"}}
+			opts.OpsFiles = []OpsFileArg{
+				{
+					Ops: patch.Ops([]patch.Op{
+						patch.ReplaceOp{Path: patch.MustNewPointerFromString("/properties/password"), Value: "new-pass"},
+						patch.ReplaceOp{Path: patch.MustNewPointerFromString("/stemcells/0/os"), Value: "centos"},
+					}),
+				},
+			}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected deployment 'dep' to match manifest and latest configs"))
+
+			Expect(ui.Said).To(Equal([]string{
+				"Manifest differs from deployed manifest:",
+				"  name: dep\n",
+				"  properties:\n",
+				"-   password: <redacted>\n",
+				"+   password: <redacted>\n",
+				"  stemcells:\n",
+				"- - os: ubuntu\n",
+				"+ - os: centos\n",
+				"Deployment uses latest configs",
+			}))
+		})
+
+		It("shows non-redacted diff if requested", func() {
+			opts.NoRedact = true
+			opts.VarKVs = []boshtpl.VarKV{{Name: "os", Value: "ubuntu"}}
+			opts.OpsFiles = []OpsFileArg{
+				{
+					Ops: patch.Ops([]patch.Op{
+						patch.ReplaceOp{Path: patch.MustNewPointerFromString("/properties/password"), Value: "new-pass"},
+					}),
+				},
+			}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+
+			Expect(ui.Said).To(ContainElement("-   password: ((pass))\n"))
+			Expect(ui.Said).To(ContainElement("+   password: new-pass\n"))
+
+			_, noRedact := deployment.DiffArgsForCall(0)
+			Expect(noRedact).To(BeTrue())
+		})
+
+		It("returns error and shows diff when latest configs change deployed manifest", func() {
+			opts.VarKVs = []boshtpl.VarKV{{Name: "os", Value: "ubuntu"}}
+
+			deployment.DiffReturns(boshdir.NewDeploymentDiff([][]interface{}{
+				{"addons:", nil},
+				{"- name: new-addon", "added"},
+			}, nil), nil)
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected deployment 'dep' to match manifest and latest configs"))
+
+			bytes, noRedact := deployment.DiffArgsForCall(0)
+			Expect(string(bytes)).To(Equal("stemcells:\n- os: ubuntu\nname: dep\nproperties:\n  password: ((pass))\n"))
+			Expect(noRedact).To(BeFalse())
+
+			Expect(ui.Said).To(Equal([]string{
+				"Manifest matches deployed manifest",
+				"Latest configs differ from configs used by deployment:",
+				"  addons:\n",
+				"+ - name: new-addon\n",
+			}))
+		})
+
+		It("returns error if manifest specifies different deployment name", func() {
+			opts.Args.Manifest.Bytes = []byte("name: other-dep")
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected manifest to specify deployment name 'dep' but was 'other-dep'"))
+		})
+
+		It("returns error if deployment is not deployed", func() {
+			deployment.ManifestReturns("", nil)
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected deployment 'dep' to be deployed"))
+		})
+
+		It("returns error if deployed manifest cannot be retrieved", func() {
+			deployment.ManifestReturns("", errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+
+		It("returns error if diff cannot be retrieved", func() {
+			deployment.DiffReturns(boshdir.DeploymentDiff{}, errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+})
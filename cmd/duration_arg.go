@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+const day = 24 * time.Hour
+
+// DurationArg accepts Go durations (e.g. 12h) and additionally days (e.g. 30d)
+type DurationArg time.Duration
+
+func (a *DurationArg) UnmarshalFlag(data string) error {
+	if strings.HasSuffix(data, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(data, "d"))
+		if err != nil || days < 0 {
+			return bosherr.Errorf("Expected duration '%s' to be a non-negative number of days", data)
+		}
+
+		*a = DurationArg(time.Duration(days) * day)
+
+		return nil
+	}
+
+	dur, err := time.ParseDuration(data)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Parsing duration '%s'", data)
+	}
+
+	if dur < 0 {
+		return bosherr.Errorf("Expected duration '%s' to be non-negative", data)
+	}
+
+	*a = DurationArg(dur)
+
+	return nil
+}
+
+func (a DurationArg) String() string {
+	dur := time.Duration(a)
+
+	if dur > 0 && dur%day == 0 {
+		return strconv.Itoa(int(dur/day)) + "d"
+	}
+
+	return dur.String()
+}
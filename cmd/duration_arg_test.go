@@ -0,0 +1,62 @@
+package cmd_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+)
+
+var _ = Describe("DurationArg", func() {
+	var (
+		arg DurationArg
+	)
+
+	BeforeEach(func() {
+		arg = DurationArg(0)
+	})
+
+	Describe("UnmarshalFlag", func() {
+		It("parses days", func() {
+			err := (&arg).UnmarshalFlag("30d")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(arg).To(Equal(DurationArg(30 * 24 * time.Hour)))
+		})
+
+		It("parses Go durations", func() {
+			err := (&arg).UnmarshalFlag("1h30m")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(arg).To(Equal(DurationArg(90 * time.Minute)))
+		})
+
+		It("returns error if days cannot be parsed", func() {
+			err := (&arg).UnmarshalFlag("1.5d")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected duration '1.5d' to be a non-negative number of days"))
+		})
+
+		It("returns error if duration cannot be parsed", func() {
+			err := (&arg).UnmarshalFlag("soon")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Parsing duration 'soon'"))
+		})
+
+		It("returns error if duration is negative", func() {
+			err := (&arg).UnmarshalFlag("-1h")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected duration '-1h' to be non-negative"))
+		})
+	})
+
+	Describe("String", func() {
+		It("returns days if duration is whole number of days", func() {
+			Expect(DurationArg(48 * time.Hour).String()).To(Equal("2d"))
+		})
+
+		It("returns Go duration otherwise", func() {
+			Expect(DurationArg(90 * time.Minute).String()).To(Equal("1h30m0s"))
+		})
+	})
+})
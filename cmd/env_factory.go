@@ -3,6 +3,7 @@ package cmd
 import (
 	"os"
 	"path/filepath"
+	"runtime"
 	"time"
 
 	"github.com/cppforlife/go-patch/patch"
@@ -11,6 +12,7 @@ import (
 	biblobstore "github.com/cloudfoundry/bosh-cli/blobstore"
 	bicloud "github.com/cloudfoundry/bosh-cli/cloud"
 	biconfig "github.com/cloudfoundry/bosh-cli/config"
+	bicpifake "github.com/cloudfoundry/bosh-cli/cpi/fake"
 	bicpirel "github.com/cloudfoundry/bosh-cli/cpi/release"
 	bidepl "github.com/cloudfoundry/bosh-cli/deployment"
 	bidisk "github.com/cloudfoundry/bosh-cli/deployment/disk"
@@ -25,6 +27,7 @@ import (
 	biindex "github.com/cloudfoundry/bosh-cli/index"
 	boshinst "github.com/cloudfoundry/bosh-cli/installation"
 	boshinstmanifest "github.com/cloudfoundry/bosh-cli/installation/manifest"
+	bipkgcache "github.com/cloudfoundry/bosh-cli/installation/pkgcache"
 	bitarball "github.com/cloudfoundry/bosh-cli/installation/tarball"
 	biregistry "github.com/cloudfoundry/bosh-cli/registry"
 	boshrel "github.com/cloudfoundry/bosh-cli/release"
@@ -33,9 +36,19 @@ import (
 	bistemcell "github.com/cloudfoundry/bosh-cli/stemcell"
 	bitemplate "github.com/cloudfoundry/bosh-cli/templatescompiler"
 	bitemplateerb "github.com/cloudfoundry/bosh-cli/templatescompiler/erbrenderer"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
 	"github.com/cloudfoundry/bosh-utils/httpclient"
 )
 
+// EnvFactoryOpts configures how releases are verified and how CPI is called
+type EnvFactoryOpts struct {
+	ReleaseSignature ReleaseSignatureFlags
+
+	RecordCPI string
+	ReplayCPI string
+	FakeCPI   string
+}
+
 type envFactory struct {
 	deps         BasicDeps
 	manifestPath string
@@ -53,20 +66,25 @@ type envFactory struct {
 	targetProvider boshinst.TargetProvider
 	cloudFactory   bicloud.Factory
 
-	diskManagerFactory     bidisk.ManagerFactory
 	vmManagerFactory       bivm.ManagerFactory
 	stemcellManagerFactory bistemcell.ManagerFactory
 
-	instanceManagerFactory   biinstance.ManagerFactory
-	deploymentManagerFactory bidepl.ManagerFactory
+	instanceManagerFactory     biinstance.ManagerFactory
+	instanceEnvProviderFactory bidepl.InstanceEnvProviderFactory
+	deploymentManagerFactory   bidepl.ManagerFactory
 
-	agentClientFactory bihttpagent.AgentClientFactory
-	blobstoreFactory   biblobstore.Factory
-	deploymentFactory  bidepl.Factory
-	deploymentRecord   bidepl.Record
+	deploymentFactory bidepl.Factory
+	deploymentRecord  bidepl.Record
 }
 
-func NewEnvFactory(deps BasicDeps, manifestPath string, statePath string, manifestVars boshtpl.Variables, manifestOp patch.Op) *envFactory {
+func NewEnvFactory(
+	deps BasicDeps,
+	manifestPath string,
+	statePath string,
+	manifestVars boshtpl.Variables,
+	manifestOp patch.Op,
+	opts EnvFactoryOpts,
+) *envFactory {
 	f := envFactory{
 		deps:         deps,
 		manifestPath: manifestPath,
@@ -91,8 +109,8 @@ func NewEnvFactory(deps BasicDeps, manifestPath string, statePath string, manife
 			deps.CmdRunner, deps.Compressor, deps.DigestCalculator, deps.FS, deps.Logger)
 
 		f.releaseFetcher = boshinst.NewReleaseFetcher(
-			tarballProvider,
-			releaseProvider.NewExtractingArchiveReader(),
+			opts.ReleaseSignature.AsTarballProvider(tarballProvider),
+			opts.ReleaseSignature.AsReleaseReader(releaseProvider.NewExtractingArchiveReader(), deps.FS),
 			f.releaseManager,
 		)
 
@@ -108,11 +126,24 @@ func NewEnvFactory(deps BasicDeps, manifestPath string, statePath string, manife
 	f.deploymentStateService = biconfig.NewFileSystemDeploymentStateService(
 		deps.FS, deps.UUIDGen, deps.Logger, biconfig.DeploymentStatePath(manifestPath, statePath))
 
+	var fakeCPIStore *bicpifake.Store
+	if len(opts.FakeCPI) > 0 {
+		fakeCPIStore = bicpifake.NewStore(deps.FS, deps.UUIDGen, opts.FakeCPI, deps.Logger)
+	}
+
 	{
 		registryServer := biregistry.NewServerManager(deps.Logger)
-		installerFactory := boshinst.NewInstallerFactory(
-			deps.UI, deps.CmdRunner, deps.Compressor, releaseJobResolver,
-			deps.UUIDGen, registryServer, deps.Logger, deps.FS, deps.DigestCreationAlgorithms)
+
+		var installerFactory boshinst.InstallerFactory
+		if fakeCPIStore != nil {
+			installerFactory = bicpifake.NewInstallerFactory(registryServer)
+		} else {
+			compiledPackageCache := bipkgcache.NewFSCache(
+				compiledPackageCachePath(workspaceRootPath), runtime.GOOS, runtime.GOARCH, deps.FS, deps.Time, deps.Logger)
+			installerFactory = boshinst.NewInstallerFactory(
+				deps.UI, deps.CmdRunner, deps.Compressor, releaseJobResolver,
+				deps.UUIDGen, registryServer, compiledPackageCache, deps.Logger, deps.FS, deps.DigestCreationAlgorithms)
+		}
 
 		f.cpiInstaller = bicpirel.CpiInstaller{
 			ReleaseManager:   f.releaseManager,
@@ -129,8 +160,8 @@ func NewEnvFactory(deps BasicDeps, manifestPath string, statePath string, manife
 		stemcellRepo := biconfig.NewStemcellRepo(f.deploymentStateService, deps.UUIDGen)
 		vmRepo := biconfig.NewVMRepo(f.deploymentStateService)
 
-		f.diskManagerFactory = bidisk.NewManagerFactory(diskRepo, deps.Logger)
-		diskDeployer := bivm.NewDiskDeployer(f.diskManagerFactory, diskRepo, deps.Logger)
+		diskManagerFactory := bidisk.NewManagerFactory(diskRepo, deps.Logger)
+		diskDeployer := bivm.NewDiskDeployer(diskManagerFactory, diskRepo, deps.Logger)
 
 		f.stemcellManagerFactory = bistemcell.NewManagerFactory(stemcellRepo)
 		f.vmManagerFactory = bivm.NewManagerFactory(
@@ -142,10 +173,21 @@ func NewEnvFactory(deps BasicDeps, manifestPath string, statePath string, manife
 	}
 
 	{
-		f.blobstoreFactory = biblobstore.NewBlobstoreFactory(deps.UUIDGen, deps.FS, deps.Logger)
+		blobstoreFactory := biblobstore.NewBlobstoreFactory(deps.UUIDGen, deps.FS, deps.Logger)
+		agentClientFactory := bihttpagent.NewAgentClientFactory(1*time.Second, deps.Logger)
+		f.cloudFactory = bicloud.NewFactory(
+			deps.FS, deps.CmdRunner, opts.RecordCPI, opts.ReplayCPI, boshui.NewStage(deps.UI, deps.Time, deps.Logger), deps.Time, deps.Logger)
+
+		if fakeCPIStore != nil {
+			blobstoreFactory = bicpifake.NewBlobstoreFactory(fakeCPIStore)
+			agentClientFactory = bicpifake.NewAgentClientFactory(fakeCPIStore)
+			f.cloudFactory = bicpifake.NewCloudFactory(fakeCPIStore)
+		}
+
+		f.instanceEnvProviderFactory = bidepl.NewInstanceEnvProviderFactory(
+			f.deploymentStateService, f.vmManagerFactory, agentClientFactory, blobstoreFactory, deps.UUIDGen, deps.Logger)
+
 		f.deploymentFactory = bidepl.NewFactory(10*time.Second, 500*time.Millisecond)
-		f.agentClientFactory = bihttpagent.NewAgentClientFactory(1*time.Second, deps.Logger)
-		f.cloudFactory = bicloud.NewFactory(deps.FS, deps.CmdRunner, deps.Logger)
 	}
 
 	{
@@ -183,6 +225,11 @@ func NewEnvFactory(deps BasicDeps, manifestPath string, statePath string, manife
 	return &f
 }
 
+// compiledPackageCachePath is shared by all installations on this machine
+func compiledPackageCachePath(workspaceRootPath string) string {
+	return filepath.Join(workspaceRootPath, "cache", "compiled-packages")
+}
+
 func (f *envFactory) Preparer() DeploymentPreparer {
 	return NewDeploymentPreparer(
 		f.deps.UI,
@@ -199,11 +246,8 @@ func (f *envFactory) Preparer() DeploymentPreparer {
 		f.deploymentRecord,
 		f.cloudFactory,
 		f.stemcellManagerFactory,
-		f.agentClientFactory,
-		f.vmManagerFactory,
-		f.blobstoreFactory,
+		f.instanceEnvProviderFactory,
 		bidepl.NewDeployer(
-			f.vmManagerFactory,
 			f.instanceManagerFactory,
 			f.deploymentFactory,
 			f.deps.Logger,
@@ -234,12 +278,9 @@ func (f *envFactory) Deleter() DeploymentDeleter {
 		f.deploymentStateService,
 		f.releaseManager,
 		f.cloudFactory,
-		f.agentClientFactory,
-		f.blobstoreFactory,
+		f.instanceEnvProviderFactory,
 		bidepl.NewManagerFactory(
-			f.vmManagerFactory,
 			f.instanceManagerFactory,
-			f.diskManagerFactory,
 			f.stemcellManagerFactory,
 			f.deploymentFactory,
 		),
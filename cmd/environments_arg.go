@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+type EnvironmentsArg []string
+
+func (a *EnvironmentsArg) UnmarshalFlag(data string) error {
+	var environments []string
+
+	for _, env := range strings.Split(data, ",") {
+		env = strings.TrimSpace(env)
+
+		if len(env) == 0 {
+			return bosherr.Errorf("Expected environments '%s' to not include empty names", data)
+		}
+
+		environments = append(environments, env)
+	}
+
+	*a = EnvironmentsArg(environments)
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+)
+
+var _ = Describe("EnvironmentsArg", func() {
+	Describe("UnmarshalFlag", func() {
+		var (
+			arg EnvironmentsArg
+		)
+
+		BeforeEach(func() {
+			arg = EnvironmentsArg{}
+		})
+
+		It("populates with comma-separated environments", func() {
+			err := (&arg).UnmarshalFlag("env1, https://10.0.0.6:25555,env3")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(arg).To(Equal(EnvironmentsArg{"env1", "https://10.0.0.6:25555", "env3"}))
+		})
+
+		It("populates with single environment", func() {
+			err := (&arg).UnmarshalFlag("env1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(arg).To(Equal(EnvironmentsArg{"env1"}))
+		})
+
+		It("returns error if any environment name is empty", func() {
+			err := (&arg).UnmarshalFlag("env1,,env3")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected environments 'env1,,env3' to not include empty names"))
+		})
+	})
+})
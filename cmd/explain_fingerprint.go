@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	semver "github.com/cppforlife/go-semi-semantic/version"
+
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
+	boshreldir "github.com/cloudfoundry/bosh-cli/releasedir"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+type ExplainFingerprintCmd struct {
+	explainer boshreldir.FingerprintExplainer
+	ui        boshui.UI
+}
+
+func NewExplainFingerprintCmd(explainer boshreldir.FingerprintExplainer, ui boshui.UI) ExplainFingerprintCmd {
+	return ExplainFingerprintCmd{explainer: explainer, ui: ui}
+}
+
+func (c ExplainFingerprintCmd) Run(opts ExplainFingerprintOpts) error {
+	var explain func(semver.Version) (boshreldir.FingerprintExplanation, error)
+
+	switch {
+	case len(opts.Job) > 0 && len(opts.Package) > 0:
+		return bosherr.Error("Expected only one of --job or --package to be specified")
+	case len(opts.Job) > 0:
+		explain = func(ver semver.Version) (boshreldir.FingerprintExplanation, error) {
+			return c.explainer.ExplainJob(opts.Job, ver)
+		}
+	case len(opts.Package) > 0:
+		explain = func(ver semver.Version) (boshreldir.FingerprintExplanation, error) {
+			return c.explainer.ExplainPackage(opts.Package, ver)
+		}
+	default:
+		return bosherr.Error("Expected either --job or --package to be specified")
+	}
+
+	current, err := explain(semver.Version{})
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Explaining fingerprint")
+	}
+
+	prevVersion := semver.Version(opts.PreviousVersion)
+
+	if prevVersion.Empty() {
+		c.printEntries(current)
+		return nil
+	}
+
+	previous, err := explain(prevVersion)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Explaining fingerprint in release version '%s'", prevVersion.AsString())
+	}
+
+	c.printDifferences(current, previous, prevVersion)
+
+	return nil
+}
+
+func (c ExplainFingerprintCmd) printEntries(expl boshreldir.FingerprintExplanation) {
+	table := boshtbl.Table{
+		Content: "fingerprint entries",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Type"),
+			boshtbl.NewHeader("Name"),
+			boshtbl.NewHeader("Digest"),
+			boshtbl.NewHeader("Mode"),
+		},
+		Notes: []string{
+			fmt.Sprintf("Fingerprint of '%s' is '%s'", expl.Name, expl.Fingerprint),
+			"Entries are listed in the order they are fingerprinted",
+		},
+	}
+
+	for _, entry := range expl.Entries {
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(c.entryType(entry)),
+			boshtbl.NewValueString(entry.Name),
+			boshtbl.NewValueString(entry.Digest),
+			boshtbl.NewValueString(entry.Mode),
+		})
+	}
+
+	c.ui.PrintTable(table)
+}
+
+func (c ExplainFingerprintCmd) printDifferences(current, previous boshreldir.FingerprintExplanation, prevVersion semver.Version) {
+	table := boshtbl.Table{
+		Content: "fingerprint differences",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Type"),
+			boshtbl.NewHeader("Name"),
+			boshtbl.NewHeader("Change"),
+			boshtbl.NewHeader("Previous"),
+			boshtbl.NewHeader("Current"),
+		},
+		SortBy: []boshtbl.ColumnSort{{Column: 1, Asc: true}},
+		Notes: []string{
+			fmt.Sprintf("Fingerprint of '%s' in release version '%s' is '%s'", previous.Name, prevVersion.AsString(), previous.Fingerprint),
+			fmt.Sprintf("Fingerprint of '%s' in release directory is '%s'", current.Name, current.Fingerprint),
+		},
+	}
+
+	prevEntries := map[string]boshres.FingerprintEntry{}
+
+	for _, entry := range previous.Entries {
+		prevEntries[c.entryKey(entry)] = entry
+	}
+
+	for _, entry := range current.Entries {
+		prevEntry, found := prevEntries[c.entryKey(entry)]
+		delete(prevEntries, c.entryKey(entry))
+
+		switch {
+		case !found:
+			table.Rows = append(table.Rows, c.differenceRow(entry, "added", "", c.entryValue(entry)))
+		case prevEntry != entry:
+			table.Rows = append(table.Rows, c.differenceRow(entry, "changed", c.entryValue(prevEntry), c.entryValue(entry)))
+		}
+	}
+
+	for _, entry := range prevEntries {
+		table.Rows = append(table.Rows, c.differenceRow(entry, "removed", c.entryValue(entry), ""))
+	}
+
+	c.ui.PrintTable(table)
+}
+
+func (c ExplainFingerprintCmd) differenceRow(entry boshres.FingerprintEntry, change, prev, curr string) []boshtbl.Value {
+	name := entry.Name
+	if entry.AdditionalChunks {
+		name = ""
+	}
+
+	return []boshtbl.Value{
+		boshtbl.NewValueString(c.entryType(entry)),
+		boshtbl.NewValueString(name),
+		boshtbl.NewValueString(change),
+		boshtbl.NewValueString(prev),
+		boshtbl.NewValueString(curr),
+	}
+}
+
+// entryKey matches additional chunks regardless of their content
+func (c ExplainFingerprintCmd) entryKey(entry boshres.FingerprintEntry) string {
+	if entry.AdditionalChunks {
+		return "chunks"
+	}
+	return "file:" + entry.Name
+}
+
+func (c ExplainFingerprintCmd) entryValue(entry boshres.FingerprintEntry) string {
+	if entry.AdditionalChunks {
+		return entry.Name
+	}
+	if len(entry.Mode) > 0 {
+		return entry.Digest + " " + entry.Mode
+	}
+	return entry.Digest
+}
+
+func (c ExplainFingerprintCmd) entryType(entry boshres.FingerprintEntry) string {
+	if entry.AdditionalChunks {
+		return "chunks"
+	}
+	return "file"
+}
@@ -0,0 +1,216 @@
+package cmd_test
+
+import (
+	"errors"
+
+	semver "github.com/cppforlife/go-semi-semantic/version"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
+	boshreldir "github.com/cloudfoundry/bosh-cli/releasedir"
+	fakereldir "github.com/cloudfoundry/bosh-cli/releasedir/releasedirfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("ExplainFingerprintCmd", func() {
+	var (
+		explainer *fakereldir.FakeFingerprintExplainer
+		ui        *fakeui.FakeUI
+		command   ExplainFingerprintCmd
+	)
+
+	BeforeEach(func() {
+		explainer = &fakereldir.FakeFingerprintExplainer{}
+		ui = &fakeui.FakeUI{}
+		command = NewExplainFingerprintCmd(explainer, ui)
+	})
+
+	Describe("Run", func() {
+		var (
+			opts ExplainFingerprintOpts
+		)
+
+		BeforeEach(func() {
+			opts = ExplainFingerprintOpts{Package: "pkg"}
+		})
+
+		act := func() error { return command.Run(opts) }
+
+		It("shows package fingerprint entries", func() {
+			explainer.ExplainPackageReturns(boshreldir.FingerprintExplanation{
+				Name:        "pkg",
+				Fingerprint: "fp",
+				Entries: []boshres.FingerprintEntry{
+					{Name: "packaging", Digest: "sha1"},
+					{Name: "file", Digest: "sha2", Mode: "100644"},
+					{Name: "dep1,dep2", AdditionalChunks: true},
+				},
+			}, nil)
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			name, ver := explainer.ExplainPackageArgsForCall(0)
+			Expect(name).To(Equal("pkg"))
+			Expect(ver.Empty()).To(BeTrue())
+
+			Expect(ui.Table).To(Equal(boshtbl.Table{
+				Content: "fingerprint entries",
+				Header: []boshtbl.Header{
+					boshtbl.NewHeader("Type"),
+					boshtbl.NewHeader("Name"),
+					boshtbl.NewHeader("Digest"),
+					boshtbl.NewHeader("Mode"),
+				},
+				Rows: [][]boshtbl.Value{
+					{
+						boshtbl.NewValueString("file"),
+						boshtbl.NewValueString("packaging"),
+						boshtbl.NewValueString("sha1"),
+						boshtbl.NewValueString(""),
+					},
+					{
+						boshtbl.NewValueString("file"),
+						boshtbl.NewValueString("file"),
+						boshtbl.NewValueString("sha2"),
+						boshtbl.NewValueString("100644"),
+					},
+					{
+						boshtbl.NewValueString("chunks"),
+						boshtbl.NewValueString("dep1,dep2"),
+						boshtbl.NewValueString(""),
+						boshtbl.NewValueString(""),
+					},
+				},
+				Notes: []string{
+					"Fingerprint of 'pkg' is 'fp'",
+					"Entries are listed in the order they are fingerprinted",
+				},
+			}))
+		})
+
+		It("explains job when job is given", func() {
+			opts = ExplainFingerprintOpts{Job: "job"}
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(explainer.ExplainJobCallCount()).To(Equal(1))
+			Expect(explainer.ExplainPackageCallCount()).To(Equal(0))
+
+			name, _ := explainer.ExplainJobArgsForCall(0)
+			Expect(name).To(Equal("job"))
+		})
+
+		It("shows differing entries when previous version is given", func() {
+			opts.PreviousVersion = VersionArg(semver.MustNewVersionFromString("1+dev.1"))
+
+			explainer.ExplainPackageStub = func(name string, ver semver.Version) (boshreldir.FingerprintExplanation, error) {
+				if ver.Empty() {
+					return boshreldir.FingerprintExplanation{
+						Name:        "pkg",
+						Fingerprint: "cur-fp",
+						Entries: []boshres.FingerprintEntry{
+							{Name: "added", Digest: "sha1", Mode: "100644"},
+							{Name: "changed", Digest: "sha2", Mode: "100755"},
+							{Name: "same", Digest: "sha3", Mode: "100644"},
+							{Name: "dep1,dep2", AdditionalChunks: true},
+						},
+					}, nil
+				}
+
+				return boshreldir.FingerprintExplanation{
+					Name:        "pkg",
+					Fingerprint: "prev-fp",
+					Entries: []boshres.FingerprintEntry{
+						{Name: "changed", Digest: "sha2", Mode: "100644"},
+						{Name: "removed", Digest: "sha4", Mode: "100644"},
+						{Name: "same", Digest: "sha3", Mode: "100644"},
+						{Name: "dep1", AdditionalChunks: true},
+					},
+				}, nil
+			}
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			_, ver := explainer.ExplainPackageArgsForCall(1)
+			Expect(ver.AsString()).To(Equal("1+dev.1"))
+
+			Expect(ui.Table).To(Equal(boshtbl.Table{
+				Content: "fingerprint differences",
+				Header: []boshtbl.Header{
+					boshtbl.NewHeader("Type"),
+					boshtbl.NewHeader("Name"),
+					boshtbl.NewHeader("Change"),
+					boshtbl.NewHeader("Previous"),
+					boshtbl.NewHeader("Current"),
+				},
+				SortBy: []boshtbl.ColumnSort{{Column: 1, Asc: true}},
+				Rows: [][]boshtbl.Value{
+					{
+						boshtbl.NewValueString("file"),
+						boshtbl.NewValueString("added"),
+						boshtbl.NewValueString("added"),
+						boshtbl.NewValueString(""),
+						boshtbl.NewValueString("sha1 100644"),
+					},
+					{
+						boshtbl.NewValueString("file"),
+						boshtbl.NewValueString("changed"),
+						boshtbl.NewValueString("changed"),
+						boshtbl.NewValueString("sha2 100644"),
+						boshtbl.NewValueString("sha2 100755"),
+					},
+					{
+						boshtbl.NewValueString("chunks"),
+						boshtbl.NewValueString(""),
+						boshtbl.NewValueString("changed"),
+						boshtbl.NewValueString("dep1"),
+						boshtbl.NewValueString("dep1,dep2"),
+					},
+					{
+						boshtbl.NewValueString("file"),
+						boshtbl.NewValueString("removed"),
+						boshtbl.NewValueString("removed"),
+						boshtbl.NewValueString("sha4 100644"),
+						boshtbl.NewValueString(""),
+					},
+				},
+				Notes: []string{
+					"Fingerprint of 'pkg' in release version '1+dev.1' is 'prev-fp'",
+					"Fingerprint of 'pkg' in release directory is 'cur-fp'",
+				},
+			}))
+		})
+
+		It("returns error if neither job nor package is given", func() {
+			opts = ExplainFingerprintOpts{}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected either --job or --package to be specified"))
+		})
+
+		It("returns error if both job and package are given", func() {
+			opts = ExplainFingerprintOpts{Job: "job", Package: "pkg"}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected only one of --job or --package to be specified"))
+		})
+
+		It("returns error if explaining previous version fails", func() {
+			opts.PreviousVersion = VersionArg(semver.MustNewVersionFromString("1"))
+			explainer.ExplainPackageReturnsOnCall(1, boshreldir.FingerprintExplanation{}, errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+			Expect(ui.Tables).To(BeEmpty())
+		})
+	})
+})
@@ -100,6 +100,11 @@ func (f Factory) New(args []string) (Cmd, error) {
 			if field.IsValid() {
 				field.Set(reflect.ValueOf(f.deps.FS))
 			}
+
+			field = stype.FieldByName("CmdRunner")
+			if field.IsValid() {
+				field.Set(reflect.ValueOf(f.deps.CmdRunner))
+			}
 		}
 	}
 
@@ -388,6 +388,7 @@ var _ = Describe("Factory", func() {
 			boshOpts.CACertOpt.FS = nil // fs is populated by factory.New
 			boshOpts.UploadRelease = UploadReleaseOpts{}
 			boshOpts.ExportRelease = ExportReleaseOpts{}
+			boshOpts.Sbom = SbomOpts{}
 			boshOpts.RunErrand = RunErrandOpts{}
 			boshOpts.Logs = LogsOpts{}
 			boshOpts.Interpolate = InterpolateOpts{}
@@ -396,6 +397,8 @@ var _ = Describe("Factory", func() {
 			boshOpts.GenerateJob = GenerateJobOpts{}
 			boshOpts.GeneratePackage = GeneratePackageOpts{}
 			boshOpts.VendorPackage = VendorPackageOpts{}
+			boshOpts.LintRelease = LintReleaseOpts{}
+			boshOpts.ExplainFingerprint = ExplainFingerprintOpts{}
 			boshOpts.CreateRelease = CreateReleaseOpts{}
 			boshOpts.FinalizeRelease = FinalizeReleaseOpts{}
 			boshOpts.Blobs = BlobsOpts{}
@@ -412,6 +415,9 @@ var _ = Describe("Factory", func() {
 			boshOpts.Config = ConfigOpts{}
 			boshOpts.UpdateConfig = UpdateConfigOpts{}
 			boshOpts.DeleteConfig = DeleteConfigOpts{}
+			boshOpts.RotateVars = RotateVarsOpts{}
+			boshOpts.CheckCerts = CheckCertsOpts{}
+			boshOpts.RenderJob = RenderJobOpts{}
 			return boshOpts
 		}
 
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"errors"
+	"sync"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+// FanOutCmd runs read-only command against multiple environments in parallel
+// and merges tables that it prints into tables with an extra Environment column
+type FanOutCmd struct {
+	ui     boshui.UI
+	runner func(string, boshui.UI) error
+}
+
+func NewFanOutCmd(ui boshui.UI, runner func(string, boshui.UI) error) FanOutCmd {
+	return FanOutCmd{ui: ui, runner: runner}
+}
+
+type fanOutResult struct {
+	tables []boshtbl.Table
+	err    error
+}
+
+func (c FanOutCmd) Run(environments []string) error {
+	if len(environments) == 0 {
+		return errors.New("Expected at least one environment")
+	}
+
+	results := make([]fanOutResult, len(environments))
+
+	var wg sync.WaitGroup
+
+	for i, env := range environments {
+		wg.Add(1)
+
+		go func(i int, env string) {
+			defer wg.Done()
+
+			ui := &tablesUI{}
+			results[i] = fanOutResult{err: c.runner(env, ui), tables: ui.tables}
+		}(i, env)
+	}
+
+	wg.Wait()
+
+	var tables []*boshtbl.Table
+	var errs []error
+
+	for i, env := range environments {
+		for _, table := range results[i].tables {
+			tables = c.mergeTable(tables, env, table)
+		}
+
+		if results[i].err != nil {
+			errs = append(errs, bosherr.WrapErrorf(results[i].err, "Environment '%s'", env))
+		}
+	}
+
+	for _, table := range tables {
+		c.ui.PrintTable(*table)
+	}
+
+	if len(errs) > 0 {
+		return bosherr.NewMultiError(errs...)
+	}
+
+	return nil
+}
+
+// mergeTable adds table rows to a table with the same title and content;
+// columns are matched by header in case environments return different columns
+func (c FanOutCmd) mergeTable(tables []*boshtbl.Table, env string, table boshtbl.Table) []*boshtbl.Table {
+	var merged *boshtbl.Table
+
+	for _, t := range tables {
+		if t.Title == table.Title && t.Content == table.Content {
+			merged = t
+			break
+		}
+	}
+
+	if merged == nil {
+		merged = &boshtbl.Table{
+			Title:   table.Title,
+			Content: table.Content,
+			Header:  append([]boshtbl.Header{boshtbl.NewHeader("Environment")}, table.Header...),
+			SortBy:  []boshtbl.ColumnSort{{Column: 0, Asc: true}},
+		}
+
+		for _, s := range table.SortBy {
+			merged.SortBy = append(merged.SortBy, boshtbl.ColumnSort{Column: s.Column + 1, Asc: s.Asc})
+		}
+
+		tables = append(tables, merged)
+	}
+
+	columns := map[string]int{}
+
+	for i, h := range table.Header {
+		columns[fanOutHeaderID(h)] = i
+	}
+
+	for _, row := range fanOutRows(table) {
+		mergedRow := []boshtbl.Value{boshtbl.NewValueString(env)}
+
+		for _, h := range merged.Header[1:] {
+			i, found := columns[fanOutHeaderID(h)]
+			if found && i < len(row) {
+				mergedRow = append(mergedRow, row[i])
+			} else {
+				mergedRow = append(mergedRow, boshtbl.ValueString{})
+			}
+		}
+
+		merged.Rows = append(merged.Rows, mergedRow)
+	}
+
+	for _, note := range table.Notes {
+		if !fanOutHasNote(merged.Notes, note) {
+			merged.Notes = append(merged.Notes, note)
+		}
+	}
+
+	return tables
+}
+
+// fanOutRows flattens sections without sorting or deduping rows like Table.AsRows does
+func fanOutRows(table boshtbl.Table) [][]boshtbl.Value {
+	var rows [][]boshtbl.Value
+
+	for _, s := range table.Sections {
+		for _, r := range s.Rows {
+			row := append([]boshtbl.Value{}, r...)
+
+			if s.FirstColumn != nil && len(s.FirstColumn.String()) > 0 && len(row) > 0 {
+				row[0] = s.FirstColumn
+			}
+
+			rows = append(rows, row)
+		}
+	}
+
+	return append(rows, table.Rows...)
+}
+
+func fanOutHeaderID(h boshtbl.Header) string {
+	if len(h.Key) > 0 {
+		return h.Key
+	}
+
+	return h.Title
+}
+
+func fanOutHasNote(notes []string, note string) bool {
+	for _, n := range notes {
+		if n == note {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tablesUI collects printed tables; other output (e.g. environment details) is dropped
+type tablesUI struct {
+	tables []boshtbl.Table
+}
+
+var _ boshui.UI = &tablesUI{}
+
+func (ui *tablesUI) ErrorLinef(pattern string, args ...interface{}) {}
+func (ui *tablesUI) PrintLinef(pattern string, args ...interface{}) {}
+func (ui *tablesUI) BeginLinef(pattern string, args ...interface{}) {}
+func (ui *tablesUI) EndLinef(pattern string, args ...interface{})   {}
+func (ui *tablesUI) PrintBlock([]byte)                              {}
+func (ui *tablesUI) PrintErrorBlock(string)                         {}
+
+func (ui *tablesUI) PrintTable(table boshtbl.Table) {
+	ui.tables = append(ui.tables, table)
+}
+
+func (ui *tablesUI) AskForText(label string) (string, error) {
+	return "", errors.New("Cannot ask for input when running against multiple environments")
+}
+
+func (ui *tablesUI) AskForChoice(label string, options []string) (int, error) {
+	return 0, errors.New("Cannot ask for input when running against multiple environments")
+}
+
+func (ui *tablesUI) AskForPassword(label string) (string, error) {
+	return "", errors.New("Cannot ask for input when running against multiple environments")
+}
+
+func (ui *tablesUI) AskForConfirmation() error {
+	return errors.New("Cannot ask for input when running against multiple environments")
+}
+
+func (ui *tablesUI) IsInteractive() bool { return false }
+
+func (ui *tablesUI) Flush() {}
@@ -0,0 +1,176 @@
+package cmd_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("FanOutCmd", func() {
+	var (
+		ui      *fakeui.FakeUI
+		tables  map[string][]boshtbl.Table
+		errs    map[string]error
+		command FanOutCmd
+	)
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		tables = map[string][]boshtbl.Table{}
+		errs = map[string]error{}
+
+		runner := func(env string, ui boshui.UI) error {
+			ui.PrintLinef("Using environment '%s'", env)
+
+			for _, table := range tables[env] {
+				ui.PrintTable(table)
+			}
+
+			return errs[env]
+		}
+
+		command = NewFanOutCmd(ui, runner)
+	})
+
+	Describe("Run", func() {
+		deploymentsTable := func(names ...string) boshtbl.Table {
+			table := boshtbl.Table{
+				Content: "deployments",
+				Header:  []boshtbl.Header{boshtbl.NewHeader("Name")},
+				SortBy:  []boshtbl.ColumnSort{{Column: 0, Asc: true}},
+				Notes:   []string{"note"},
+			}
+
+			for _, name := range names {
+				table.Rows = append(table.Rows, []boshtbl.Value{boshtbl.NewValueString(name)})
+			}
+
+			return table
+		}
+
+		It("merges tables from all environments adding environment column", func() {
+			tables["env1"] = []boshtbl.Table{deploymentsTable("dep1", "dep2")}
+			tables["env2"] = []boshtbl.Table{deploymentsTable("dep3")}
+
+			err := command.Run([]string{"env1", "env2"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Said).To(BeEmpty())
+			Expect(ui.Tables).To(Equal([]boshtbl.Table{{
+				Content: "deployments",
+
+				Header: []boshtbl.Header{
+					boshtbl.NewHeader("Environment"),
+					boshtbl.NewHeader("Name"),
+				},
+
+				SortBy: []boshtbl.ColumnSort{
+					{Column: 0, Asc: true},
+					{Column: 1, Asc: true},
+				},
+
+				Rows: [][]boshtbl.Value{
+					{boshtbl.NewValueString("env1"), boshtbl.NewValueString("dep1")},
+					{boshtbl.NewValueString("env1"), boshtbl.NewValueString("dep2")},
+					{boshtbl.NewValueString("env2"), boshtbl.NewValueString("dep3")},
+				},
+
+				Notes: []string{"note"},
+			}}))
+		})
+
+		It("keeps tables with different titles separate", func() {
+			table1 := deploymentsTable("dep1")
+			table1.Title = "title1"
+
+			table2 := deploymentsTable("dep2")
+			table2.Title = "title2"
+
+			tables["env1"] = []boshtbl.Table{table1, table2}
+			tables["env2"] = []boshtbl.Table{deploymentsTable("dep3"), table2}
+
+			err := command.Run([]string{"env1", "env2"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Tables).To(HaveLen(3))
+			Expect(ui.Tables[0].Title).To(Equal("title1"))
+			Expect(ui.Tables[0].Rows).To(HaveLen(1))
+			Expect(ui.Tables[1].Title).To(Equal("title2"))
+			Expect(ui.Tables[1].Rows).To(HaveLen(2))
+			Expect(ui.Tables[2].Title).To(Equal(""))
+			Expect(ui.Tables[2].Rows).To(HaveLen(1))
+		})
+
+		It("matches columns by header when environments return different columns", func() {
+			table1 := deploymentsTable("dep1")
+
+			table2 := boshtbl.Table{
+				Content: "deployments",
+				Header:  []boshtbl.Header{boshtbl.NewHeader("Team"), boshtbl.NewHeader("Name")},
+				Rows: [][]boshtbl.Value{
+					{boshtbl.NewValueString("team"), boshtbl.NewValueString("dep2")},
+				},
+			}
+
+			tables["env1"] = []boshtbl.Table{table1}
+			tables["env2"] = []boshtbl.Table{table2}
+
+			err := command.Run([]string{"env1", "env2"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Table.Rows).To(Equal([][]boshtbl.Value{
+				{boshtbl.NewValueString("env1"), boshtbl.NewValueString("dep1")},
+				{boshtbl.NewValueString("env2"), boshtbl.NewValueString("dep2")},
+			}))
+		})
+
+		It("includes rows from table sections", func() {
+			tables["env1"] = []boshtbl.Table{{
+				Content: "instances",
+				Header:  []boshtbl.Header{boshtbl.NewHeader("Instance"), boshtbl.NewHeader("Process")},
+				Sections: []boshtbl.Section{{
+					FirstColumn: boshtbl.NewValueString("inst1"),
+					Rows: [][]boshtbl.Value{
+						{boshtbl.NewValueString("inst1"), boshtbl.NewValueString("")},
+						{boshtbl.ValueString{}, boshtbl.NewValueString("proc1")},
+					},
+				}},
+			}}
+
+			err := command.Run([]string{"env1"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Table.Rows).To(Equal([][]boshtbl.Value{
+				{boshtbl.NewValueString("env1"), boshtbl.NewValueString("inst1"), boshtbl.NewValueString("")},
+				{boshtbl.NewValueString("env1"), boshtbl.NewValueString("inst1"), boshtbl.NewValueString("proc1")},
+			}))
+		})
+
+		It("reports failed environments after showing results from other environments", func() {
+			tables["env1"] = []boshtbl.Table{deploymentsTable("dep1")}
+			errs["env2"] = errors.New("fake-err2")
+			errs["env3"] = errors.New("fake-err3")
+
+			err := command.Run([]string{"env1", "env2", "env3"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Environment 'env2': fake-err2"))
+			Expect(err.Error()).To(ContainSubstring("Environment 'env3': fake-err3"))
+
+			Expect(ui.Table.Rows).To(Equal([][]boshtbl.Value{
+				{boshtbl.NewValueString("env1"), boshtbl.NewValueString("dep1")},
+			}))
+		})
+
+		It("returns error if no environments are given", func() {
+			err := command.Run(nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected at least one environment"))
+		})
+	})
+})
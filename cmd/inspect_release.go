@@ -3,26 +3,46 @@ package cmd
 import (
 	"fmt"
 
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
 	boshdir "github.com/cloudfoundry/bosh-cli/director"
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
 	boshui "github.com/cloudfoundry/bosh-cli/ui"
 	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
 )
 
 type InspectReleaseCmd struct {
-	ui       boshui.UI
-	director boshdir.Director
+	ui            boshui.UI
+	director      boshdir.Director
+	releaseReader boshrel.Reader
 }
 
-func NewInspectReleaseCmd(ui boshui.UI, director boshdir.Director) InspectReleaseCmd {
-	return InspectReleaseCmd{ui: ui, director: director}
+func NewInspectReleaseCmd(ui boshui.UI, director boshdir.Director, releaseReader boshrel.Reader) InspectReleaseCmd {
+	return InspectReleaseCmd{ui: ui, director: director, releaseReader: releaseReader}
 }
 
 func (c InspectReleaseCmd) Run(opts InspectReleaseOpts) error {
+	if len(opts.Release) > 0 {
+		if len(opts.Graph) == 0 {
+			return bosherr.Error("Expected --release to be used with --graph")
+		}
+
+		return c.showLocalGraph(opts.Release, opts.Graph)
+	}
+
+	if len(opts.Args.Slug.Name()) == 0 {
+		return bosherr.Error("Expected release NAME/VERSION or --release to be specified")
+	}
+
 	release, err := c.director.FindRelease(opts.Args.Slug)
 	if err != nil {
 		return err
 	}
 
+	if len(opts.Graph) > 0 {
+		return c.showGraph(release, opts.Graph)
+	}
+
 	jobsTable := boshtbl.Table{
 		Content: "jobs",
 		Header: []boshtbl.Header{
@@ -97,3 +117,53 @@ func (c InspectReleaseCmd) Run(opts InspectReleaseOpts) error {
 
 	return nil
 }
+
+// showGraph only includes package dependencies since Director does not report job packages
+func (c InspectReleaseCmd) showGraph(release boshdir.Release, format string) error {
+	pkgs, err := release.Packages()
+	if err != nil {
+		return err
+	}
+
+	pkgDeps := map[string][]string{}
+
+	for _, p := range pkgs {
+		pkgDeps[p.Name] = p.Dependencies
+	}
+
+	return c.printGraph(newReleaseGraph(map[string][]string{}, pkgDeps, false), format)
+}
+
+func (c InspectReleaseCmd) showLocalGraph(path, format string) error {
+	release, err := c.releaseReader.Read(path)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Reading release '%s'", path)
+	}
+
+	defer release.CleanUp()
+
+	jobPkgs := map[string][]string{}
+
+	for _, job := range release.Jobs() {
+		jobPkgs[job.Name()] = job.PackageNames
+	}
+
+	pkgDeps := map[string][]string{}
+
+	for _, pkg := range release.Packages() {
+		pkgDeps[pkg.Name()] = pkg.DependencyNames()
+	}
+
+	return c.printGraph(newReleaseGraph(jobPkgs, pkgDeps, true), format)
+}
+
+func (c InspectReleaseCmd) printGraph(graph releaseGraph, format string) error {
+	output, err := graph.Format(format)
+	if err != nil {
+		return err
+	}
+
+	c.ui.PrintBlock([]byte(output))
+
+	return nil
+}
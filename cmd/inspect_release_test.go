@@ -9,21 +9,27 @@ import (
 	. "github.com/cloudfoundry/bosh-cli/cmd"
 	boshdir "github.com/cloudfoundry/bosh-cli/director"
 	fakedir "github.com/cloudfoundry/bosh-cli/director/directorfakes"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+	boshpkg "github.com/cloudfoundry/bosh-cli/release/pkg"
+	fakerel "github.com/cloudfoundry/bosh-cli/release/releasefakes"
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
 	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
 	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
 )
 
 var _ = Describe("InspectReleaseCmd", func() {
 	var (
-		ui       *fakeui.FakeUI
-		director *fakedir.FakeDirector
-		command  InspectReleaseCmd
+		ui            *fakeui.FakeUI
+		director      *fakedir.FakeDirector
+		releaseReader *fakerel.FakeReader
+		command       InspectReleaseCmd
 	)
 
 	BeforeEach(func() {
 		ui = &fakeui.FakeUI{}
 		director = &fakedir.FakeDirector{}
-		command = NewInspectReleaseCmd(ui, director)
+		releaseReader = &fakerel.FakeReader{}
+		command = NewInspectReleaseCmd(ui, director, releaseReader)
 	})
 
 	Describe("Run", func() {
@@ -197,5 +203,136 @@ var _ = Describe("InspectReleaseCmd", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("fake-err"))
 		})
+
+		It("returns error if release is not specified", func() {
+			opts.Args.Slug = boshdir.ReleaseSlug{}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected release NAME/VERSION or --release to be specified"))
+		})
+
+		Context("when graph is requested", func() {
+			BeforeEach(func() {
+				opts.Graph = "dot"
+
+				release.PackagesReturns([]boshdir.Package{
+					{Name: "app", Dependencies: []string{"golang"}},
+					{Name: "golang"},
+				}, nil)
+			})
+
+			It("shows package dependencies for uploaded release", func() {
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(director.FindReleaseArgsForCall(0)).To(Equal(boshdir.NewReleaseSlug("some-name", "some-version")))
+
+				Expect(ui.Blocks).To(Equal([]string{`digraph release {
+  "pkg/app" [label="app"];
+  "pkg/golang" [label="golang"];
+  "pkg/app" -> "pkg/golang";
+}
+`}))
+				Expect(ui.Tables).To(BeEmpty())
+			})
+
+			It("returns error if format is not supported", func() {
+				opts.Graph = "svg"
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Expected graph format 'svg' to be either 'dot' or 'json'"))
+			})
+
+			It("returns error if packages cannot be retrieved", func() {
+				release.PackagesReturns(nil, errors.New("fake-err"))
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+			})
+		})
+
+		Context("when local release is specified", func() {
+			var (
+				localRelease *fakerel.FakeRelease
+			)
+
+			BeforeEach(func() {
+				opts = InspectReleaseOpts{Release: "/release", Graph: "json"}
+
+				webJob := boshjob.NewJob(boshres.NewResource("web", "fp", nil))
+				webJob.PackageNames = []string{"app"}
+
+				localRelease = &fakerel.FakeRelease{}
+				localRelease.JobsReturns([]*boshjob.Job{webJob})
+				localRelease.PackagesReturns([]*boshpkg.Package{
+					boshpkg.NewPackage(boshres.NewResource("app", "fp", nil), []string{"lib-a"}),
+					boshpkg.NewPackage(boshres.NewResource("lib-a", "fp", nil), []string{"lib-b"}),
+					boshpkg.NewPackage(boshres.NewResource("lib-b", "fp", nil), []string{"lib-a"}),
+					boshpkg.NewPackage(boshres.NewResource("unused", "fp", nil), nil),
+				})
+
+				releaseReader.ReadReturns(localRelease, nil)
+			})
+
+			It("shows graph with job packages, cycles and orphans", func() {
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(releaseReader.ReadArgsForCall(0)).To(Equal("/release"))
+				Expect(localRelease.CleanUpCallCount()).To(Equal(1))
+				Expect(director.FindReleaseCallCount()).To(Equal(0))
+
+				Expect(ui.Blocks).To(HaveLen(1))
+				Expect(ui.Blocks[0]).To(MatchJSON(`{
+					"jobs": [{"name": "web", "packages": ["app"]}],
+					"packages": [
+						{"name": "app", "dependencies": ["lib-a"], "cyclic": false, "orphan": false},
+						{"name": "lib-a", "dependencies": ["lib-b"], "cyclic": true, "orphan": false},
+						{"name": "lib-b", "dependencies": ["lib-a"], "cyclic": true, "orphan": false},
+						{"name": "unused", "dependencies": [], "cyclic": false, "orphan": true}
+					],
+					"cycles": [["lib-a", "lib-b"]]
+				}`))
+			})
+
+			It("highlights cycles and orphans in dot format", func() {
+				opts.Graph = "dot"
+
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(ui.Blocks).To(Equal([]string{`digraph release {
+  "job/web" [label="web", shape=box];
+  "pkg/app" [label="app"];
+  "pkg/lib-a" [label="lib-a", color=red];
+  "pkg/lib-b" [label="lib-b", color=red];
+  "pkg/unused" [label="unused", style=dashed];
+  "job/web" -> "pkg/app";
+  "pkg/app" -> "pkg/lib-a";
+  "pkg/lib-a" -> "pkg/lib-b" [color=red];
+  "pkg/lib-b" -> "pkg/lib-a" [color=red];
+}
+`}))
+			})
+
+			It("returns error if graph is not requested", func() {
+				opts.Graph = ""
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Expected --release to be used with --graph"))
+			})
+
+			It("returns error if release cannot be read", func() {
+				releaseReader.ReadReturns(nil, errors.New("fake-err"))
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Reading release '/release'"))
+			})
+		})
 	})
 })
@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 	"github.com/cppforlife/go-patch/patch"
+	"gopkg.in/yaml.v2"
 
 	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
 )
 
 type InterpolateCmd struct {
@@ -16,15 +19,29 @@ func NewInterpolateCmd(ui boshui.UI) InterpolateCmd {
 }
 
 func (c InterpolateCmd) Run(opts InterpolateOpts) error {
+	if opts.Trace.IsSet() && (opts.Path.IsSet() || opts.StrictOps) {
+		return bosherr.Error("Expected '--trace' to not be given with '--path' or '--strict-ops'")
+	}
+
 	tpl := boshtpl.NewTemplate(opts.Args.Manifest.Bytes)
 
 	vars := opts.VarFlags.AsVariables()
 	op := opts.OpsFlags.AsOp()
+
+	if opts.StrictOps {
+		op = opts.OpsFlags.AsStrictOp()
+	}
+
 	evalOpts := boshtpl.EvaluateOpts{
 		ExpectAllKeys:     opts.VarErrors,
 		ExpectAllVarsUsed: opts.VarErrorsUnused,
 	}
 
+	// Traced manifest is evaluated with the same variable expectations
+	if opts.Trace.IsSet() {
+		return c.trace(tpl, opts, evalOpts)
+	}
+
 	if opts.Path.IsSet() {
 		evalOpts.PostVarSubstitutionOp = patch.FindOp{Path: opts.Path}
 
@@ -41,3 +58,74 @@ func (c InterpolateCmd) Run(opts InterpolateOpts) error {
 
 	return nil
 }
+
+func (c InterpolateCmd) trace(tpl boshtpl.Template, opts InterpolateOpts, evalOpts boshtpl.EvaluateOpts) error {
+	opsTrace := &OpsTrace{OpsFiles: opts.OpsFiles, Path: opts.Trace}
+
+	_, err := tpl.Evaluate(opts.VarFlags.AsVariables(), opsTrace, evalOpts)
+	if err != nil {
+		return err
+	}
+
+	val, err := patch.FindOp{Path: opts.Trace}.Apply(opsTrace.Doc)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Finding traced path")
+	}
+
+	valBytes, err := yaml.Marshal(val)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Serializing traced value")
+	}
+
+	// Interpolating only traced value shows variables used under path
+	tracedVars, varsTrace := opts.VarFlags.AsTracedVariables()
+
+	_, err = boshtpl.NewTemplate(valBytes).Evaluate(tracedVars, nil, boshtpl.EvaluateOpts{})
+	if err != nil {
+		return err
+	}
+
+	opsTable := boshtbl.Table{
+		Content: "operations",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("File"),
+			boshtbl.NewHeader("Index"),
+			boshtbl.NewHeader("Operation"),
+		},
+
+		Notes: []string{"Operations are listed in the order they were applied"},
+	}
+
+	for _, result := range opsTrace.Touched {
+		opsTable.Rows = append(opsTable.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(result.FilePath),
+			boshtbl.NewValueInt(result.Index),
+			boshtbl.NewValueString(result.Description()),
+		})
+	}
+
+	c.ui.PrintTable(opsTable)
+
+	varsTable := boshtbl.Table{
+		Content: "variables",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Name"),
+			boshtbl.NewHeader("Source"),
+		},
+
+		SortBy: []boshtbl.ColumnSort{{Column: 0, Asc: true}},
+	}
+
+	for _, entry := range varsTrace.Found {
+		varsTable.Rows = append(varsTable.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(entry.Name),
+			boshtbl.NewValueString(entry.Source),
+		})
+	}
+
+	c.ui.PrintTable(varsTable)
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	. "github.com/cloudfoundry/bosh-cli/cmd"
 	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
 )
 
 var _ = Describe("InterpolateCmd", func() {
@@ -61,6 +62,149 @@ var _ = Describe("InterpolateCmd", func() {
 			Expect(ui.Blocks).To(Equal([]string{bytes}))
 		})
 
+		It("returns an error if strict ops are requested and operations do not change template", func() {
+			opts.Args.Manifest = FileBytesArg{Bytes: []byte("name1: val1")}
+
+			opts.OpsFiles = []OpsFileArg{
+				{
+					FilePath: "/ops.yml",
+					Ops: patch.Ops([]patch.Op{
+						patch.ReplaceOp{Path: patch.MustNewPointerFromString("/name1"), Value: "val1"},
+					}),
+				},
+			}
+
+			opts.StrictOps = true
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(
+				"Ops file '/ops.yml' operation [0] (replace /name1): Expected operation to change document"))
+
+			opts.StrictOps = false
+
+			err = act()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("shows ops files and variables that affected value at traced path", func() {
+			opts.Args.Manifest = FileBytesArg{
+				Bytes: []byte("name: ((name))\nprops:\n  a: ((a))\n  b: b\nother: ((other))"),
+			}
+
+			opts.VarKVs = []boshtpl.VarKV{
+				{Name: "a", Value: "a-from-kv"},
+				{Name: "c", Value: "c-from-kv"},
+				{Name: "name", Value: "name"},
+				{Name: "other", Value: "other"},
+			}
+
+			opts.VarsFiles = []boshtpl.VarsFileArg{
+				{FilePath: "/vars.yml", Vars: boshtpl.StaticVariables{"d": "d-from-file"}},
+			}
+
+			opts.OpsFiles = []OpsFileArg{
+				{
+					FilePath: "/ops1.yml",
+					Ops: patch.Ops{
+						patch.ReplaceOp{Path: patch.MustNewPointerFromString("/props/b"), Value: "((c))"},
+						patch.ReplaceOp{Path: patch.MustNewPointerFromString("/other"), Value: "changed"},
+					},
+				},
+				{
+					FilePath: "/ops2.yml",
+					Ops: patch.Ops{
+						patch.ReplaceOp{Path: patch.MustNewPointerFromString("/props/d?"), Value: "((d))"},
+						patch.ReplaceOp{Path: patch.MustNewPointerFromString("/props/b"), Value: "((c))"},
+					},
+				},
+			}
+
+			opts.Trace = patch.MustNewPointerFromString("/props")
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Blocks).To(BeEmpty())
+			Expect(ui.Tables).To(HaveLen(2))
+
+			Expect(ui.Tables[0]).To(Equal(boshtbl.Table{
+				Content: "operations",
+
+				Header: []boshtbl.Header{
+					boshtbl.NewHeader("File"),
+					boshtbl.NewHeader("Index"),
+					boshtbl.NewHeader("Operation"),
+				},
+
+				Rows: [][]boshtbl.Value{
+					{
+						boshtbl.NewValueString("/ops1.yml"),
+						boshtbl.NewValueInt(0),
+						boshtbl.NewValueString("replace /props/b"),
+					},
+					{
+						boshtbl.NewValueString("/ops2.yml"),
+						boshtbl.NewValueInt(0),
+						boshtbl.NewValueString("replace /props/d?"),
+					},
+				},
+
+				Notes: []string{"Operations are listed in the order they were applied"},
+			}))
+
+			Expect(ui.Tables[1].Content).To(Equal("variables"))
+			Expect(ui.Tables[1].Header).To(Equal([]boshtbl.Header{
+				boshtbl.NewHeader("Name"),
+				boshtbl.NewHeader("Source"),
+			}))
+			Expect(ui.Tables[1].Rows).To(ConsistOf(
+				[]boshtbl.Value{boshtbl.NewValueString("a"), boshtbl.NewValueString("--var")},
+				[]boshtbl.Value{boshtbl.NewValueString("c"), boshtbl.NewValueString("--var")},
+				[]boshtbl.Value{boshtbl.NewValueString("d"), boshtbl.NewValueString("--vars-file /vars.yml")},
+			))
+		})
+
+		It("returns an error if traced path cannot be found", func() {
+			opts.Args.Manifest = FileBytesArg{Bytes: []byte("name: name")}
+			opts.Trace = patch.MustNewPointerFromString("/missing")
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Finding traced path"))
+		})
+
+		It("returns an error if trace is given with path or strict ops", func() {
+			opts.Args.Manifest = FileBytesArg{Bytes: []byte("name: name")}
+			opts.Trace = patch.MustNewPointerFromString("/name")
+			opts.Path = patch.MustNewPointerFromString("/name")
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected '--trace' to not be given with '--path' or '--strict-ops'"))
+
+			opts.Path = patch.Pointer{}
+			opts.StrictOps = true
+
+			err = act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected '--trace' to not be given with '--path' or '--strict-ops'"))
+
+			Expect(ui.Blocks).To(BeEmpty())
+			Expect(ui.Tables).To(BeEmpty())
+		})
+
+		It("returns an error if traced manifest is missing variables and var-errs flag is set", func() {
+			opts.Args.Manifest = FileBytesArg{Bytes: []byte("name1: ((name1))\nname2: ((name2))")}
+			opts.Trace = patch.MustNewPointerFromString("/name1")
+			opts.VarKVs = []boshtpl.VarKV{{Name: "name1", Value: "val1-from-kv"}}
+			opts.VarErrors = true
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected to find variables: name2"))
+		})
+
 		It("returns portion of the template after it's interpolated if path is given", func() {
 			opts.Args.Manifest = FileBytesArg{
 				Bytes: []byte("name1: ((name1))\nname2: ((name2))"),
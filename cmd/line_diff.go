@@ -0,0 +1,86 @@
+package cmd
+
+// Number of unchanged lines shown around changed lines
+const diffContextLines = 2
+
+// diffChangedLines keeps changed lines with few surrounding lines
+// and replaces skipped unchanged lines with '...'
+func diffChangedLines(allLines [][]interface{}) [][]interface{} {
+	var lines [][]interface{}
+	var lastIncluded = -1
+
+	for i, line := range allLines {
+		if !diffIsNearChange(allLines, i) {
+			continue
+		}
+
+		if lastIncluded >= 0 && lastIncluded != i-1 {
+			lines = append(lines, []interface{}{"...", nil})
+		}
+
+		lines = append(lines, line)
+		lastIncluded = i
+	}
+
+	return lines
+}
+
+func diffIsNearChange(lines [][]interface{}, i int) bool {
+	for j := i - diffContextLines; j <= i+diffContextLines; j++ {
+		if j >= 0 && j < len(lines) && lines[j][1] != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// diffLines returns all lines marked as added, removed or unchanged (nil)
+// based on longest common subsequence of lines
+func diffLines(from, to []string) [][]interface{} {
+	lcs := make([][]int, len(from)+1)
+
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines [][]interface{}
+	var i, j int
+
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			lines = append(lines, []interface{}{from[i], nil})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, []interface{}{from[i], "removed"})
+			i++
+		default:
+			lines = append(lines, []interface{}{to[j], "added"})
+			j++
+		}
+	}
+
+	for ; i < len(from); i++ {
+		lines = append(lines, []interface{}{from[i], "removed"})
+	}
+
+	for ; j < len(to); j++ {
+		lines = append(lines, []interface{}{to[j], "added"})
+	}
+
+	return lines
+}
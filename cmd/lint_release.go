@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	boshreldir "github.com/cloudfoundry/bosh-cli/releasedir"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+type LintReleaseCmd struct {
+	linter boshreldir.Linter
+	ui     boshui.UI
+}
+
+func NewLintReleaseCmd(linter boshreldir.Linter, ui boshui.UI) LintReleaseCmd {
+	return LintReleaseCmd{linter: linter, ui: ui}
+}
+
+func (c LintReleaseCmd) Run() error {
+	problems, err := c.linter.Lint()
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Linting release")
+	}
+
+	table := boshtbl.Table{
+		Content: "lint problems",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Severity"),
+			boshtbl.NewHeader("Check"),
+			boshtbl.NewHeader("Subject"),
+			boshtbl.NewHeader("Message"),
+		},
+	}
+
+	var errs int
+
+	for _, problem := range problems {
+		if problem.Severity == boshreldir.LintSeverityError {
+			errs++
+		}
+
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueFmt(boshtbl.NewValueString(problem.Severity), problem.Severity == boshreldir.LintSeverityError),
+			boshtbl.NewValueString(problem.Check),
+			boshtbl.NewValueString(problem.Subject),
+			boshtbl.NewValueString(problem.Message),
+		})
+	}
+
+	c.ui.PrintTable(table)
+
+	if errs > 0 {
+		return bosherr.Errorf("Expected release to not have lint errors but found %d", errs)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package cmd_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	boshreldir "github.com/cloudfoundry/bosh-cli/releasedir"
+	fakereldir "github.com/cloudfoundry/bosh-cli/releasedir/releasedirfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("LintReleaseCmd", func() {
+	var (
+		linter  *fakereldir.FakeLinter
+		ui      *fakeui.FakeUI
+		command LintReleaseCmd
+	)
+
+	BeforeEach(func() {
+		linter = &fakereldir.FakeLinter{}
+		ui = &fakeui.FakeUI{}
+		command = NewLintReleaseCmd(linter, ui)
+	})
+
+	Describe("Run", func() {
+		act := func() error { return command.Run() }
+
+		It("shows lint problems and succeeds if there are only warnings", func() {
+			linter.LintReturns([]boshreldir.LintProblem{
+				{Severity: "warning", Check: "unused-package", Subject: "packages/pkg", Message: "msg"},
+			}, nil)
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Table).To(Equal(boshtbl.Table{
+				Content: "lint problems",
+				Header: []boshtbl.Header{
+					boshtbl.NewHeader("Severity"),
+					boshtbl.NewHeader("Check"),
+					boshtbl.NewHeader("Subject"),
+					boshtbl.NewHeader("Message"),
+				},
+				Rows: [][]boshtbl.Value{
+					{
+						boshtbl.NewValueFmt(boshtbl.NewValueString("warning"), false),
+						boshtbl.NewValueString("unused-package"),
+						boshtbl.NewValueString("packages/pkg"),
+						boshtbl.NewValueString("msg"),
+					},
+				},
+			}))
+		})
+
+		It("returns error if there are lint errors", func() {
+			linter.LintReturns([]boshreldir.LintProblem{
+				{Severity: "error", Check: "missing-template", Subject: "jobs/job", Message: "msg"},
+				{Severity: "warning", Check: "unused-package", Subject: "packages/pkg", Message: "msg"},
+			}, nil)
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected release to not have lint errors but found 1"))
+
+			Expect(ui.Table.Rows).To(HaveLen(2))
+			Expect(ui.Table.Rows[0][0]).To(Equal(boshtbl.NewValueFmt(boshtbl.NewValueString("error"), true)))
+		})
+
+		It("returns error if linting fails", func() {
+			linter.LintReturns(nil, errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+			Expect(ui.Tables).To(BeEmpty())
+		})
+	})
+})
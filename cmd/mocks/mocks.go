@@ -38,3 +38,13 @@ func (_m *MockDeploymentDeleter) DeleteDeployment(_param0 ui.Stage) error {
 func (_mr *_MockDeploymentDeleterRecorder) DeleteDeployment(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "DeleteDeployment", arg0)
 }
+
+func (_m *MockDeploymentDeleter) PlanDeleteDeployment(_param0 ui.Stage) error {
+	ret := _m.ctrl.Call(_m, "PlanDeleteDeployment", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDeploymentDeleterRecorder) PlanDeleteDeployment(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "PlanDeleteDeployment", arg0)
+}
@@ -38,6 +38,8 @@ var _ = Describe("OpsFileArg", func() {
 				patch.RemoveOp{Path: patch.MustNewPointerFromString("/a")},
 				patch.RemoveOp{Path: patch.MustNewPointerFromString("/b")},
 			}))
+
+			Expect(arg.FilePath).To(Equal("/some/path"))
 		})
 
 		It("returns an error if operations are not valid", func() {
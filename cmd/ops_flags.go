@@ -18,3 +18,9 @@ func (f OpsFlags) AsOp() patch.Op {
 
 	return ops
 }
+
+// AsStrictOp returns an op that fails if any operation does not apply
+// or does not change the document
+func (f OpsFlags) AsStrictOp() patch.Op {
+	return StrictOps{OpsFiles: f.OpsFiles}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"reflect"
+
+	"github.com/cppforlife/go-patch/patch"
+)
+
+// OpsTrace applies operations and records which of them changed value at path
+type OpsTrace struct {
+	OpsFiles []OpsFileArg
+	Path     patch.Pointer
+
+	Touched []OpResult
+
+	// Doc is a copy of the document after all operations were applied
+	Doc interface{}
+}
+
+var _ patch.Op = &OpsTrace{}
+
+func (t *OpsTrace) Apply(doc interface{}) (interface{}, error) {
+	t.Touched = nil
+
+	for _, opsFile := range t.OpsFiles {
+		for i, op := range opsFile.Ops {
+			prevVal, err := t.valueAtPath(doc)
+			if err != nil {
+				return nil, err
+			}
+
+			doc, err = op.Apply(doc)
+			if err != nil {
+				return nil, err
+			}
+
+			val, err := t.valueAtPath(doc)
+			if err != nil {
+				return nil, err
+			}
+
+			if !reflect.DeepEqual(prevVal, val) {
+				t.Touched = append(t.Touched, OpResult{FilePath: opsFile.FilePath, Index: i, Op: op})
+			}
+		}
+	}
+
+	// Interpolation modifies document in place
+	docCopy, err := copyDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Doc = docCopy
+
+	return doc, nil
+}
+
+func (t *OpsTrace) valueAtPath(doc interface{}) (interface{}, error) {
+	val, err := patch.FindOp{Path: t.Path}.Apply(doc)
+	if err != nil {
+		// Path may not exist before or after some operations
+		return nil, nil
+	}
+
+	return copyDocument(val)
+}
@@ -20,6 +20,10 @@ type BoshOpts struct {
 	Sha2           bool      `long:"sha2"                  description:"Use SHA256 checksums" env:"BOSH_SHA2"`
 	Parallel       int       `long:"parallel" description:"The max number of parallel operations" default:"5"`
 
+	// Fan out read-only commands
+	EnvironmentsOpt    EnvironmentsArg `long:"environments" value-name:"NAMES" description:"Run read-only command against comma-separated environments"`
+	AllEnvironmentsOpt bool            `long:"all-environments"                description:"Run read-only command against all environments in config"`
+
 	// Hidden
 	UsernameOpt string `long:"user" hidden:"true" env:"BOSH_USER"`
 
@@ -32,6 +36,7 @@ type BoshOpts struct {
 	// Output formatting
 	ColumnOpt         []ColumnOpt `long:"column"                    description:"Filter to show only given column(s)"`
 	JSONOpt           bool        `long:"json"                      description:"Output as JSON"`
+	NDJSONOpt         bool        `long:"ndjson"                    description:"Stream task events as newline-delimited JSON"`
 	TTYOpt            bool        `long:"tty"                       description:"Force TTY-like output"`
 	NoColorOpt        bool        `long:"no-color"                  description:"Toggle colorized output"`
 	NonInteractiveOpt bool        `long:"non-interactive" short:"n" description:"Don't ask for user input" env:"BOSH_NON_INTERACTIVE"`
@@ -46,6 +51,7 @@ type BoshOpts struct {
 	CreateEnv    CreateEnvOpts    `command:"create-env"                description:"Create or update BOSH environment"`
 	DeleteEnv    DeleteEnvOpts    `command:"delete-env"                description:"Delete BOSH environment"`
 	AliasEnv     AliasEnvOpts     `command:"alias-env"                 description:"Alias environment to save URL and CA certificate"`
+	Cache        CacheOpts        `command:"cache"                     description:"Manage compiled packages cached by create-env"`
 
 	// Authentication
 	LogIn  LogInOpts  `command:"log-in"  alias:"l" alias:"login"  description:"Log in"`
@@ -86,9 +92,15 @@ type BoshOpts struct {
 
 	Deploy   DeployOpts   `command:"deploy"   alias:"d"   description:"Update deployment"`
 	Manifest ManifestOpts `command:"manifest" alias:"man" description:"Show deployment manifest"`
+	Drift    DriftOpts    `command:"drift"                description:"Check whether deployment matches manifest and latest configs"`
 
 	Interpolate InterpolateOpts `command:"interpolate" alias:"int" description:"Interpolates variables into a manifest"`
 
+	RekeyVarsStore RekeyVarsStoreOpts `command:"rekey-vars-store" description:"Re-encrypt or decrypt vars store"`
+	RotateVars     RotateVarsOpts     `command:"rotate-vars"      description:"Regenerate variables in vars store"`
+	CheckCerts     CheckCertsOpts     `command:"check-certs"      description:"Check certificates in vars store and manifest for expiration"`
+	ValidateOps    ValidateOpsOpts    `command:"validate-ops"     description:"Validate that ops files apply to a manifest"`
+
 	// Events
 	Events EventsOpts `command:"events" description:"List events"`
 	Event  EventOpts  `command:"event" description:"Show event details"`
@@ -104,6 +116,8 @@ type BoshOpts struct {
 	UploadRelease  UploadReleaseOpts  `command:"upload-release"  alias:"ur"   description:"Upload release"`
 	ExportRelease  ExportReleaseOpts  `command:"export-release"               description:"Export the compiled release to a tarball"`
 	InspectRelease InspectReleaseOpts `command:"inspect-release"              description:"List release contents such as jobs"`
+	DiffReleases   DiffReleasesOpts   `command:"diff-releases"                description:"Show differences between two release tarballs"`
+	Sbom           SbomOpts           `command:"sbom"                         description:"Generate software bill of materials for release tarball or directory"`
 	DeleteRelease  DeleteReleaseOpts  `command:"delete-release"  alias:"delr" description:"Delete release"`
 
 	// Errands
@@ -151,6 +165,14 @@ type BoshOpts struct {
 	GeneratePackage GeneratePackageOpts `command:"generate-package"            description:"Generate package"`
 	CreateRelease   CreateReleaseOpts   `command:"create-release"   alias:"cr" description:"Create release"`
 	VendorPackage   VendorPackageOpts   `command:"vendor-package"              description:"Vendor package"`
+	LintRelease     LintReleaseOpts     `command:"lint-release"                description:"Check release directory for common problems"`
+
+	ExplainFingerprint ExplainFingerprintOpts `command:"explain-fingerprint" description:"Show entries that make up job or package fingerprint"`
+	SignRelease        SignReleaseOpts        `command:"sign-release"        description:"Sign release tarball"`
+
+	// Job testing
+	RenderJob          RenderJobOpts          `command:"render-job"          description:"Render job templates with given properties and instance spec"`
+	ValidateProperties ValidatePropertiesOpts `command:"validate-properties" description:"Validate manifest properties against job specs"`
 
 	// Hidden
 	Sha1ifyRelease  Sha1ifyReleaseOpts  `command:"sha1ify-release"  hidden:"true" description:"Convert release tarball to use SHA1"`
@@ -176,8 +198,14 @@ type CreateEnvOpts struct {
 	Args CreateEnvArgs `positional-args:"true" required:"true"`
 	VarFlags
 	OpsFlags
+	ReleaseSignatureFlags
 	StatePath string `long:"state" value-name:"PATH" description:"State file path"`
 	Recreate  bool   `long:"recreate" description:"Recreate VM in deployment"`
+	StrictOps bool   `long:"strict-ops" description:"Fail if any ops file operation does not apply or does not change manifest"`
+	Plan      bool   `long:"plan" description:"Show CPI actions that would be taken without making any changes"`
+	RecordCPI string `long:"record-cpi" value-name:"DIR" description:"Write CPI requests and responses as JSON files to a directory"`
+	ReplayCPI string `long:"replay-cpi" value-name:"DIR" description:"Answer CPI requests with responses written by --record-cpi to a directory"`
+	FakeCPI   string `long:"fake-cpi" value-name:"DIR" description:"Use built-in fake CPI keeping VMs, disks and stemcells in a directory"`
 	cmd
 }
 
@@ -190,6 +218,8 @@ type DeleteEnvOpts struct {
 	VarFlags
 	OpsFlags
 	StatePath string `long:"state" value-name:"PATH" description:"State file path"`
+	Plan      bool   `long:"plan" description:"Show CPI actions that would be taken without making any changes"`
+	FakeCPI   string `long:"fake-cpi" value-name:"DIR" description:"Use built-in fake CPI keeping VMs, disks and stemcells in a directory"`
 	cmd
 }
 
@@ -206,6 +236,20 @@ type EnvironmentsOpts struct {
 	cmd
 }
 
+type CacheOpts struct {
+	Ls    CacheLsOpts    `command:"ls"    description:"List cached compiled packages"`
+	Prune CachePruneOpts `command:"prune" description:"Remove cached compiled packages"`
+}
+
+type CacheLsOpts struct {
+	cmd
+}
+
+type CachePruneOpts struct {
+	UnusedFor DurationArg `long:"unused-for" value-name:"DURATION" description:"Only remove packages not used within duration (e.g.: 30d, 12h)"`
+	cmd
+}
+
 type AliasEnvOpts struct {
 	Args AliasEnvArgs `positional-args:"true" required:"true"`
 
@@ -236,6 +280,8 @@ type TaskOpts struct {
 	Debug  bool `long:"debug"  description:"Track debug log"`
 	Result bool `long:"result" description:"Track result log"`
 
+	Resume bool `long:"resume" description:"Continue event log from where it was last consumed and follow task to completion"`
+
 	All        bool `long:"all" short:"a" description:"Include all task types (ssh, logs, vms, etc)"`
 	Deployment string
 
@@ -288,8 +334,10 @@ type InterpolateOpts struct {
 	OpsFlags
 
 	Path            patch.Pointer `long:"path" value-name:"OP-PATH" description:"Extract value out of template (e.g.: /private_key)"`
+	Trace           patch.Pointer `long:"trace" value-name:"OP-PATH" description:"Show ops files and variables that affected value at path"`
 	VarErrors       bool          `long:"var-errs"                  description:"Expect all variables to be found, otherwise error"`
 	VarErrorsUnused bool          `long:"var-errs-unused"           description:"Expect all variables to be used, otherwise error"`
+	StrictOps       bool          `long:"strict-ops"                description:"Fail if any ops file operation does not apply or does not change manifest"`
 
 	cmd
 }
@@ -298,6 +346,60 @@ type InterpolateArgs struct {
 	Manifest FileBytesArg `positional-arg-name:"PATH" description:"Path to a template that will be interpolated"`
 }
 
+type RekeyVarsStoreOpts struct {
+	Args RekeyVarsStoreArgs `positional-args:"true" required:"true"`
+
+	Key     VarsStoreKeyArg `long:"key"     value-name:"PATH" description:"Current key file path (or BOSH_VARS_STORE_PASSPHRASE)" env:"BOSH_VARS_STORE_KEY"`
+	NewKey  VarsStoreKeyArg `long:"new-key" value-name:"PATH" description:"New key file path (or BOSH_VARS_STORE_NEW_PASSPHRASE)"`
+	Decrypt bool            `long:"decrypt"                   description:"Save vars store without encryption"`
+
+	cmd
+}
+
+type RekeyVarsStoreArgs struct {
+	VarsStore VarsFSStore `positional-arg-name:"PATH" description:"Path to a vars store"`
+}
+
+type RotateVarsOpts struct {
+	Manifest FileBytesArg `long:"manifest" value-name:"PATH" description:"Path to a manifest with variable definitions" required:"true"`
+
+	VarFlags
+	OpsFlags
+
+	Names        []string `long:"name"          value-name:"NAME"   description:"Regenerate variable"`
+	SignedBy     []string `long:"signed-by"     value-name:"CA"     description:"Regenerate all certificates signed by CA (including intermediate CAs)"`
+	BackupSuffix string   `long:"backup-suffix" value-name:"SUFFIX" description:"Suffix for names that keep previous values" default:"_old"`
+
+	cmd
+}
+
+type CheckCertsOpts struct {
+	Args CheckCertsArgs `positional-args:"true"`
+
+	VarFlags
+	OpsFlags
+
+	ExpiringWithin DurationArg `long:"expiring-within" value-name:"DURATION" description:"Fail if certificates expire within duration (e.g.: 30d, 12h)" default:"30d"`
+
+	cmd
+}
+
+type CheckCertsArgs struct {
+	Manifest FileBytesArg `positional-arg-name:"PATH" description:"Path to a manifest that will be interpolated"`
+}
+
+type ValidateOpsOpts struct {
+	Args ValidateOpsArgs `positional-args:"true" required:"true"`
+
+	OpsFlags
+
+	cmd
+}
+
+type ValidateOpsArgs struct {
+	Manifest FileBytesArg `positional-arg-name:"PATH" description:"Path to a manifest that ops files will be applied to"`
+}
+
 // Config
 type ConfigOpts struct {
 	Args ConfigArgs `positional-args:"true" required:"true"`
@@ -434,6 +536,8 @@ type DeployOpts struct {
 
 	DryRun bool `long:"dry-run" description:"Renders job templates without altering deployment"`
 
+	StrictOps bool `long:"strict-ops" description:"Fail if any ops file operation does not apply or does not change manifest"`
+
 	cmd
 }
 
@@ -441,6 +545,21 @@ type DeployArgs struct {
 	Manifest FileBytesArg `positional-arg-name:"PATH" description:"Path to a manifest file"`
 }
 
+type DriftOpts struct {
+	Args DriftArgs `positional-args:"true" required:"true"`
+
+	VarFlags
+	OpsFlags
+
+	NoRedact bool `long:"no-redact" description:"Show non-redacted manifest diff"`
+
+	cmd
+}
+
+type DriftArgs struct {
+	Manifest FileBytesArg `positional-arg-name:"PATH" description:"Path to a manifest file"`
+}
+
 type ManifestOpts struct {
 	cmd
 }
@@ -547,6 +666,8 @@ type UploadReleaseOpts struct {
 
 	Stemcell boshdir.OSVersionSlug `long:"stemcell" value-name:"OS/VERSION" description:"Stemcell that the release is compiled against (applies to remote releases)"`
 
+	ReleaseSignatureFlags
+
 	Release boshrel.Release
 
 	cmd
@@ -583,7 +704,11 @@ type ExportReleaseArgs struct {
 }
 
 type InspectReleaseOpts struct {
-	Args InspectReleaseArgs `positional-args:"true" required:"true"`
+	Args InspectReleaseArgs `positional-args:"true"`
+
+	Graph   string `long:"graph"   value-name:"FORMAT" description:"Show package dependency graph (dot, json)"`
+	Release string `long:"release" value-name:"PATH"   description:"Release tarball or directory to show graph for instead of uploaded release"`
+
 	cmd
 }
 
@@ -591,6 +716,28 @@ type InspectReleaseArgs struct {
 	Slug boshdir.ReleaseSlug `positional-arg-name:"NAME/VERSION"`
 }
 
+type DiffReleasesOpts struct {
+	Args DiffReleasesArgs `positional-args:"true" required:"true"`
+	cmd
+}
+
+type DiffReleasesArgs struct {
+	Old string `positional-arg-name:"OLD" description:"Path to an old release tarball"`
+	New string `positional-arg-name:"NEW" description:"Path to a new release tarball"`
+}
+
+type SbomOpts struct {
+	Args SbomArgs `positional-args:"true" required:"true"`
+
+	Format string `long:"format" value-name:"FORMAT" description:"SBOM format (spdx-json, cyclonedx-json)" default:"spdx-json"`
+
+	cmd
+}
+
+type SbomArgs struct {
+	Release string `positional-arg-name:"RELEASE" description:"Path to a release tarball or directory"`
+}
+
 // Errands
 type ErrandsOpts struct {
 	cmd
@@ -891,18 +1038,75 @@ type VendorPackageArgs struct {
 	URL         DirOrCWDArg `positional-arg-name:"SRC-DIR" default:"."`
 }
 
+type LintReleaseOpts struct {
+	Directory DirOrCWDArg `long:"dir" description:"Release directory path if not current working directory" default:"."`
+	cmd
+}
+
+type ExplainFingerprintOpts struct {
+	Directory DirOrCWDArg `long:"dir" description:"Release directory path if not current working directory" default:"."`
+
+	Job     string `long:"job"     value-name:"NAME" description:"Job name"`
+	Package string `long:"package" value-name:"NAME" description:"Package name"`
+
+	PreviousVersion VersionArg `long:"previous-version" value-name:"VERSION" description:"Dev or final release version to compare with (e.g.: 1.0.0, 1.0-beta.2+dev.10)"`
+	cmd
+}
+
+type RenderJobOpts struct {
+	Directory DirOrCWDArg `long:"release-dir" description:"Release directory path if not current working directory" default:"."`
+
+	Job        string       `long:"job"        value-name:"NAME" description:"Job name" required:"true"`
+	Properties FileBytesArg `long:"properties" value-name:"PATH" description:"Path to a YAML file with job properties"`
+	Spec       FileBytesArg `long:"spec"       value-name:"PATH" description:"Path to a YAML file with instance spec (index, id, az, networks, links, etc.)"`
+	Output     DirOrCWDArg  `long:"output"     value-name:"DIR"  description:"Directory to write rendered templates to" required:"true"`
+
+	cmd
+}
+
+type ValidatePropertiesOpts struct {
+	Args ValidatePropertiesArgs `positional-args:"true" required:"true"`
+
+	VarFlags
+	OpsFlags
+
+	Releases []string `long:"release" value-name:"PATH" description:"Path to a release tarball or release directory (can be specified multiple times)" required:"true"`
+
+	cmd
+}
+
+type ValidatePropertiesArgs struct {
+	Manifest FileBytesArg `positional-arg-name:"PATH" description:"Path to a manifest file"`
+}
+
 type Sha1ifyReleaseOpts struct {
 	Args RedigestReleaseArgs `positional-args:"true"`
 
+	Reproducible bool `long:"reproducible" description:"Normalize release tarball metadata so that same release produces identical tarball (job and package archives are kept as is)"`
+
 	cmd
 }
 
 type Sha2ifyReleaseOpts struct {
 	Args RedigestReleaseArgs `positional-args:"true"`
 
+	Reproducible bool `long:"reproducible" description:"Normalize release tarball metadata so that same release produces identical tarball (job and package archives are kept as is)"`
+
 	cmd
 }
 
+type SignReleaseOpts struct {
+	Args SignReleaseArgs `positional-args:"true" required:"true"`
+
+	PrivateKey FileBytesArg `long:"private-key" value-name:"PATH" description:"Path to a PEM encoded Ed25519, RSA or ECDSA private key" required:"true"`
+
+	cmd
+}
+
+type SignReleaseArgs struct {
+	Path string `positional-arg-name:"PATH" description:"Path to a release tarball (signature is saved to PATH.sig)"`
+}
+
 type RedigestReleaseArgs struct {
 	Path        string  `positional-arg-name:"PATH"`
 	Destination FileArg `positional-arg-name:"DESTINATION"`
@@ -921,6 +1125,8 @@ type CreateReleaseOpts struct {
 	Tarball FileArg `long:"tarball" description:"Create release tarball at path (e.g. /tmp/release.tgz)"`
 	Force   bool    `long:"force"   description:"Ignore Git dirty state check"`
 
+	Reproducible bool `long:"reproducible" description:"Normalize tarball metadata so that same contents produce identical tarball (fails if previously built jobs or packages are not reproducible)"`
+
 	cmd
 }
 
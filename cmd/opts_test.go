@@ -115,6 +115,22 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("EnvironmentsOpt", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("EnvironmentsOpt", opts)).To(Equal(
+					`long:"environments" value-name:"NAMES" description:"Run read-only command against comma-separated environments"`,
+				))
+			})
+		})
+
+		Describe("AllEnvironmentsOpt", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("AllEnvironmentsOpt", opts)).To(Equal(
+					`long:"all-environments" description:"Run read-only command against all environments in config"`,
+				))
+			})
+		})
+
 		Describe("Sha2", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("Sha2", opts)).To(Equal(
@@ -179,6 +195,14 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("NDJSONOpt", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("NDJSONOpt", opts)).To(Equal(
+					`long:"ndjson" description:"Stream task events as newline-delimited JSON"`,
+				))
+			})
+		})
+
 		Describe("TTYOpt", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("TTYOpt", opts)).To(Equal(
@@ -219,6 +243,14 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("Cache", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Cache", opts)).To(Equal(
+					`command:"cache" description:"Manage compiled packages cached by create-env"`,
+				))
+			})
+		})
+
 		Describe("Environment", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("Environment", opts)).To(Equal(
@@ -299,6 +331,38 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("RekeyVarsStore", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("RekeyVarsStore", opts)).To(Equal(
+					`command:"rekey-vars-store" description:"Re-encrypt or decrypt vars store"`,
+				))
+			})
+		})
+
+		Describe("RotateVars", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("RotateVars", opts)).To(Equal(
+					`command:"rotate-vars" description:"Regenerate variables in vars store"`,
+				))
+			})
+		})
+
+		Describe("CheckCerts", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("CheckCerts", opts)).To(Equal(
+					`command:"check-certs" description:"Check certificates in vars store and manifest for expiration"`,
+				))
+			})
+		})
+
+		Describe("ValidateOps", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("ValidateOps", opts)).To(Equal(
+					`command:"validate-ops" description:"Validate that ops files apply to a manifest"`,
+				))
+			})
+		})
+
 		Describe("Config", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("Config", opts)).To(Equal(
@@ -427,6 +491,54 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("Drift", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Drift", opts)).To(Equal(
+					`command:"drift" description:"Check whether deployment matches manifest and latest configs"`,
+				))
+			})
+		})
+
+		Describe("LintRelease", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("LintRelease", opts)).To(Equal(
+					`command:"lint-release" description:"Check release directory for common problems"`,
+				))
+			})
+		})
+
+		Describe("ExplainFingerprint", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("ExplainFingerprint", opts)).To(Equal(
+					`command:"explain-fingerprint" description:"Show entries that make up job or package fingerprint"`,
+				))
+			})
+		})
+
+		Describe("SignRelease", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("SignRelease", opts)).To(Equal(
+					`command:"sign-release" description:"Sign release tarball"`,
+				))
+			})
+		})
+
+		Describe("RenderJob", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("RenderJob", opts)).To(Equal(
+					`command:"render-job" description:"Render job templates with given properties and instance spec"`,
+				))
+			})
+		})
+
+		Describe("ValidateProperties", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("ValidateProperties", opts)).To(Equal(
+					`command:"validate-properties" description:"Validate manifest properties against job specs"`,
+				))
+			})
+		})
+
 		Describe("Stemcells", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("Stemcells", opts)).To(Equal(
@@ -491,6 +603,22 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("DiffReleases", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("DiffReleases", opts)).To(Equal(
+					`command:"diff-releases" description:"Show differences between two release tarballs"`,
+				))
+			})
+		})
+
+		Describe("Sbom", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Sbom", opts)).To(Equal(
+					`command:"sbom" description:"Generate software bill of materials for release tarball or directory"`,
+				))
+			})
+		})
+
 		Describe("DeleteRelease", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("DeleteRelease", opts)).To(Equal(
@@ -804,6 +932,36 @@ var _ = Describe("Opts", func() {
 				`long:"recreate" description:"Recreate VM in deployment"`,
 			))
 		})
+
+		It("has --strict-ops", func() {
+			Expect(getStructTagForName("StrictOps", opts)).To(Equal(
+				`long:"strict-ops" description:"Fail if any ops file operation does not apply or does not change manifest"`,
+			))
+		})
+
+		It("has --plan", func() {
+			Expect(getStructTagForName("Plan", opts)).To(Equal(
+				`long:"plan" description:"Show CPI actions that would be taken without making any changes"`,
+			))
+		})
+
+		It("has --record-cpi", func() {
+			Expect(getStructTagForName("RecordCPI", opts)).To(Equal(
+				`long:"record-cpi" value-name:"DIR" description:"Write CPI requests and responses as JSON files to a directory"`,
+			))
+		})
+
+		It("has --replay-cpi", func() {
+			Expect(getStructTagForName("ReplayCPI", opts)).To(Equal(
+				`long:"replay-cpi" value-name:"DIR" description:"Answer CPI requests with responses written by --record-cpi to a directory"`,
+			))
+		})
+
+		It("has --fake-cpi", func() {
+			Expect(getStructTagForName("FakeCPI", opts)).To(Equal(
+				`long:"fake-cpi" value-name:"DIR" description:"Use built-in fake CPI keeping VMs, disks and stemcells in a directory"`,
+			))
+		})
 	})
 
 	Describe("CreateEnvArgs", func() {
@@ -840,6 +998,18 @@ var _ = Describe("Opts", func() {
 				`long:"state" value-name:"PATH" description:"State file path"`,
 			))
 		})
+
+		It("has --plan", func() {
+			Expect(getStructTagForName("Plan", opts)).To(Equal(
+				`long:"plan" description:"Show CPI actions that would be taken without making any changes"`,
+			))
+		})
+
+		It("has --fake-cpi", func() {
+			Expect(getStructTagForName("FakeCPI", opts)).To(Equal(
+				`long:"fake-cpi" value-name:"DIR" description:"Use built-in fake CPI keeping VMs, disks and stemcells in a directory"`,
+			))
+		})
 	})
 
 	Describe("DeleteEnvArgs", func() {
@@ -858,6 +1028,46 @@ var _ = Describe("Opts", func() {
 		})
 	})
 
+	Describe("CacheOpts", func() {
+		var opts *CacheOpts
+
+		BeforeEach(func() {
+			opts = &CacheOpts{}
+		})
+
+		Describe("Ls", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Ls", opts)).To(Equal(
+					`command:"ls" description:"List cached compiled packages"`,
+				))
+			})
+		})
+
+		Describe("Prune", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Prune", opts)).To(Equal(
+					`command:"prune" description:"Remove cached compiled packages"`,
+				))
+			})
+		})
+	})
+
+	Describe("CachePruneOpts", func() {
+		var opts *CachePruneOpts
+
+		BeforeEach(func() {
+			opts = &CachePruneOpts{}
+		})
+
+		Describe("UnusedFor", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("UnusedFor", opts)).To(Equal(
+					`long:"unused-for" value-name:"DURATION" description:"Only remove packages not used within duration (e.g.: 30d, 12h)"`,
+				))
+			})
+		})
+	})
+
 	Describe("AliasEnvOpts", func() {
 		var opts *AliasEnvOpts
 
@@ -1102,6 +1312,14 @@ var _ = Describe("Opts", func() {
 			})
 		})
 
+		Describe("Resume", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Resume", opts)).To(Equal(
+					`long:"resume" description:"Continue event log from where it was last consumed and follow task to completion"`,
+				))
+			})
+		})
+
 		Describe("All", func() {
 			It("contains desired values", func() {
 				Expect(getStructTagForName("All", opts)).To(Equal(
@@ -1223,6 +1441,12 @@ var _ = Describe("Opts", func() {
 			))
 		})
 
+		It("has Trace", func() {
+			Expect(getStructTagForName("Trace", &opts)).To(Equal(
+				`long:"trace" value-name:"OP-PATH" description:"Show ops files and variables that affected value at path"`,
+			))
+		})
+
 		It("has VarErrors", func() {
 			Expect(getStructTagForName("VarErrors", &opts)).To(Equal(
 				`long:"var-errs" description:"Expect all variables to be found, otherwise error"`,
@@ -1234,6 +1458,12 @@ var _ = Describe("Opts", func() {
 				`long:"var-errs-unused" description:"Expect all variables to be used, otherwise error"`,
 			))
 		})
+
+		It("has StrictOps", func() {
+			Expect(getStructTagForName("StrictOps", &opts)).To(Equal(
+				`long:"strict-ops" description:"Fail if any ops file operation does not apply or does not change manifest"`,
+			))
+		})
 	})
 
 	Describe("InterpolateArgs", func() {
@@ -1252,6 +1482,152 @@ var _ = Describe("Opts", func() {
 		})
 	})
 
+	Describe("RekeyVarsStoreOpts", func() {
+		var opts *RekeyVarsStoreOpts
+
+		BeforeEach(func() {
+			opts = &RekeyVarsStoreOpts{}
+		})
+
+		It("has Args", func() {
+			Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+		})
+
+		It("has Key", func() {
+			Expect(getStructTagForName("Key", opts)).To(Equal(
+				`long:"key" value-name:"PATH" description:"Current key file path (or BOSH_VARS_STORE_PASSPHRASE)" env:"BOSH_VARS_STORE_KEY"`,
+			))
+		})
+
+		It("has NewKey", func() {
+			Expect(getStructTagForName("NewKey", opts)).To(Equal(
+				`long:"new-key" value-name:"PATH" description:"New key file path (or BOSH_VARS_STORE_NEW_PASSPHRASE)"`,
+			))
+		})
+
+		It("has Decrypt", func() {
+			Expect(getStructTagForName("Decrypt", opts)).To(Equal(
+				`long:"decrypt" description:"Save vars store without encryption"`,
+			))
+		})
+	})
+
+	Describe("RekeyVarsStoreArgs", func() {
+		var opts *RekeyVarsStoreArgs
+
+		BeforeEach(func() {
+			opts = &RekeyVarsStoreArgs{}
+		})
+
+		Describe("VarsStore", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("VarsStore", opts)).To(Equal(
+					`positional-arg-name:"PATH" description:"Path to a vars store"`,
+				))
+			})
+		})
+	})
+
+	Describe("RotateVarsOpts", func() {
+		var opts *RotateVarsOpts
+
+		BeforeEach(func() {
+			opts = &RotateVarsOpts{}
+		})
+
+		It("has Manifest", func() {
+			Expect(getStructTagForName("Manifest", opts)).To(Equal(
+				`long:"manifest" value-name:"PATH" description:"Path to a manifest with variable definitions" required:"true"`,
+			))
+		})
+
+		It("has Names", func() {
+			Expect(getStructTagForName("Names", opts)).To(Equal(
+				`long:"name" value-name:"NAME" description:"Regenerate variable"`,
+			))
+		})
+
+		It("has SignedBy", func() {
+			Expect(getStructTagForName("SignedBy", opts)).To(Equal(
+				`long:"signed-by" value-name:"CA" description:"Regenerate all certificates signed by CA (including intermediate CAs)"`,
+			))
+		})
+
+		It("has BackupSuffix", func() {
+			Expect(getStructTagForName("BackupSuffix", opts)).To(Equal(
+				`long:"backup-suffix" value-name:"SUFFIX" description:"Suffix for names that keep previous values" default:"_old"`,
+			))
+		})
+	})
+
+	Describe("CheckCertsOpts", func() {
+		var opts *CheckCertsOpts
+
+		BeforeEach(func() {
+			opts = &CheckCertsOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true"`))
+			})
+		})
+
+		Describe("ExpiringWithin", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("ExpiringWithin", opts)).To(Equal(
+					`long:"expiring-within" value-name:"DURATION" description:"Fail if certificates expire within duration (e.g.: 30d, 12h)" default:"30d"`,
+				))
+			})
+		})
+	})
+
+	Describe("ValidateOpsOpts", func() {
+		var opts *ValidateOpsOpts
+
+		BeforeEach(func() {
+			opts = &ValidateOpsOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+	})
+
+	Describe("ValidateOpsArgs", func() {
+		var opts *ValidateOpsArgs
+
+		BeforeEach(func() {
+			opts = &ValidateOpsArgs{}
+		})
+
+		Describe("Manifest", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Manifest", opts)).To(Equal(
+					`positional-arg-name:"PATH" description:"Path to a manifest that ops files will be applied to"`,
+				))
+			})
+		})
+	})
+
+	Describe("CheckCertsArgs", func() {
+		var opts *CheckCertsArgs
+
+		BeforeEach(func() {
+			opts = &CheckCertsArgs{}
+		})
+
+		Describe("Manifest", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Manifest", opts)).To(Equal(
+					`positional-arg-name:"PATH" description:"Path to a manifest that will be interpolated"`,
+				))
+			})
+		})
+	})
+
 	Describe("UpdateCloudConfigOpts", func() {
 		var opts *UpdateCloudConfigOpts
 
@@ -1398,6 +1774,14 @@ var _ = Describe("Opts", func() {
 				))
 			})
 		})
+
+		Describe("StrictOps", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("StrictOps", opts)).To(Equal(
+					`long:"strict-ops" description:"Fail if any ops file operation does not apply or does not change manifest"`,
+				))
+			})
+		})
 	})
 
 	Describe("DeployArgs", func() {
@@ -1416,6 +1800,44 @@ var _ = Describe("Opts", func() {
 		})
 	})
 
+	Describe("DriftOpts", func() {
+		var opts *DriftOpts
+
+		BeforeEach(func() {
+			opts = &DriftOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+
+		Describe("NoRedact", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("NoRedact", opts)).To(Equal(
+					`long:"no-redact" description:"Show non-redacted manifest diff"`,
+				))
+			})
+		})
+	})
+
+	Describe("DriftArgs", func() {
+		var opts *DriftArgs
+
+		BeforeEach(func() {
+			opts = &DriftArgs{}
+		})
+
+		Describe("Manifest", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Manifest", opts)).To(Equal(
+					`positional-arg-name:"PATH" description:"Path to a manifest file"`,
+				))
+			})
+		})
+	})
+
 	Describe("DeleteDeploymentOpts", func() {
 		var opts *DeleteDeploymentOpts
 
@@ -1794,7 +2216,23 @@ var _ = Describe("Opts", func() {
 
 		Describe("Args", func() {
 			It("contains desired values", func() {
-				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true"`))
+			})
+		})
+
+		Describe("Graph", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Graph", opts)).To(Equal(
+					`long:"graph" value-name:"FORMAT" description:"Show package dependency graph (dot, json)"`,
+				))
+			})
+		})
+
+		Describe("Release", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Release", opts)).To(Equal(
+					`long:"release" value-name:"PATH" description:"Release tarball or directory to show graph for instead of uploaded release"`,
+				))
 			})
 		})
 	})
@@ -1815,6 +2253,86 @@ var _ = Describe("Opts", func() {
 		})
 	})
 
+	Describe("DiffReleasesOpts", func() {
+		var opts *DiffReleasesOpts
+
+		BeforeEach(func() {
+			opts = &DiffReleasesOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(
+					`positional-args:"true" required:"true"`,
+				))
+			})
+		})
+	})
+
+	Describe("DiffReleasesArgs", func() {
+		var opts *DiffReleasesArgs
+
+		BeforeEach(func() {
+			opts = &DiffReleasesArgs{}
+		})
+
+		Describe("Old", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Old", opts)).To(Equal(
+					`positional-arg-name:"OLD" description:"Path to an old release tarball"`,
+				))
+			})
+		})
+
+		Describe("New", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("New", opts)).To(Equal(
+					`positional-arg-name:"NEW" description:"Path to a new release tarball"`,
+				))
+			})
+		})
+	})
+
+	Describe("SbomOpts", func() {
+		var opts *SbomOpts
+
+		BeforeEach(func() {
+			opts = &SbomOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(
+					`positional-args:"true" required:"true"`,
+				))
+			})
+		})
+
+		Describe("Format", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Format", opts)).To(Equal(
+					`long:"format" value-name:"FORMAT" description:"SBOM format (spdx-json, cyclonedx-json)" default:"spdx-json"`,
+				))
+			})
+		})
+	})
+
+	Describe("SbomArgs", func() {
+		var opts *SbomArgs
+
+		BeforeEach(func() {
+			opts = &SbomArgs{}
+		})
+
+		Describe("Release", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Release", opts)).To(Equal(
+					`positional-arg-name:"RELEASE" description:"Path to a release tarball or directory"`,
+				))
+			})
+		})
+	})
+
 	Describe("InstanceGroupOrInstanceSlugFlags", func() {
 		var opts *InstanceGroupOrInstanceSlugFlags
 
@@ -2795,6 +3313,210 @@ var _ = Describe("Opts", func() {
 		})
 	})
 
+	Describe("LintReleaseOpts", func() {
+		var opts *LintReleaseOpts
+
+		BeforeEach(func() {
+			opts = &LintReleaseOpts{}
+		})
+
+		Describe("Directory", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Directory", opts)).To(Equal(
+					`long:"dir" description:"Release directory path if not current working directory" default:"."`,
+				))
+			})
+		})
+	})
+
+	Describe("SignReleaseOpts", func() {
+		var opts *SignReleaseOpts
+
+		BeforeEach(func() {
+			opts = &SignReleaseOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+
+		Describe("PrivateKey", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("PrivateKey", opts)).To(Equal(
+					`long:"private-key" value-name:"PATH" description:"Path to a PEM encoded Ed25519, RSA or ECDSA private key" required:"true"`,
+				))
+			})
+		})
+	})
+
+	Describe("SignReleaseArgs", func() {
+		var opts *SignReleaseArgs
+
+		BeforeEach(func() {
+			opts = &SignReleaseArgs{}
+		})
+
+		Describe("Path", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Path", opts)).To(Equal(
+					`positional-arg-name:"PATH" description:"Path to a release tarball (signature is saved to PATH.sig)"`,
+				))
+			})
+		})
+	})
+
+	Describe("ReleaseSignatureFlags", func() {
+		var opts *ReleaseSignatureFlags
+
+		BeforeEach(func() {
+			opts = &ReleaseSignatureFlags{}
+		})
+
+		Describe("VerifySignature", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("VerifySignature", opts)).To(Equal(
+					`long:"verify-signature" description:"Refuse release tarballs without valid signature (TARBALL.sig) made by one of trusted keys"`,
+				))
+			})
+		})
+
+		Describe("TrustedKeys", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("TrustedKeys", opts)).To(Equal(
+					`long:"trusted-keys" value-name:"DIR" description:"Directory with PEM encoded trusted public keys or certificates"`,
+				))
+			})
+		})
+	})
+
+	Describe("ExplainFingerprintOpts", func() {
+		var opts *ExplainFingerprintOpts
+
+		BeforeEach(func() {
+			opts = &ExplainFingerprintOpts{}
+		})
+
+		Describe("Directory", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Directory", opts)).To(Equal(
+					`long:"dir" description:"Release directory path if not current working directory" default:"."`,
+				))
+			})
+		})
+
+		Describe("Job", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Job", opts)).To(Equal(
+					`long:"job" value-name:"NAME" description:"Job name"`,
+				))
+			})
+		})
+
+		Describe("Package", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Package", opts)).To(Equal(
+					`long:"package" value-name:"NAME" description:"Package name"`,
+				))
+			})
+		})
+
+		Describe("PreviousVersion", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("PreviousVersion", opts)).To(Equal(
+					`long:"previous-version" value-name:"VERSION" description:"Dev or final release version to compare with (e.g.: 1.0.0, 1.0-beta.2+dev.10)"`,
+				))
+			})
+		})
+	})
+
+	Describe("RenderJobOpts", func() {
+		var opts *RenderJobOpts
+
+		BeforeEach(func() {
+			opts = &RenderJobOpts{}
+		})
+
+		Describe("Directory", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Directory", opts)).To(Equal(
+					`long:"release-dir" description:"Release directory path if not current working directory" default:"."`,
+				))
+			})
+		})
+
+		Describe("Job", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Job", opts)).To(Equal(
+					`long:"job" value-name:"NAME" description:"Job name" required:"true"`,
+				))
+			})
+		})
+
+		Describe("Properties", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Properties", opts)).To(Equal(
+					`long:"properties" value-name:"PATH" description:"Path to a YAML file with job properties"`,
+				))
+			})
+		})
+
+		Describe("Spec", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Spec", opts)).To(Equal(
+					`long:"spec" value-name:"PATH" description:"Path to a YAML file with instance spec (index, id, az, networks, links, etc.)"`,
+				))
+			})
+		})
+
+		Describe("Output", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Output", opts)).To(Equal(
+					`long:"output" value-name:"DIR" description:"Directory to write rendered templates to" required:"true"`,
+				))
+			})
+		})
+	})
+
+	Describe("ValidatePropertiesOpts", func() {
+		var opts *ValidatePropertiesOpts
+
+		BeforeEach(func() {
+			opts = &ValidatePropertiesOpts{}
+		})
+
+		Describe("Args", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true" required:"true"`))
+			})
+		})
+
+		Describe("Releases", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Releases", opts)).To(Equal(
+					`long:"release" value-name:"PATH" description:"Path to a release tarball or release directory (can be specified multiple times)" required:"true"`,
+				))
+			})
+		})
+	})
+
+	Describe("ValidatePropertiesArgs", func() {
+		var opts *ValidatePropertiesArgs
+
+		BeforeEach(func() {
+			opts = &ValidatePropertiesArgs{}
+		})
+
+		Describe("Manifest", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Manifest", opts)).To(Equal(
+					`positional-arg-name:"PATH" description:"Path to a manifest file"`,
+				))
+			})
+		})
+	})
+
 	Describe("CreateReleaseOpts", func() {
 		var opts *CreateReleaseOpts
 
@@ -2869,6 +3591,13 @@ var _ = Describe("Opts", func() {
 				))
 			})
 		})
+		Describe("Reproducible", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Reproducible", opts)).To(Equal(
+					`long:"reproducible" description:"Normalize tarball metadata so that same contents produce identical tarball (fails if previously built jobs or packages are not reproducible)"`,
+				))
+			})
+		})
 	})
 
 	Describe("Sha2ifyReleaseOpts", func() {
@@ -2883,6 +3612,14 @@ var _ = Describe("Opts", func() {
 				Expect(getStructTagForName("Args", opts)).To(Equal(`positional-args:"true"`))
 			})
 		})
+
+		Describe("Reproducible", func() {
+			It("contains desired values", func() {
+				Expect(getStructTagForName("Reproducible", opts)).To(Equal(
+					`long:"reproducible" description:"Normalize release tarball metadata so that same release produces identical tarball (job and package archives are kept as is)"`,
+				))
+			})
+		})
 	})
 
 	Describe("Sha2ifyReleaseArgs", func() {
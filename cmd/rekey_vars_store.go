@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+const VarsStoreNewPassphraseEnv = "BOSH_VARS_STORE_NEW_PASSPHRASE"
+
+type RekeyVarsStoreCmd struct{}
+
+func NewRekeyVarsStoreCmd() RekeyVarsStoreCmd {
+	return RekeyVarsStoreCmd{}
+}
+
+func (c RekeyVarsStoreCmd) Run(opts RekeyVarsStoreOpts) error {
+	store := opts.Args.VarsStore
+
+	if !store.FS.FileExists(store.path) {
+		return bosherr.Errorf("Expected vars store '%s' to exist", store.path)
+	}
+
+	newEncryptor := opts.NewKey.Encryptor(VarsStoreNewPassphraseEnv)
+
+	if opts.Decrypt && newEncryptor != nil {
+		return bosherr.Error("Expected either new key or '--decrypt' to be specified but not both")
+	}
+
+	if !opts.Decrypt && newEncryptor == nil {
+		return bosherr.Errorf("Expected new key or '--decrypt' to be specified (or %s to be set)", VarsStoreNewPassphraseEnv)
+	}
+
+	store.Encryptor = opts.Key.Encryptor(VarsStorePassphraseEnv)
+
+	vars, err := store.load()
+	if err != nil {
+		return err
+	}
+
+	store.Encryptor = newEncryptor
+
+	return store.save(vars)
+}
@@ -0,0 +1,137 @@
+package cmd_test
+
+import (
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	"github.com/cloudfoundry/bosh-cli/crypto"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+var _ = Describe("RekeyVarsStoreCmd", func() {
+	var (
+		fs      *fakesys.FakeFileSystem
+		command RekeyVarsStoreCmd
+	)
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+		command = NewRekeyVarsStoreCmd()
+	})
+
+	Describe("Run", func() {
+		var (
+			opts RekeyVarsStoreOpts
+		)
+
+		BeforeEach(func() {
+			opts = RekeyVarsStoreOpts{}
+			opts.Args.VarsStore = VarsFSStore{FS: fs}
+
+			err := (&opts.Args.VarsStore).UnmarshalFlag("/file")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		act := func() error { return command.Run(opts) }
+
+		readStore := func(passphrase string) (interface{}, error) {
+			store := VarsFSStore{FS: fs}
+			store.UnmarshalFlag("/file")
+
+			if len(passphrase) > 0 {
+				store.Encryptor = crypto.NewPassphraseEncryptor([]byte(passphrase))
+			}
+
+			val, _, err := store.Get(boshtpl.VariableDefinition{Name: "key"})
+			return val, err
+		}
+
+		It("encrypts unencrypted store with new key", func() {
+			fs.WriteFileString("/file", "key: val")
+			opts.NewKey.Passphrase = []byte("new")
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			val, err := readStore("new")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(val).To(Equal("val"))
+		})
+
+		It("re-encrypts encrypted store with new key", func() {
+			encrypted, err := crypto.NewPassphraseEncryptor([]byte("old")).Encrypt([]byte("key: val"))
+			Expect(err).ToNot(HaveOccurred())
+
+			fs.WriteFile("/file", encrypted)
+			opts.Key.Passphrase = []byte("old")
+			opts.NewKey.Passphrase = []byte("new")
+
+			err = act()
+			Expect(err).ToNot(HaveOccurred())
+
+			val, err := readStore("new")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(val).To(Equal("val"))
+
+			_, err = readStore("old")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("decrypts encrypted store", func() {
+			encrypted, err := crypto.NewPassphraseEncryptor([]byte("old")).Encrypt([]byte("key: val"))
+			Expect(err).ToNot(HaveOccurred())
+
+			fs.WriteFile("/file", encrypted)
+			opts.Key.Passphrase = []byte("old")
+			opts.Decrypt = true
+
+			err = act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fs.ReadFileString("/file")).To(Equal("key: val\n"))
+		})
+
+		It("returns an error if current key cannot decrypt store", func() {
+			encrypted, err := crypto.NewPassphraseEncryptor([]byte("old")).Encrypt([]byte("key: val"))
+			Expect(err).ToNot(HaveOccurred())
+
+			fs.WriteFile("/file", encrypted)
+			opts.Key.Passphrase = []byte("wrong")
+			opts.Decrypt = true
+
+			err = act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Decrypting variables file store '/file'"))
+
+			Expect(fs.ReadFile("/file")).To(Equal(encrypted))
+		})
+
+		It("returns an error if store does not exist", func() {
+			opts.Decrypt = true
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected vars store '/file' to exist"))
+		})
+
+		It("returns an error if both new key and decrypt are specified", func() {
+			fs.WriteFileString("/file", "key: val")
+			opts.NewKey.Passphrase = []byte("new")
+			opts.Decrypt = true
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected either new key or '--decrypt' to be specified but not both"))
+		})
+
+		It("returns an error if neither new key nor decrypt is specified", func() {
+			fs.WriteFileString("/file", "key: val")
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected new key or '--decrypt' to be specified"))
+		})
+	})
+})
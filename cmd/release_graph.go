@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+// releaseGraph is a package dependency graph with job to package edges
+type releaseGraph struct {
+	Jobs     []releaseGraphJob     `json:"jobs"`
+	Packages []releaseGraphPackage `json:"packages"`
+	Cycles   [][]string            `json:"cycles"`
+}
+
+type releaseGraphJob struct {
+	Name     string   `json:"name"`
+	Packages []string `json:"packages"`
+}
+
+type releaseGraphPackage struct {
+	Name         string   `json:"name"`
+	Dependencies []string `json:"dependencies"`
+
+	Cyclic bool `json:"cyclic"`
+	Orphan bool `json:"orphan"`
+}
+
+// newReleaseGraph builds graph from job and package dependency names;
+// orphan packages are only determined when job packages are known
+func newReleaseGraph(jobPkgs map[string][]string, pkgDeps map[string][]string, jobPkgsKnown bool) releaseGraph {
+	graph := releaseGraph{
+		Jobs:     []releaseGraphJob{},
+		Packages: []releaseGraphPackage{},
+		Cycles:   releaseGraphCycles(pkgDeps),
+	}
+
+	for _, name := range releaseGraphSortedKeys(jobPkgs) {
+		pkgs := append([]string{}, jobPkgs[name]...)
+		sort.Strings(pkgs)
+		graph.Jobs = append(graph.Jobs, releaseGraphJob{Name: name, Packages: pkgs})
+	}
+
+	cyclic := map[string]bool{}
+
+	for _, cycle := range graph.Cycles {
+		for _, name := range cycle {
+			cyclic[name] = true
+		}
+	}
+
+	used := map[string]bool{}
+
+	var markUsed func(string)
+
+	markUsed = func(name string) {
+		if used[name] {
+			return
+		}
+		used[name] = true
+		for _, dep := range pkgDeps[name] {
+			markUsed(dep)
+		}
+	}
+
+	for _, job := range graph.Jobs {
+		for _, name := range job.Packages {
+			markUsed(name)
+		}
+	}
+
+	for _, name := range releaseGraphSortedKeys(pkgDeps) {
+		deps := append([]string{}, pkgDeps[name]...)
+		sort.Strings(deps)
+
+		graph.Packages = append(graph.Packages, releaseGraphPackage{
+			Name:         name,
+			Dependencies: deps,
+			Cyclic:       cyclic[name],
+			Orphan:       jobPkgsKnown && !used[name],
+		})
+	}
+
+	return graph
+}
+
+func (g releaseGraph) Format(format string) (string, error) {
+	switch format {
+	case "dot":
+		return g.dot(), nil
+
+	case "json":
+		bytes, err := json.MarshalIndent(g, "", "  ")
+		if err != nil {
+			return "", bosherr.WrapErrorf(err, "Marshaling graph")
+		}
+
+		return string(bytes) + "\n", nil
+
+	default:
+		return "", bosherr.Errorf("Expected graph format '%s' to be either 'dot' or 'json'", format)
+	}
+}
+
+// dot highlights cyclic packages in red and orphan packages with dashed outline
+func (g releaseGraph) dot() string {
+	lines := []string{"digraph release {"}
+
+	for _, job := range g.Jobs {
+		lines = append(lines, fmt.Sprintf("  %q [label=%q, shape=box];", "job/"+job.Name, job.Name))
+	}
+
+	for _, pkg := range g.Packages {
+		var attrs []string
+
+		attrs = append(attrs, fmt.Sprintf("label=%q", pkg.Name))
+
+		if pkg.Cyclic {
+			attrs = append(attrs, "color=red")
+		}
+
+		if pkg.Orphan {
+			attrs = append(attrs, "style=dashed")
+		}
+
+		lines = append(lines, fmt.Sprintf("  %q [%s];", "pkg/"+pkg.Name, strings.Join(attrs, ", ")))
+	}
+
+	for _, job := range g.Jobs {
+		for _, name := range job.Packages {
+			lines = append(lines, fmt.Sprintf("  %q -> %q;", "job/"+job.Name, "pkg/"+name))
+		}
+	}
+
+	for _, pkg := range g.Packages {
+		for _, name := range pkg.Dependencies {
+			line := fmt.Sprintf("  %q -> %q", "pkg/"+pkg.Name, "pkg/"+name)
+
+			if pkg.Cyclic && g.inSameCycle(pkg.Name, name) {
+				line += " [color=red]"
+			}
+
+			lines = append(lines, line+";")
+		}
+	}
+
+	lines = append(lines, "}")
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (g releaseGraph) inSameCycle(name1, name2 string) bool {
+	for _, cycle := range g.Cycles {
+		var found1, found2 bool
+
+		for _, name := range cycle {
+			found1 = found1 || name == name1
+			found2 = found2 || name == name2
+		}
+
+		if found1 && found2 {
+			return true
+		}
+	}
+	return false
+}
+
+// releaseGraphCycles returns strongly connected components (Tarjan's algorithm)
+// that include more than one package or a package depending on itself
+func releaseGraphCycles(pkgDeps map[string][]string) [][]string {
+	var (
+		index   int
+		stack   []string
+		cycles  = [][]string{}
+		indices = map[string]int{}
+		lowlink = map[string]int{}
+		onStack = map[string]bool{}
+	)
+
+	var connect func(string)
+
+	connect = func(name string) {
+		indices[name] = index
+		lowlink[name] = index
+		index++
+
+		stack = append(stack, name)
+		onStack[name] = true
+
+		for _, dep := range pkgDeps[name] {
+			if _, visited := indices[dep]; !visited {
+				connect(dep)
+				if lowlink[dep] < lowlink[name] {
+					lowlink[name] = lowlink[dep]
+				}
+			} else if onStack[dep] && indices[dep] < lowlink[name] {
+				lowlink[name] = indices[dep]
+			}
+		}
+
+		if lowlink[name] != indices[name] {
+			return
+		}
+
+		var component []string
+
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component = append(component, last)
+
+			if last == name {
+				break
+			}
+		}
+
+		var selfDep bool
+
+		for _, dep := range pkgDeps[name] {
+			selfDep = selfDep || dep == name
+		}
+
+		if len(component) > 1 || selfDep {
+			sort.Strings(component)
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, name := range releaseGraphSortedKeys(pkgDeps) {
+		if _, visited := indices[name]; !visited {
+			connect(name)
+		}
+	}
+
+	sort.Sort(releaseGraphCyclesByName(cycles))
+
+	return cycles
+}
+
+type releaseGraphCyclesByName [][]string
+
+func (a releaseGraphCyclesByName) Len() int           { return len(a) }
+func (a releaseGraphCyclesByName) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a releaseGraphCyclesByName) Less(i, j int) bool { return a[i][0] < a[j][0] }
+
+func releaseGraphSortedKeys(m map[string][]string) []string {
+	var keys []string
+
+	for key, _ := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,421 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+// releaseSbom lists release contents independently of output format
+type releaseSbom struct {
+	Name    string
+	Version string
+
+	ID      string
+	Created time.Time
+
+	Jobs             []releaseSbomItem
+	Packages         []releaseSbomItem
+	CompiledPackages []releaseSbomItem
+	Blobs            []releaseSbomItem
+	License          *releaseSbomItem
+}
+
+type releaseSbomItem struct {
+	Name    string
+	Version string
+	Digest  string
+
+	// Dependencies are package names (compiled package names for compiled packages)
+	Dependencies []string
+
+	// Stemcell is OS and version compiled packages were compiled against
+	Stemcell string
+}
+
+type releaseSbomItemsByName []releaseSbomItem
+
+func (s releaseSbomItemsByName) Len() int           { return len(s) }
+func (s releaseSbomItemsByName) Less(i, j int) bool { return s[i].Name < s[j].Name }
+func (s releaseSbomItemsByName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+type releaseSbomChecksum struct {
+	Algorithm string
+	Value     string
+}
+
+func (s releaseSbom) Format(format string) (string, error) {
+	var doc interface{}
+	var err error
+
+	switch format {
+	case "spdx-json":
+		doc, err = s.spdx()
+	case "cyclonedx-json":
+		doc, err = s.cycloneDX()
+	default:
+		return "", bosherr.Errorf("Expected SBOM format '%s' to be either 'spdx-json' or 'cyclonedx-json'", format)
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	bytes, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", bosherr.WrapErrorf(err, "Marshaling SBOM")
+	}
+
+	return string(bytes) + "\n", nil
+}
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name                  string         `json:"name"`
+	SPDXID                string         `json:"SPDXID"`
+	VersionInfo           string         `json:"versionInfo,omitempty"`
+	DownloadLocation      string         `json:"downloadLocation"`
+	FilesAnalyzed         bool           `json:"filesAnalyzed"`
+	Checksums             []spdxChecksum `json:"checksums,omitempty"`
+	LicenseConcluded      string         `json:"licenseConcluded"`
+	LicenseDeclared       string         `json:"licenseDeclared"`
+	CopyrightText         string         `json:"copyrightText"`
+	PrimaryPackagePurpose string         `json:"primaryPackagePurpose"`
+	Comment               string         `json:"comment"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+var (
+	spdxIDInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9.\-]`)
+
+	spdxChecksumAlgorithms = map[string]string{"sha1": "SHA1", "sha256": "SHA256", "sha512": "SHA512"}
+)
+
+// spdx does not try to determine SPDX license expressions from license files
+func (s releaseSbom) spdx() (spdxDocument, error) {
+	const noAssertion = "NOASSERTION"
+
+	doc := spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              s.fullName(),
+		DocumentNamespace: fmt.Sprintf("https://bosh.io/spdx/%s-%s", s.Name, s.ID),
+		CreationInfo: spdxCreationInfo{
+			Created:  s.Created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: bosh-cli"},
+		},
+	}
+
+	relPkg := spdxPackage{
+		Name:                  s.Name,
+		SPDXID:                "SPDXRef-Release",
+		VersionInfo:           s.Version,
+		PrimaryPackagePurpose: "ARCHIVE",
+		Comment:               "BOSH release",
+	}
+
+	doc.Packages = append(doc.Packages, relPkg)
+	doc.Relationships = append(doc.Relationships, spdxRelationship{doc.SPDXID, "DESCRIBES", relPkg.SPDXID})
+
+	spdxID := func(kind, name string) string {
+		return "SPDXRef-" + kind + "-" + spdxIDInvalidChars.ReplaceAllString(name, "-")
+	}
+
+	jobDepKind := "Package"
+	if s.isCompiled() {
+		jobDepKind = "CompiledPackage"
+	}
+
+	addItems := func(items []releaseSbomItem, kind, depKind, purpose, comment string) error {
+		for _, item := range items {
+			checksums, err := releaseSbomChecksums(item.Digest)
+			if err != nil {
+				return bosherr.WrapErrorf(err, "Parsing digest of %s '%s'", strings.ToLower(kind), item.Name)
+			}
+
+			pkg := spdxPackage{
+				Name:                  item.Name,
+				SPDXID:                spdxID(kind, item.Name),
+				VersionInfo:           item.Version,
+				PrimaryPackagePurpose: purpose,
+				Comment:               comment,
+			}
+
+			if len(item.Stemcell) > 0 {
+				pkg.Comment += " for " + item.Stemcell
+			}
+
+			for _, checksum := range checksums {
+				pkg.Checksums = append(pkg.Checksums, spdxChecksum{
+					Algorithm:     spdxChecksumAlgorithms[checksum.Algorithm],
+					ChecksumValue: checksum.Value,
+				})
+			}
+
+			doc.Packages = append(doc.Packages, pkg)
+			doc.Relationships = append(doc.Relationships, spdxRelationship{relPkg.SPDXID, "CONTAINS", pkg.SPDXID})
+
+			for _, dep := range item.Dependencies {
+				doc.Relationships = append(doc.Relationships, spdxRelationship{pkg.SPDXID, "DEPENDS_ON", spdxID(depKind, dep)})
+			}
+		}
+		return nil
+	}
+
+	err := addItems(s.Jobs, "Job", jobDepKind, "APPLICATION", "BOSH job")
+	if err != nil {
+		return doc, err
+	}
+
+	err = addItems(s.Packages, "Package", "Package", "LIBRARY", "BOSH package")
+	if err != nil {
+		return doc, err
+	}
+
+	err = addItems(s.CompiledPackages, "CompiledPackage", "CompiledPackage", "LIBRARY", "BOSH package compiled")
+	if err != nil {
+		return doc, err
+	}
+
+	err = addItems(s.Blobs, "Blob", "", "SOURCE", "BOSH blob")
+	if err != nil {
+		return doc, err
+	}
+
+	if s.License != nil {
+		err = addItems([]releaseSbomItem{*s.License}, "License", "", "FILE", "BOSH release license and notice files")
+		if err != nil {
+			return doc, err
+		}
+	}
+
+	for i := range doc.Packages {
+		doc.Packages[i].DownloadLocation = noAssertion
+		doc.Packages[i].LicenseConcluded = noAssertion
+		doc.Packages[i].LicenseDeclared = noAssertion
+		doc.Packages[i].CopyrightText = noAssertion
+	}
+
+	return doc, nil
+}
+
+type cycloneDXDocument struct {
+	BOMFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	SerialNumber string                `json:"serialNumber"`
+	Version      int                   `json:"version"`
+	Metadata     cycloneDXMetadata     `json:"metadata"`
+	Components   []cycloneDXComponent  `json:"components"`
+	Dependencies []cycloneDXDependency `json:"dependencies"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string             `json:"timestamp"`
+	Tools     cycloneDXTools     `json:"tools"`
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXTools struct {
+	Components []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXComponent struct {
+	Type       string              `json:"type"`
+	BOMRef     string              `json:"bom-ref,omitempty"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	Hashes     []cycloneDXHash     `json:"hashes,omitempty"`
+	Properties []cycloneDXProperty `json:"properties,omitempty"`
+}
+
+type cycloneDXHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+var cycloneDXHashAlgorithms = map[string]string{"sha1": "SHA-1", "sha256": "SHA-256", "sha512": "SHA-512"}
+
+func (s releaseSbom) cycloneDX() (cycloneDXDocument, error) {
+	doc := cycloneDXDocument{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + s.ID,
+		Version:      1,
+		Metadata: cycloneDXMetadata{
+			Timestamp: s.Created.UTC().Format(time.RFC3339),
+			Tools: cycloneDXTools{
+				Components: []cycloneDXComponent{{Type: "application", Name: "bosh-cli"}},
+			},
+			Component: cycloneDXComponent{
+				Type:       "application",
+				BOMRef:     "release",
+				Name:       s.Name,
+				Version:    s.Version,
+				Properties: []cycloneDXProperty{{"bosh:type", "release"}},
+			},
+		},
+		Components:   []cycloneDXComponent{},
+		Dependencies: []cycloneDXDependency{},
+	}
+
+	relDep := cycloneDXDependency{Ref: "release", DependsOn: []string{}}
+
+	jobDepKind := "package"
+	if s.isCompiled() {
+		jobDepKind = "compiled-package"
+	}
+
+	// depKind is empty for items without dependencies
+	addItems := func(items []releaseSbomItem, kind, depKind, type_ string) error {
+		for _, item := range items {
+			checksums, err := releaseSbomChecksums(item.Digest)
+			if err != nil {
+				return bosherr.WrapErrorf(err, "Parsing digest of %s '%s'", kind, item.Name)
+			}
+
+			comp := cycloneDXComponent{
+				Type:       type_,
+				BOMRef:     kind + "/" + item.Name,
+				Name:       item.Name,
+				Version:    item.Version,
+				Properties: []cycloneDXProperty{{"bosh:type", kind}},
+			}
+
+			if len(item.Stemcell) > 0 {
+				comp.Properties = append(comp.Properties, cycloneDXProperty{"bosh:stemcell", item.Stemcell})
+			}
+
+			for _, checksum := range checksums {
+				comp.Hashes = append(comp.Hashes, cycloneDXHash{
+					Alg:     cycloneDXHashAlgorithms[checksum.Algorithm],
+					Content: checksum.Value,
+				})
+			}
+
+			doc.Components = append(doc.Components, comp)
+
+			if len(depKind) > 0 {
+				relDep.DependsOn = append(relDep.DependsOn, comp.BOMRef)
+
+				dep := cycloneDXDependency{Ref: comp.BOMRef, DependsOn: []string{}}
+
+				for _, name := range item.Dependencies {
+					dep.DependsOn = append(dep.DependsOn, depKind+"/"+name)
+				}
+
+				doc.Dependencies = append(doc.Dependencies, dep)
+			}
+		}
+		return nil
+	}
+
+	err := addItems(s.Jobs, "job", jobDepKind, "application")
+	if err != nil {
+		return doc, err
+	}
+
+	err = addItems(s.Packages, "package", "package", "library")
+	if err != nil {
+		return doc, err
+	}
+
+	err = addItems(s.CompiledPackages, "compiled-package", "compiled-package", "library")
+	if err != nil {
+		return doc, err
+	}
+
+	err = addItems(s.Blobs, "blob", "", "file")
+	if err != nil {
+		return doc, err
+	}
+
+	if s.License != nil {
+		err = addItems([]releaseSbomItem{*s.License}, "license", "", "file")
+		if err != nil {
+			return doc, err
+		}
+	}
+
+	doc.Dependencies = append([]cycloneDXDependency{relDep}, doc.Dependencies...)
+
+	return doc, nil
+}
+
+// isCompiled checks whether jobs depend on compiled packages
+// since compiled releases do not include package sources
+func (s releaseSbom) isCompiled() bool {
+	return len(s.Packages) == 0 && len(s.CompiledPackages) > 0
+}
+
+func (s releaseSbom) fullName() string {
+	if len(s.Version) > 0 {
+		return s.Name + "/" + s.Version
+	}
+	return s.Name
+}
+
+// releaseSbomChecksums parses digests recorded in release manifests and indicies
+// (e.g. 'abc' for SHA1 or 'sha256:abc;sha1:def')
+func releaseSbomChecksums(digest string) ([]releaseSbomChecksum, error) {
+	var checksums []releaseSbomChecksum
+
+	for _, piece := range strings.Split(digest, ";") {
+		if len(piece) == 0 {
+			continue
+		}
+
+		algo, value := "sha1", piece
+
+		if pieces := strings.SplitN(piece, ":", 2); len(pieces) == 2 {
+			algo, value = pieces[0], pieces[1]
+		}
+
+		if _, found := spdxChecksumAlgorithms[algo]; !found {
+			return nil, bosherr.Errorf("Expected digest algorithm '%s' to be one of 'sha1', 'sha256' or 'sha512'", algo)
+		}
+
+		checksums = append(checksums, releaseSbomChecksum{Algorithm: algo, Value: value})
+	}
+
+	return checksums, nil
+}
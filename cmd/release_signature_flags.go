@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	bitarball "github.com/cloudfoundry/bosh-cli/installation/tarball"
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	boshrelsig "github.com/cloudfoundry/bosh-cli/release/signature"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+// Shared
+type ReleaseSignatureFlags struct {
+	VerifySignature bool        `long:"verify-signature"                  description:"Refuse release tarballs without valid signature (TARBALL.sig) made by one of trusted keys"`
+	TrustedKeys     DirOrCWDArg `long:"trusted-keys" value-name:"DIR"    description:"Directory with PEM encoded trusted public keys or certificates"`
+}
+
+// AsReleaseReader wraps release reader so that only verified releases are returned
+func (f ReleaseSignatureFlags) AsReleaseReader(reader boshrel.Reader, fs boshsys.FileSystem) boshrel.Reader {
+	if !f.VerifySignature {
+		return reader
+	}
+
+	return boshrelsig.NewVerifyingReader(reader, boshrelsig.NewFSVerifier(f.TrustedKeys.Path, fs))
+}
+
+// AsTarballProvider wraps release tarball provider so that remote releases are refused
+// since their signatures are not downloaded next to cached tarballs
+func (f ReleaseSignatureFlags) AsTarballProvider(provider bitarball.Provider) bitarball.Provider {
+	if !f.VerifySignature {
+		return provider
+	}
+
+	return localTarballProvider{provider: provider}
+}
+
+type localTarballProvider struct {
+	provider bitarball.Provider
+}
+
+func (p localTarballProvider) Get(source bitarball.Source, stage boshui.Stage) (string, error) {
+	if strings.HasPrefix(source.GetURL(), "http") {
+		return "", bosherr.Errorf("Expected %s to be given by local path when verifying release signature", source.Description())
+	}
+
+	return p.provider.Get(source, stage)
+}
@@ -0,0 +1,63 @@
+package cmd_test
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	mock_tarball "github.com/cloudfoundry/bosh-cli/installation/tarball/mocks"
+	birelmanifest "github.com/cloudfoundry/bosh-cli/release/manifest"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+)
+
+var _ = Describe("ReleaseSignatureFlags", func() {
+	var (
+		mockCtrl        *gomock.Controller
+		tarballProvider *mock_tarball.MockProvider
+		stage           *fakeui.FakeStage
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		tarballProvider = mock_tarball.NewMockProvider(mockCtrl)
+		stage = fakeui.NewFakeStage()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("AsTarballProvider", func() {
+		remoteRelease := birelmanifest.ReleaseRef{Name: "fake-release", URL: "https://example.com/fake-release.tgz", SHA1: "fake-sha1"}
+		localRelease := birelmanifest.ReleaseRef{Name: "fake-release", URL: "file:///fake-release.tgz"}
+
+		It("returns given provider when not verifying signatures", func() {
+			provider := ReleaseSignatureFlags{}.AsTarballProvider(tarballProvider)
+
+			tarballProvider.EXPECT().Get(remoteRelease, stage).Return("/cache/fake-sha1", nil)
+
+			path, err := provider.Get(remoteRelease, stage)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/cache/fake-sha1"))
+		})
+
+		It("returns local release tarballs when verifying signatures", func() {
+			provider := ReleaseSignatureFlags{VerifySignature: true}.AsTarballProvider(tarballProvider)
+
+			tarballProvider.EXPECT().Get(localRelease, stage).Return("/fake-release.tgz", nil)
+
+			path, err := provider.Get(localRelease, stage)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(path).To(Equal("/fake-release.tgz"))
+		})
+
+		It("refuses remote releases before downloading when verifying signatures", func() {
+			provider := ReleaseSignatureFlags{VerifySignature: true}.AsTarballProvider(tarballProvider)
+
+			_, err := provider.Get(remoteRelease, stage)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected release 'fake-release' to be given by local path when verifying release signature"))
+		})
+	})
+})
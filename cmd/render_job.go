@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	boshuuid "github.com/cloudfoundry/bosh-utils/uuid"
+	"gopkg.in/yaml.v2"
+
+	bireljob "github.com/cloudfoundry/bosh-cli/release/job"
+	boshjobman "github.com/cloudfoundry/bosh-cli/release/job/manifest"
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
+	bitemplate "github.com/cloudfoundry/bosh-cli/templatescompiler"
+	bitemplateerb "github.com/cloudfoundry/bosh-cli/templatescompiler/erbrenderer"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+type RenderJobCmd struct {
+	erbRenderer bitemplateerb.ERBRenderer
+	fs          boshsys.FileSystem
+	uuidGen     boshuuid.Generator
+	ui          boshui.UI
+	logger      boshlog.Logger
+}
+
+type renderJobSpec struct {
+	Index      int    `yaml:"index"`
+	ID         string `yaml:"id"`
+	AZ         string `yaml:"az"`
+	Bootstrap  bool   `yaml:"bootstrap"`
+	Deployment string `yaml:"deployment"`
+	Address    string `yaml:"address"`
+
+	Networks map[string]renderJobSpecNetwork `yaml:"networks"`
+	Links    map[string]renderJobSpecLink    `yaml:"links"`
+}
+
+type renderJobSpecNetwork struct {
+	IP      string `yaml:"ip"`
+	Netmask string `yaml:"netmask"`
+	Gateway string `yaml:"gateway"`
+}
+
+type renderJobSpecLink struct {
+	Address    string                      `yaml:"address"`
+	Properties map[interface{}]interface{} `yaml:"properties"`
+	Instances  []renderJobSpecLinkInstance `yaml:"instances"`
+}
+
+type renderJobSpecLinkInstance struct {
+	Name      string `yaml:"name"`
+	Index     int    `yaml:"index"`
+	ID        string `yaml:"id"`
+	AZ        string `yaml:"az"`
+	Address   string `yaml:"address"`
+	Bootstrap bool   `yaml:"bootstrap"`
+}
+
+func NewRenderJobCmd(
+	erbRenderer bitemplateerb.ERBRenderer,
+	fs boshsys.FileSystem,
+	uuidGen boshuuid.Generator,
+	ui boshui.UI,
+	logger boshlog.Logger,
+) RenderJobCmd {
+	return RenderJobCmd{
+		erbRenderer: erbRenderer,
+		fs:          fs,
+		uuidGen:     uuidGen,
+		ui:          ui,
+		logger:      logger,
+	}
+}
+
+func (c RenderJobCmd) Run(opts RenderJobOpts) error {
+	jobPath := filepath.Join(opts.Directory.Path, "jobs", opts.Job)
+
+	job, err := c.readJob(jobPath)
+	if err != nil {
+		return err
+	}
+
+	var rawProperties map[interface{}]interface{}
+
+	err = yaml.Unmarshal(opts.Properties.Bytes, &rawProperties)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Unmarshalling job properties")
+	}
+
+	properties, err := biproperty.BuildMap(rawProperties)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Parsing job properties")
+	}
+
+	instance, err := c.instanceSpec(opts.Spec.Bytes)
+	if err != nil {
+		return err
+	}
+
+	context := bitemplate.NewInstanceEvaluationContext(*job, properties, instance, c.uuidGen, c.logger)
+
+	table := boshtbl.Table{
+		Content: "templates",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Template"),
+			boshtbl.NewHeader("Path"),
+		},
+	}
+
+	var srcs []string
+
+	for src, _ := range job.Templates {
+		srcs = append(srcs, src)
+	}
+
+	sort.Strings(srcs)
+
+	for _, src := range srcs {
+		dstPath := filepath.Join(opts.Output.Path, job.Templates[src])
+
+		err := c.renderFile(filepath.Join(jobPath, "templates", src), dstPath, context)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Rendering template '%s'", src)
+		}
+
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(src),
+			boshtbl.NewValueString(dstPath),
+		})
+	}
+
+	monitPath := filepath.Join(jobPath, "monit")
+
+	if c.fs.FileExists(monitPath) {
+		dstPath := filepath.Join(opts.Output.Path, "monit")
+
+		err := c.renderFile(monitPath, dstPath, context)
+		if err != nil {
+			return bosherr.WrapError(err, "Rendering monit file")
+		}
+
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString("monit"),
+			boshtbl.NewValueString(dstPath),
+		})
+	}
+
+	c.ui.PrintTable(table)
+
+	return nil
+}
+
+func (c RenderJobCmd) readJob(jobPath string) (*bireljob.Job, error) {
+	manifest, err := boshjobman.NewManifestFromPath(filepath.Join(jobPath, "spec"), c.fs)
+	if err != nil {
+		return nil, err
+	}
+
+	job := bireljob.NewExtractedJob(boshres.NewExistingResource(manifest.Name, "", ""), jobPath, c.fs)
+	job.Templates = manifest.Templates
+	job.PackageNames = manifest.Packages
+
+	job.Properties, err = bireljob.NewPropertyDefinitions(manifest.Name, manifest.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (c RenderJobCmd) instanceSpec(bytes []byte) (bitemplate.InstanceSpec, error) {
+	var spec renderJobSpec
+
+	err := yaml.Unmarshal(bytes, &spec)
+	if err != nil {
+		return bitemplate.InstanceSpec{}, bosherr.WrapErrorf(err, "Unmarshalling instance spec")
+	}
+
+	instance := bitemplate.InstanceSpec{
+		Index:      spec.Index,
+		ID:         spec.ID,
+		AZ:         spec.AZ,
+		Bootstrap:  spec.Bootstrap,
+		Deployment: spec.Deployment,
+		Address:    spec.Address,
+		Networks:   map[string]bitemplate.NetworkSpec{},
+		Links:      map[string]bitemplate.LinkSpec{},
+	}
+
+	for name, network := range spec.Networks {
+		instance.Networks[name] = bitemplate.NetworkSpec(network)
+	}
+
+	for name, link := range spec.Links {
+		properties, err := biproperty.BuildMap(link.Properties)
+		if err != nil {
+			return bitemplate.InstanceSpec{}, bosherr.WrapErrorf(err, "Parsing link '%s' properties", name)
+		}
+
+		linkSpec := bitemplate.LinkSpec{Address: link.Address, Properties: properties}
+
+		for _, inst := range link.Instances {
+			linkSpec.Instances = append(linkSpec.Instances, bitemplate.LinkInstanceSpec(inst))
+		}
+
+		instance.Links[name] = linkSpec
+	}
+
+	return instance, nil
+}
+
+func (c RenderJobCmd) renderFile(srcPath, dstPath string, context bitemplateerb.TemplateEvaluationContext) error {
+	err := c.fs.MkdirAll(filepath.Dir(dstPath), os.ModePerm)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Creating directory '%s'", filepath.Dir(dstPath))
+	}
+
+	return c.erbRenderer.Render(srcPath, dstPath, context)
+}
@@ -0,0 +1,187 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	bitemplateerb "github.com/cloudfoundry/bosh-cli/templatescompiler/erbrenderer"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+type recordingERBRenderer struct {
+	Inputs   [][2]string
+	Contexts []map[string]interface{}
+	Err      error
+}
+
+func (r *recordingERBRenderer) Render(srcPath, dstPath string, context bitemplateerb.TemplateEvaluationContext) error {
+	r.Inputs = append(r.Inputs, [2]string{srcPath, dstPath})
+
+	ctxBytes, err := json.Marshal(context)
+	Expect(err).ToNot(HaveOccurred())
+
+	var ctx map[string]interface{}
+	Expect(json.Unmarshal(ctxBytes, &ctx)).ToNot(HaveOccurred())
+	r.Contexts = append(r.Contexts, ctx)
+
+	return r.Err
+}
+
+var _ = Describe("RenderJobCmd", func() {
+	var (
+		erbRenderer *recordingERBRenderer
+		fs          *fakesys.FakeFileSystem
+		ui          *fakeui.FakeUI
+		command     RenderJobCmd
+	)
+
+	BeforeEach(func() {
+		erbRenderer = &recordingERBRenderer{}
+		fs = fakesys.NewFakeFileSystem()
+		ui = &fakeui.FakeUI{}
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		command = NewRenderJobCmd(erbRenderer, fs, &fakeuuid.FakeGenerator{}, ui, logger)
+	})
+
+	Describe("Run", func() {
+		var (
+			opts RenderJobOpts
+		)
+
+		BeforeEach(func() {
+			opts = RenderJobOpts{
+				Directory:  DirOrCWDArg{Path: "/release"},
+				Job:        "web",
+				Properties: FileBytesArg{Bytes: []byte("port: 8080\n")},
+				Spec: FileBytesArg{Bytes: []byte(`
+index: 1
+id: web-id
+az: z1
+bootstrap: true
+deployment: dep
+networks:
+  default: {ip: 10.0.0.5, netmask: 255.255.255.0, gateway: 10.0.0.1}
+links:
+  db:
+    address: db.bosh
+    properties: {port: 5432}
+    instances:
+    - {name: db, index: 0, id: db-id, address: 10.0.0.6}
+`)},
+				Output: DirOrCWDArg{Path: "/output"},
+			}
+
+			fs.WriteFileString("/release/jobs/web/spec", `---
+name: web
+templates:
+  ctl.erb: bin/ctl
+  config.yml.erb: config/config.yml
+properties:
+  port:
+    description: Port to listen on
+    default: 80
+  host:
+    default: 0.0.0.0
+`)
+			fs.WriteFileString("/release/jobs/web/monit", "")
+		})
+
+		act := func() error { return command.Run(opts) }
+
+		It("renders all templates and monit file into output directory", func() {
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(erbRenderer.Inputs).To(Equal([][2]string{
+				{"/release/jobs/web/templates/config.yml.erb", "/output/config/config.yml"},
+				{"/release/jobs/web/templates/ctl.erb", "/output/bin/ctl"},
+				{"/release/jobs/web/monit", "/output/monit"},
+			}))
+
+			Expect(fs.FileExists("/output/config")).To(BeTrue())
+			Expect(fs.FileExists("/output/bin")).To(BeTrue())
+
+			Expect(erbRenderer.Contexts).To(HaveLen(3))
+			Expect(erbRenderer.Contexts[0]["index"]).To(Equal(float64(1)))
+			Expect(erbRenderer.Contexts[0]["id"]).To(Equal("web-id"))
+			Expect(erbRenderer.Contexts[0]["az"]).To(Equal("z1"))
+			Expect(erbRenderer.Contexts[0]["bootstrap"]).To(Equal(true))
+			Expect(erbRenderer.Contexts[0]["deployment"]).To(Equal("dep"))
+			Expect(erbRenderer.Contexts[0]["job"]).To(Equal(map[string]interface{}{"name": "web"}))
+			Expect(erbRenderer.Contexts[0]["job_properties"]).To(Equal(map[string]interface{}{"port": float64(8080)}))
+			Expect(erbRenderer.Contexts[0]["default_properties"]).To(Equal(map[string]interface{}{
+				"port": float64(80),
+				"host": "0.0.0.0",
+			}))
+			Expect(erbRenderer.Contexts[0]["networks"]).To(Equal(map[string]interface{}{
+				"default": map[string]interface{}{
+					"ip":      "10.0.0.5",
+					"netmask": "255.255.255.0",
+					"gateway": "10.0.0.1",
+				},
+			}))
+			Expect(erbRenderer.Contexts[0]["links"]).To(HaveKey("db"))
+
+			Expect(ui.Table).To(Equal(boshtbl.Table{
+				Content: "templates",
+				Header: []boshtbl.Header{
+					boshtbl.NewHeader("Template"),
+					boshtbl.NewHeader("Path"),
+				},
+				Rows: [][]boshtbl.Value{
+					{boshtbl.NewValueString("config.yml.erb"), boshtbl.NewValueString("/output/config/config.yml")},
+					{boshtbl.NewValueString("ctl.erb"), boshtbl.NewValueString("/output/bin/ctl")},
+					{boshtbl.NewValueString("monit"), boshtbl.NewValueString("/output/monit")},
+				},
+			}))
+		})
+
+		It("skips monit file if job does not have one", func() {
+			fs.RemoveAll("/release/jobs/web/monit")
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(erbRenderer.Inputs).To(HaveLen(2))
+		})
+
+		It("returns error if rendering template fails", func() {
+			erbRenderer.Err = errors.New("fake-err")
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Rendering template 'config.yml.erb'"))
+		})
+
+		It("returns error if job spec cannot be read", func() {
+			opts.Job = "unknown"
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(ui.Tables).To(BeEmpty())
+		})
+
+		It("returns error if properties are not valid YAML", func() {
+			opts.Properties = FileBytesArg{Bytes: []byte("-")}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unmarshalling job properties"))
+		})
+
+		It("returns error if instance spec is not valid YAML", func() {
+			opts.Spec = FileBytesArg{Bytes: []byte("index: [")}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unmarshalling instance spec"))
+		})
+	})
+})
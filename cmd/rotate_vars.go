@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/cppforlife/go-patch/patch"
+	"gopkg.in/yaml.v2"
+
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+type RotateVarsCmd struct {
+	ui boshui.UI
+}
+
+func NewRotateVarsCmd(ui boshui.UI) RotateVarsCmd {
+	return RotateVarsCmd{ui: ui}
+}
+
+func (c RotateVarsCmd) Run(opts RotateVarsOpts) error {
+	if !opts.VarsFSStore.IsSet() {
+		return bosherr.Error("Expected vars store to be specified")
+	}
+
+	if len(opts.Names) == 0 && len(opts.SignedBy) == 0 {
+		return bosherr.Error("Expected at least one variable name or CA to be specified")
+	}
+
+	op := opts.OpsFlags.AsOp()
+
+	defs, err := c.definitions(opts.Manifest.Bytes, op)
+	if err != nil {
+		return err
+	}
+
+	selectedDefs, err := c.selectDefinitions(defs, opts.Names, opts.SignedBy)
+	if err != nil {
+		return err
+	}
+
+	store := opts.VarsFSStore
+	store.Encryptor = opts.VarsFSKey.Encryptor(VarsStorePassphraseEnv)
+
+	vars, err := store.load()
+	if err != nil {
+		return err
+	}
+
+	backupNames := map[string]string{}
+
+	for _, def := range selectedDefs {
+		if val, found := vars[def.Name]; found {
+			backupName := def.Name + opts.BackupSuffix
+
+			if _, found := vars[backupName]; found {
+				return bosherr.Errorf("Expected variable '%s' to not exist to keep previous value of '%s'; remove it or use different backup suffix", backupName, def.Name)
+			}
+
+			backupNames[def.Name] = backupName
+			vars[backupName] = val
+			delete(vars, def.Name)
+		}
+	}
+
+	// Evaluating manifest regenerates removed variables in definition order
+	// with interpolated options (e.g. CAs before certificates signed by them);
+	// variables are regenerated in memory so that store is left intact on failure
+	varFlags := opts.VarFlags
+	varFlags.VarsFSStore = store.inMemory(vars)
+
+	_, err = boshtpl.NewTemplate(opts.Manifest.Bytes).Evaluate(varFlags.AsVariables(), op, boshtpl.EvaluateOpts{})
+	if err != nil {
+		return bosherr.WrapError(err, "Regenerating variables")
+	}
+
+	err = store.save(vars)
+	if err != nil {
+		return err
+	}
+
+	table := boshtbl.Table{
+		Content: "variables",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Name"),
+			boshtbl.NewHeader("Type"),
+			boshtbl.NewHeader("Previous Value"),
+			boshtbl.NewHeader("Regenerated"),
+		},
+
+		Notes: []string{"Previous values are kept under names with '" + opts.BackupSuffix + "' suffix"},
+	}
+
+	for _, def := range selectedDefs {
+		_, regenerated := vars[def.Name]
+
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(def.Name),
+			boshtbl.NewValueString(def.Type),
+			boshtbl.NewValueString(backupNames[def.Name]),
+			boshtbl.NewValueBool(regenerated),
+		})
+	}
+
+	c.ui.PrintTable(table)
+
+	return nil
+}
+
+func (c RotateVarsCmd) definitions(manifestBytes []byte, op patch.Op) ([]boshtpl.VariableDefinition, error) {
+	var obj interface{}
+
+	err := yaml.Unmarshal(manifestBytes, &obj)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Deserializing manifest")
+	}
+
+	obj, err = op.Apply(obj)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Applying ops")
+	}
+
+	bytes, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest struct {
+		Variables []boshtpl.VariableDefinition
+	}
+
+	err = yaml.Unmarshal(bytes, &manifest)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Deserializing variable definitions")
+	}
+
+	return manifest.Variables, nil
+}
+
+func (c RotateVarsCmd) selectDefinitions(defs []boshtpl.VariableDefinition, names, caNames []string) ([]boshtpl.VariableDefinition, error) {
+	selected := map[string]struct{}{}
+
+	for _, name := range names {
+		if _, found := c.findDefinition(defs, name); !found {
+			return nil, bosherr.Errorf("Expected variable '%s' to be defined in manifest", name)
+		}
+
+		selected[name] = struct{}{}
+	}
+
+	signers := map[string]struct{}{}
+
+	for _, name := range caNames {
+		def, found := c.findDefinition(defs, name)
+		if !found || def.Type != "certificate" {
+			return nil, bosherr.Errorf("Expected CA '%s' to be defined in manifest as a certificate", name)
+		}
+
+		signers[name] = struct{}{}
+	}
+
+	// Follow 'ca' references to include certificates signed by intermediate CAs
+	for changed := true; changed; {
+		changed = false
+
+		for _, def := range defs {
+			caName := c.caName(def)
+			if len(caName) == 0 {
+				continue
+			}
+
+			if _, found := signers[caName]; !found {
+				continue
+			}
+
+			if _, found := signers[def.Name]; !found {
+				signers[def.Name] = struct{}{}
+				selected[def.Name] = struct{}{}
+				changed = true
+			}
+		}
+	}
+
+	var selectedDefs []boshtpl.VariableDefinition
+
+	for _, def := range defs {
+		if _, found := selected[def.Name]; found {
+			selectedDefs = append(selectedDefs, def)
+		}
+	}
+
+	return selectedDefs, nil
+}
+
+func (c RotateVarsCmd) findDefinition(defs []boshtpl.VariableDefinition, name string) (boshtpl.VariableDefinition, bool) {
+	for _, def := range defs {
+		if def.Name == name {
+			return def, true
+		}
+	}
+
+	return boshtpl.VariableDefinition{}, false
+}
+
+func (c RotateVarsCmd) caName(def boshtpl.VariableDefinition) string {
+	if def.Type != "certificate" {
+		return ""
+	}
+
+	opts, ok := def.Options.(map[interface{}]interface{})
+	if !ok {
+		return ""
+	}
+
+	caName, _ := opts["ca"].(string)
+
+	return caName
+}
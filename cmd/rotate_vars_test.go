@@ -0,0 +1,249 @@
+package cmd_test
+
+import (
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	"github.com/cppforlife/go-patch/patch"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("RotateVarsCmd", func() {
+	var (
+		fs      *fakesys.FakeFileSystem
+		ui      *fakeui.FakeUI
+		command RotateVarsCmd
+	)
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+		ui = &fakeui.FakeUI{}
+		command = NewRotateVarsCmd(ui)
+	})
+
+	Describe("Run", func() {
+		var (
+			opts RotateVarsOpts
+		)
+
+		const manifest = `
+variables:
+- name: password
+  type: password
+- name: ca
+  type: certificate
+  options: {is_ca: true, common_name: ca}
+- name: int_ca
+  type: certificate
+  options: {is_ca: true, common_name: int_ca, ca: ca}
+- name: leaf
+  type: certificate
+  options: {common_name: ((leaf_cn)), ca: int_ca}
+- name: other_ca
+  type: certificate
+  options: {is_ca: true, common_name: other_ca}
+- name: other_leaf
+  type: certificate
+  options: {common_name: other_leaf, ca: other_ca}
+`
+
+		readStore := func() map[interface{}]interface{} {
+			bytes, err := fs.ReadFile("/store")
+			Expect(err).ToNot(HaveOccurred())
+
+			var vars map[interface{}]interface{}
+
+			err = yaml.Unmarshal(bytes, &vars)
+			Expect(err).ToNot(HaveOccurred())
+
+			return vars
+		}
+
+		BeforeEach(func() {
+			opts = RotateVarsOpts{}
+			opts.Manifest = FileBytesArg{Bytes: []byte(manifest)}
+			opts.VarKVs = []boshtpl.VarKV{{Name: "leaf_cn", Value: "leaf"}}
+			opts.VarsFSStore = VarsFSStore{FS: fs}
+			opts.BackupSuffix = "_old"
+
+			err := (&opts.VarsFSStore).UnmarshalFlag("/store")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = boshtpl.NewTemplate([]byte(manifest)).Evaluate(opts.VarFlags.AsVariables(), nil, boshtpl.EvaluateOpts{})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		act := func() error { return command.Run(opts) }
+
+		It("regenerates named variables and keeps previous values", func() {
+			prevVars := readStore()
+
+			opts.Names = []string{"password"}
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			vars := readStore()
+			Expect(vars["password_old"]).To(Equal(prevVars["password"]))
+			Expect(vars["password"]).ToNot(Equal(prevVars["password"]))
+			Expect(vars["ca"]).To(Equal(prevVars["ca"]))
+
+			Expect(ui.Table).To(Equal(boshtbl.Table{
+				Content: "variables",
+
+				Header: []boshtbl.Header{
+					boshtbl.NewHeader("Name"),
+					boshtbl.NewHeader("Type"),
+					boshtbl.NewHeader("Previous Value"),
+					boshtbl.NewHeader("Regenerated"),
+				},
+
+				Rows: [][]boshtbl.Value{
+					{
+						boshtbl.NewValueString("password"),
+						boshtbl.NewValueString("password"),
+						boshtbl.NewValueString("password_old"),
+						boshtbl.NewValueBool(true),
+					},
+				},
+
+				Notes: []string{"Previous values are kept under names with '_old' suffix"},
+			}))
+		})
+
+		It("regenerates all certificates signed by CA following intermediate CAs", func() {
+			prevVars := readStore()
+
+			opts.SignedBy = []string{"ca"}
+			opts.BackupSuffix = "_prev"
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			vars := readStore()
+			Expect(vars["ca"]).To(Equal(prevVars["ca"]))
+			Expect(vars["other_ca"]).To(Equal(prevVars["other_ca"]))
+			Expect(vars["other_leaf"]).To(Equal(prevVars["other_leaf"]))
+
+			Expect(vars["int_ca_prev"]).To(Equal(prevVars["int_ca"]))
+			Expect(vars["int_ca"]).ToNot(Equal(prevVars["int_ca"]))
+
+			Expect(vars["leaf_prev"]).To(Equal(prevVars["leaf"]))
+			Expect(vars["leaf"]).ToNot(Equal(prevVars["leaf"]))
+
+			// Regenerated certificate must be signed by regenerated intermediate CA
+			leaf := vars["leaf"].(map[interface{}]interface{})
+			intCA := vars["int_ca"].(map[interface{}]interface{})
+			Expect(leaf["ca"]).To(Equal(intCA["certificate"]))
+
+			Expect(ui.Table.Rows).To(Equal([][]boshtbl.Value{
+				{
+					boshtbl.NewValueString("int_ca"),
+					boshtbl.NewValueString("certificate"),
+					boshtbl.NewValueString("int_ca_prev"),
+					boshtbl.NewValueBool(true),
+				},
+				{
+					boshtbl.NewValueString("leaf"),
+					boshtbl.NewValueString("certificate"),
+					boshtbl.NewValueString("leaf_prev"),
+					boshtbl.NewValueBool(true),
+				},
+			}))
+		})
+
+		It("regenerates variables defined via ops files", func() {
+			opts.OpsFiles = []OpsFileArg{{
+				Ops: patch.Ops{
+					patch.ReplaceOp{
+						Path:  patch.MustNewPointerFromString("/variables/-"),
+						Value: map[interface{}]interface{}{"name": "extra", "type": "password"},
+					},
+				},
+			}}
+			opts.Names = []string{"extra"}
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			vars := readStore()
+			Expect(vars["extra"]).ToNot(BeEmpty())
+			Expect(vars).ToNot(HaveKey("extra_old"))
+			Expect(ui.Table.Rows[0][2]).To(Equal(boshtbl.NewValueString("")))
+		})
+
+		It("does not change vars store if regenerating fails", func() {
+			prevVars := readStore()
+
+			opts.OpsFiles = []OpsFileArg{{
+				Ops: patch.Ops{
+					patch.ReplaceOp{
+						Path:  patch.MustNewPointerFromString("/variables/-"),
+						Value: map[interface{}]interface{}{"name": "extra", "type": "unknown-type"},
+					},
+				},
+			}}
+			opts.Names = []string{"password", "extra"}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Regenerating variables"))
+
+			Expect(readStore()).To(Equal(prevVars))
+		})
+
+		It("returns an error if backup variable already exists", func() {
+			prevStore, err := fs.ReadFileString("/store")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = fs.WriteFileString("/store", prevStore+"password_old: prev-password\n")
+			Expect(err).ToNot(HaveOccurred())
+
+			prevVars := readStore()
+
+			opts.Names = []string{"password"}
+
+			err = act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected variable 'password_old' to not exist to keep previous value of 'password'; remove it or use different backup suffix"))
+
+			Expect(readStore()).To(Equal(prevVars))
+		})
+
+		It("returns an error if variable is not defined in manifest", func() {
+			opts.Names = []string{"unknown"}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected variable 'unknown' to be defined in manifest"))
+		})
+
+		It("returns an error if CA is not defined as certificate", func() {
+			opts.SignedBy = []string{"password"}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected CA 'password' to be defined in manifest as a certificate"))
+		})
+
+		It("returns an error if nothing is selected for regeneration", func() {
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected at least one variable name or CA to be specified"))
+		})
+
+		It("returns an error if vars store is not specified", func() {
+			opts.VarsFSStore = VarsFSStore{}
+			opts.Names = []string{"password"}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected vars store to be specified"))
+		})
+	})
+})
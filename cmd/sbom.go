@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"sort"
+
+	"code.cloudfoundry.org/clock"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	boshuuid "github.com/cloudfoundry/bosh-utils/uuid"
+
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	boshreldir "github.com/cloudfoundry/bosh-cli/releasedir"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+type SbomCmd struct {
+	releaseReader    boshrel.Reader
+	inventoryFactory func(string) boshreldir.Inventory
+
+	uuidGen     boshuuid.Generator
+	timeService clock.Clock
+	fs          boshsys.FileSystem
+	ui          boshui.UI
+}
+
+func NewSbomCmd(
+	releaseReader boshrel.Reader,
+	inventoryFactory func(string) boshreldir.Inventory,
+	uuidGen boshuuid.Generator,
+	timeService clock.Clock,
+	fs boshsys.FileSystem,
+	ui boshui.UI,
+) SbomCmd {
+	return SbomCmd{
+		releaseReader:    releaseReader,
+		inventoryFactory: inventoryFactory,
+
+		uuidGen:     uuidGen,
+		timeService: timeService,
+		fs:          fs,
+		ui:          ui,
+	}
+}
+
+func (c SbomCmd) Run(opts SbomOpts) error {
+	path := opts.Args.Release
+
+	release, err := c.releaseReader.Read(path)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Reading release '%s'", path)
+	}
+
+	defer release.CleanUp()
+
+	id, err := c.uuidGen.Generate()
+	if err != nil {
+		return bosherr.WrapError(err, "Generating SBOM ID")
+	}
+
+	sbom := releaseSbom{
+		Name:    release.Name(),
+		Version: release.Version(),
+		ID:      id,
+		Created: c.timeService.Now(),
+	}
+
+	// Release directories do not include built archives hence name, blobs
+	// and package digests are taken from the directory itself; jobs and
+	// license are listed without digests
+	var inventory boshreldir.Inventory
+
+	if info, err := c.fs.Stat(path); err == nil && info.IsDir() {
+		inventory = c.inventoryFactory(path)
+
+		sbom.Name, err = inventory.Name()
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Reading release name")
+		}
+
+		blobs, err := inventory.Blobs()
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Listing blobs")
+		}
+
+		for _, blob := range blobs {
+			sbom.Blobs = append(sbom.Blobs, releaseSbomItem{Name: blob.Path, Digest: blob.SHA1})
+		}
+	}
+
+	for _, job := range release.Jobs() {
+		item := releaseSbomItem{
+			Name:         job.Name(),
+			Version:      job.Fingerprint(),
+			Dependencies: c.sortedNames(job.PackageNames),
+		}
+
+		if inventory == nil {
+			item.Digest = job.ArchiveDigest()
+		}
+
+		sbom.Jobs = append(sbom.Jobs, item)
+	}
+
+	for _, pkg := range release.Packages() {
+		var digest string
+
+		if inventory == nil {
+			digest = pkg.ArchiveDigest()
+		} else {
+			digest, err = inventory.PackageDigest(pkg.Name(), pkg.Fingerprint())
+			if err != nil {
+				return err
+			}
+		}
+
+		sbom.Packages = append(sbom.Packages, releaseSbomItem{
+			Name:         pkg.Name(),
+			Version:      pkg.Fingerprint(),
+			Digest:       digest,
+			Dependencies: c.sortedNames(pkg.DependencyNames()),
+		})
+	}
+
+	for _, pkg := range release.CompiledPackages() {
+		sbom.CompiledPackages = append(sbom.CompiledPackages, releaseSbomItem{
+			Name:         pkg.Name(),
+			Version:      pkg.Fingerprint(),
+			Digest:       pkg.ArchiveDigest(),
+			Dependencies: c.sortedNames(pkg.DependencyNames()),
+			Stemcell:     pkg.OSVersionSlug(),
+		})
+	}
+
+	if lic := release.License(); lic != nil {
+		sbom.License = &releaseSbomItem{Name: lic.Name(), Version: lic.Fingerprint()}
+
+		if inventory == nil {
+			sbom.License.Digest = lic.ArchiveDigest()
+		}
+	}
+
+	sort.Sort(releaseSbomItemsByName(sbom.Jobs))
+	sort.Sort(releaseSbomItemsByName(sbom.Packages))
+	sort.Sort(releaseSbomItemsByName(sbom.CompiledPackages))
+	sort.Sort(releaseSbomItemsByName(sbom.Blobs))
+
+	output, err := sbom.Format(opts.Format)
+	if err != nil {
+		return err
+	}
+
+	c.ui.PrintBlock([]byte(output))
+
+	return nil
+}
+
+func (c SbomCmd) sortedNames(names []string) []string {
+	sorted := append([]string{}, names...)
+	sort.Strings(sorted)
+	return sorted
+}
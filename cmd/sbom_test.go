@@ -0,0 +1,433 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+	boshlic "github.com/cloudfoundry/bosh-cli/release/license"
+	boshpkg "github.com/cloudfoundry/bosh-cli/release/pkg"
+	fakerel "github.com/cloudfoundry/bosh-cli/release/releasefakes"
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
+	boshreldir "github.com/cloudfoundry/bosh-cli/releasedir"
+	fakereldir "github.com/cloudfoundry/bosh-cli/releasedir/releasedirfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+)
+
+var _ = Describe("SbomCmd", func() {
+	var (
+		releaseReader *fakerel.FakeReader
+		inventory     *fakereldir.FakeInventory
+		uuidGen       *fakeuuid.FakeGenerator
+		fs            *fakesys.FakeFileSystem
+		ui            *fakeui.FakeUI
+		command       SbomCmd
+	)
+
+	BeforeEach(func() {
+		releaseReader = &fakerel.FakeReader{}
+		inventory = &fakereldir.FakeInventory{}
+		uuidGen = fakeuuid.NewFakeGenerator()
+		uuidGen.GeneratedUUID = "fake-uuid"
+		fs = fakesys.NewFakeFileSystem()
+		ui = &fakeui.FakeUI{}
+
+		inventoryFactory := func(dirPath string) boshreldir.Inventory {
+			Expect(dirPath).To(Equal("/dir"))
+			return inventory
+		}
+
+		now := time.Date(2017, time.January, 2, 3, 4, 5, 0, time.UTC)
+
+		command = NewSbomCmd(releaseReader, inventoryFactory, uuidGen, fakeclock.NewFakeClock(now), fs, ui)
+	})
+
+	Describe("Run", func() {
+		var (
+			opts    SbomOpts
+			release *fakerel.FakeRelease
+		)
+
+		BeforeEach(func() {
+			web := boshjob.NewJob(boshres.NewResourceWithBuiltArchive("web", "web-fp", "/web.tgz", "web-sha1"))
+			web.PackageNames = []string{"app"}
+
+			release = &fakerel.FakeRelease{}
+			release.NameReturns("rel")
+			release.VersionReturns("1.0")
+			release.JobsReturns([]*boshjob.Job{web})
+			release.PackagesReturns([]*boshpkg.Package{
+				boshpkg.NewPackage(boshres.NewResourceWithBuiltArchive("lib", "lib-fp", "/lib.tgz", "sha256:lib-sha256"), nil),
+				boshpkg.NewPackage(boshres.NewResourceWithBuiltArchive("app", "app-fp", "/app.tgz", "app-sha1"), []string{"lib"}),
+			})
+			release.LicenseReturns(boshlic.NewLicense(boshres.NewResourceWithBuiltArchive("license", "lic-fp", "/lic.tgz", "lic-sha1")))
+
+			releaseReader.ReadReturns(release, nil)
+
+			fs.WriteFileString("/rel.tgz", "")
+		})
+
+		act := func() error { return command.Run(opts) }
+
+		Context("when format is spdx-json", func() {
+			BeforeEach(func() {
+				opts = SbomOpts{Args: SbomArgs{Release: "/rel.tgz"}, Format: "spdx-json"}
+			})
+
+			It("prints SPDX document with jobs, packages, license and their relationships", func() {
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(releaseReader.ReadArgsForCall(0)).To(Equal("/rel.tgz"))
+				Expect(release.CleanUpCallCount()).To(Equal(1))
+
+				pkg := func(name, id, version, purpose, comment, checksums string) string {
+					return `{
+						"name": "` + name + `",
+						"SPDXID": "` + id + `",
+						"versionInfo": "` + version + `",
+						"downloadLocation": "NOASSERTION",
+						"filesAnalyzed": false,
+						"checksums": ` + checksums + `,
+						"licenseConcluded": "NOASSERTION",
+						"licenseDeclared": "NOASSERTION",
+						"copyrightText": "NOASSERTION",
+						"primaryPackagePurpose": "` + purpose + `",
+						"comment": "` + comment + `"
+					}`
+				}
+
+				Expect(ui.Blocks).To(HaveLen(1))
+				Expect(ui.Blocks[0]).To(MatchJSON(`{
+					"spdxVersion": "SPDX-2.3",
+					"dataLicense": "CC0-1.0",
+					"SPDXID": "SPDXRef-DOCUMENT",
+					"name": "rel/1.0",
+					"documentNamespace": "https://bosh.io/spdx/rel-fake-uuid",
+					"creationInfo": {"created": "2017-01-02T03:04:05Z", "creators": ["Tool: bosh-cli"]},
+					"packages": [
+						{
+							"name": "rel",
+							"SPDXID": "SPDXRef-Release",
+							"versionInfo": "1.0",
+							"downloadLocation": "NOASSERTION",
+							"filesAnalyzed": false,
+							"licenseConcluded": "NOASSERTION",
+							"licenseDeclared": "NOASSERTION",
+							"copyrightText": "NOASSERTION",
+							"primaryPackagePurpose": "ARCHIVE",
+							"comment": "BOSH release"
+						},
+						` + pkg("web", "SPDXRef-Job-web", "web-fp", "APPLICATION", "BOSH job",
+					`[{"algorithm": "SHA1", "checksumValue": "web-sha1"}]`) + `,
+						` + pkg("app", "SPDXRef-Package-app", "app-fp", "LIBRARY", "BOSH package",
+					`[{"algorithm": "SHA1", "checksumValue": "app-sha1"}]`) + `,
+						` + pkg("lib", "SPDXRef-Package-lib", "lib-fp", "LIBRARY", "BOSH package",
+					`[{"algorithm": "SHA256", "checksumValue": "lib-sha256"}]`) + `,
+						` + pkg("license", "SPDXRef-License-license", "lic-fp", "FILE", "BOSH release license and notice files",
+					`[{"algorithm": "SHA1", "checksumValue": "lic-sha1"}]`) + `
+					],
+					"relationships": [
+						{"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-Release"},
+						{"spdxElementId": "SPDXRef-Release", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-Job-web"},
+						{"spdxElementId": "SPDXRef-Job-web", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-Package-app"},
+						{"spdxElementId": "SPDXRef-Release", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-Package-app"},
+						{"spdxElementId": "SPDXRef-Package-app", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-Package-lib"},
+						{"spdxElementId": "SPDXRef-Release", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-Package-lib"},
+						{"spdxElementId": "SPDXRef-Release", "relationshipType": "CONTAINS", "relatedSpdxElement": "SPDXRef-License-license"}
+					]
+				}`))
+			})
+		})
+
+		Context("when format is cyclonedx-json", func() {
+			BeforeEach(func() {
+				opts = SbomOpts{Args: SbomArgs{Release: "/rel.tgz"}, Format: "cyclonedx-json"}
+			})
+
+			It("prints CycloneDX document with jobs, packages, license and their dependencies", func() {
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(ui.Blocks).To(HaveLen(1))
+				Expect(ui.Blocks[0]).To(MatchJSON(`{
+					"bomFormat": "CycloneDX",
+					"specVersion": "1.5",
+					"serialNumber": "urn:uuid:fake-uuid",
+					"version": 1,
+					"metadata": {
+						"timestamp": "2017-01-02T03:04:05Z",
+						"tools": {"components": [{"type": "application", "name": "bosh-cli"}]},
+						"component": {
+							"type": "application",
+							"bom-ref": "release",
+							"name": "rel",
+							"version": "1.0",
+							"properties": [{"name": "bosh:type", "value": "release"}]
+						}
+					},
+					"components": [
+						{
+							"type": "application",
+							"bom-ref": "job/web",
+							"name": "web",
+							"version": "web-fp",
+							"hashes": [{"alg": "SHA-1", "content": "web-sha1"}],
+							"properties": [{"name": "bosh:type", "value": "job"}]
+						},
+						{
+							"type": "library",
+							"bom-ref": "package/app",
+							"name": "app",
+							"version": "app-fp",
+							"hashes": [{"alg": "SHA-1", "content": "app-sha1"}],
+							"properties": [{"name": "bosh:type", "value": "package"}]
+						},
+						{
+							"type": "library",
+							"bom-ref": "package/lib",
+							"name": "lib",
+							"version": "lib-fp",
+							"hashes": [{"alg": "SHA-256", "content": "lib-sha256"}],
+							"properties": [{"name": "bosh:type", "value": "package"}]
+						},
+						{
+							"type": "file",
+							"bom-ref": "license/license",
+							"name": "license",
+							"version": "lic-fp",
+							"hashes": [{"alg": "SHA-1", "content": "lic-sha1"}],
+							"properties": [{"name": "bosh:type", "value": "license"}]
+						}
+					],
+					"dependencies": [
+						{"ref": "release", "dependsOn": ["job/web", "package/app", "package/lib"]},
+						{"ref": "job/web", "dependsOn": ["package/app"]},
+						{"ref": "package/app", "dependsOn": ["package/lib"]},
+						{"ref": "package/lib", "dependsOn": []}
+					]
+				}`))
+			})
+		})
+
+		Context("when release is compiled", func() {
+			BeforeEach(func() {
+				lib := boshpkg.NewCompiledPackageWithArchive("lib", "lib-fp", "ubuntu-xenial/97", "/lib.tgz", "lib-sha1", nil)
+				app := boshpkg.NewCompiledPackageWithArchive("app", "app-fp", "ubuntu-xenial/97", "/app.tgz", "sha256:app-sha256", []string{"lib"})
+
+				release.PackagesReturns(nil)
+				release.CompiledPackagesReturns([]*boshpkg.CompiledPackage{lib, app})
+				release.LicenseReturns(nil)
+			})
+
+			It("prints SPDX document with compiled packages that jobs depend on", func() {
+				opts = SbomOpts{Args: SbomArgs{Release: "/rel.tgz"}, Format: "spdx-json"}
+
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				var doc struct {
+					Packages []struct {
+						Name      string
+						SPDXID    string
+						Checksums []map[string]string
+						Comment   string
+					}
+					Relationships []map[string]string
+				}
+
+				Expect(ui.Blocks).To(HaveLen(1))
+				Expect(json.Unmarshal([]byte(ui.Blocks[0]), &doc)).To(Succeed())
+
+				Expect(doc.Packages).To(HaveLen(4))
+				Expect(doc.Packages[2].Name).To(Equal("app"))
+				Expect(doc.Packages[2].SPDXID).To(Equal("SPDXRef-CompiledPackage-app"))
+				Expect(doc.Packages[2].Checksums).To(Equal([]map[string]string{{"algorithm": "SHA256", "checksumValue": "app-sha256"}}))
+				Expect(doc.Packages[2].Comment).To(Equal("BOSH package compiled for ubuntu-xenial/97"))
+				Expect(doc.Packages[3].SPDXID).To(Equal("SPDXRef-CompiledPackage-lib"))
+
+				Expect(doc.Relationships).To(ContainElement(map[string]string{
+					"spdxElementId": "SPDXRef-Job-web", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-CompiledPackage-app"}))
+				Expect(doc.Relationships).To(ContainElement(map[string]string{
+					"spdxElementId": "SPDXRef-CompiledPackage-app", "relationshipType": "DEPENDS_ON", "relatedSpdxElement": "SPDXRef-CompiledPackage-lib"}))
+			})
+
+			It("prints CycloneDX document with compiled packages and their dependencies", func() {
+				opts = SbomOpts{Args: SbomArgs{Release: "/rel.tgz"}, Format: "cyclonedx-json"}
+
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				var doc struct {
+					Components   []json.RawMessage
+					Dependencies []json.RawMessage
+				}
+
+				Expect(ui.Blocks).To(HaveLen(1))
+				Expect(json.Unmarshal([]byte(ui.Blocks[0]), &doc)).To(Succeed())
+
+				Expect(doc.Components).To(HaveLen(3))
+				Expect(string(doc.Components[1])).To(MatchJSON(`{
+					"type": "library",
+					"bom-ref": "compiled-package/app",
+					"name": "app",
+					"version": "app-fp",
+					"hashes": [{"alg": "SHA-256", "content": "app-sha256"}],
+					"properties": [
+						{"name": "bosh:type", "value": "compiled-package"},
+						{"name": "bosh:stemcell", "value": "ubuntu-xenial/97"}
+					]
+				}`))
+				Expect(string(doc.Components[2])).To(MatchJSON(`{
+					"type": "library",
+					"bom-ref": "compiled-package/lib",
+					"name": "lib",
+					"version": "lib-fp",
+					"hashes": [{"alg": "SHA-1", "content": "lib-sha1"}],
+					"properties": [
+						{"name": "bosh:type", "value": "compiled-package"},
+						{"name": "bosh:stemcell", "value": "ubuntu-xenial/97"}
+					]
+				}`))
+
+				Expect(doc.Dependencies).To(HaveLen(4))
+				Expect(string(doc.Dependencies[0])).To(MatchJSON(`{"ref": "release", "dependsOn": ["job/web", "compiled-package/app", "compiled-package/lib"]}`))
+				Expect(string(doc.Dependencies[1])).To(MatchJSON(`{"ref": "job/web", "dependsOn": ["compiled-package/app"]}`))
+				Expect(string(doc.Dependencies[2])).To(MatchJSON(`{"ref": "compiled-package/app", "dependsOn": ["compiled-package/lib"]}`))
+			})
+		})
+
+		Context("when release is a directory", func() {
+			BeforeEach(func() {
+				opts = SbomOpts{Args: SbomArgs{Release: "/dir"}, Format: "cyclonedx-json"}
+
+				fs.MkdirAll("/dir", 0755)
+
+				release.NameReturns("")
+				release.VersionReturns("")
+				release.JobsReturns(nil)
+				release.PackagesReturns([]*boshpkg.Package{
+					boshpkg.NewPackage(boshres.NewResource("app", "app-fp", nil), nil),
+				})
+				release.LicenseReturns(nil)
+
+				inventory.NameReturns("dir-rel", nil)
+				inventory.BlobsReturns([]boshreldir.Blob{
+					{Path: "src/b.tgz", SHA1: "sha256:b-sha256"},
+					{Path: "src/a.tgz", SHA1: "a-sha1"},
+				}, nil)
+				inventory.PackageDigestReturns("app-index-sha1", nil)
+			})
+
+			It("includes blobs and package digests recorded in the directory", func() {
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+
+				name, fp := inventory.PackageDigestArgsForCall(0)
+				Expect(name).To(Equal("app"))
+				Expect(fp).To(Equal("app-fp"))
+
+				Expect(ui.Blocks).To(HaveLen(1))
+				Expect(ui.Blocks[0]).To(MatchJSON(`{
+					"bomFormat": "CycloneDX",
+					"specVersion": "1.5",
+					"serialNumber": "urn:uuid:fake-uuid",
+					"version": 1,
+					"metadata": {
+						"timestamp": "2017-01-02T03:04:05Z",
+						"tools": {"components": [{"type": "application", "name": "bosh-cli"}]},
+						"component": {
+							"type": "application",
+							"bom-ref": "release",
+							"name": "dir-rel",
+							"properties": [{"name": "bosh:type", "value": "release"}]
+						}
+					},
+					"components": [
+						{
+							"type": "library",
+							"bom-ref": "package/app",
+							"name": "app",
+							"version": "app-fp",
+							"hashes": [{"alg": "SHA-1", "content": "app-index-sha1"}],
+							"properties": [{"name": "bosh:type", "value": "package"}]
+						},
+						{
+							"type": "file",
+							"bom-ref": "blob/src/a.tgz",
+							"name": "src/a.tgz",
+							"hashes": [{"alg": "SHA-1", "content": "a-sha1"}],
+							"properties": [{"name": "bosh:type", "value": "blob"}]
+						},
+						{
+							"type": "file",
+							"bom-ref": "blob/src/b.tgz",
+							"name": "src/b.tgz",
+							"hashes": [{"alg": "SHA-256", "content": "b-sha256"}],
+							"properties": [{"name": "bosh:type", "value": "blob"}]
+						}
+					],
+					"dependencies": [
+						{"ref": "release", "dependsOn": ["package/app"]},
+						{"ref": "package/app", "dependsOn": []}
+					]
+				}`))
+			})
+
+			It("returns error if listing blobs fails", func() {
+				inventory.BlobsReturns(nil, errors.New("fake-err"))
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+			})
+
+			It("returns error if finding package digest fails", func() {
+				inventory.PackageDigestReturns("", errors.New("fake-err"))
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+			})
+		})
+
+		It("returns error if format is not known", func() {
+			opts = SbomOpts{Args: SbomArgs{Release: "/rel.tgz"}, Format: "xml"}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected SBOM format 'xml' to be either 'spdx-json' or 'cyclonedx-json'"))
+		})
+
+		It("returns error if digest algorithm is not known", func() {
+			opts = SbomOpts{Args: SbomArgs{Release: "/rel.tgz"}, Format: "spdx-json"}
+
+			release.PackagesReturns([]*boshpkg.Package{
+				boshpkg.NewPackage(boshres.NewResourceWithBuiltArchive("app", "app-fp", "/app.tgz", "md5:app-md5"), nil),
+			})
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Parsing digest of package 'app'"))
+		})
+
+		It("returns error if reading release fails", func() {
+			opts = SbomOpts{Args: SbomArgs{Release: "/rel.tgz"}, Format: "spdx-json"}
+
+			releaseReader.ReadReturns(nil, errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+			Expect(err.Error()).To(ContainSubstring("Reading release '/rel.tgz'"))
+		})
+	})
+})
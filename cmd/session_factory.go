@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"code.cloudfoundry.org/clock"
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 	boshsys "github.com/cloudfoundry/bosh-utils/system"
 
@@ -11,13 +12,24 @@ import (
 func NewSessionFromOpts(
 	opts BoshOpts,
 	config cmdconf.Config,
-	ui boshui.UI,
+	ui *boshui.ConfUI,
 	printEnvironment bool,
 	printDeployment bool,
 	fs boshsys.FileSystem,
+	timeService clock.Clock,
 	logger boshlog.Logger,
 ) Session {
 	context := NewSessionContextImpl(opts, config, fs)
 
-	return NewSessionImpl(context, ui, printEnvironment, printDeployment, logger)
+	taskReporter := NewTaskReporter(opts, ui, true, logger)
+
+	// Record consumed task output so that it can be continued with 'bosh task --resume'
+	taskOffsets, err := NewTaskOffsetsFromOpts(opts, fs, timeService)
+	if err != nil {
+		logger.Error("session", "Loading task offsets: %s", err)
+	} else {
+		taskReporter = NewTaskOffsetReporter(taskReporter, taskOffsets, context.Environment(), timeService, logger)
+	}
+
+	return NewSessionImpl(context, ui, taskReporter, printEnvironment, printDeployment, logger)
 }
@@ -13,6 +13,7 @@ import (
 	fakecmd "github.com/cloudfoundry/bosh-cli/cmd/cmdfakes"
 	cmdconf "github.com/cloudfoundry/bosh-cli/cmd/config"
 	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshuit "github.com/cloudfoundry/bosh-cli/ui/task"
 )
 
 var _ = Describe("SessionImpl", func() {
@@ -31,7 +32,7 @@ var _ = Describe("SessionImpl", func() {
 		printEnvironment = false
 		printDeployment = false
 		logger = boshlog.NewLogger(boshlog.LevelNone)
-		sess = NewSessionImpl(context, ui, printEnvironment, printDeployment, logger)
+		sess = NewSessionImpl(context, ui, boshuit.NewReporter(ui, true), printEnvironment, printDeployment, logger)
 	})
 
 	Describe("UAA", func() {
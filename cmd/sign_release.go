@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	boshrelsig "github.com/cloudfoundry/bosh-cli/release/signature"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+type SignReleaseCmd struct {
+	releaseReader boshrel.Reader
+	fs            boshsys.FileSystem
+	ui            boshui.UI
+}
+
+func NewSignReleaseCmd(releaseReader boshrel.Reader, fs boshsys.FileSystem, ui boshui.UI) SignReleaseCmd {
+	return SignReleaseCmd{releaseReader: releaseReader, fs: fs, ui: ui}
+}
+
+func (c SignReleaseCmd) Run(opts SignReleaseOpts) error {
+	path := opts.Args.Path
+
+	release, err := c.releaseReader.Read(path)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Reading release '%s'", path)
+	}
+
+	defer release.CleanUp()
+
+	// Avoid vouching for contents that were already tampered with
+	err = boshrelsig.VerifyArchives(release, c.fs)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Verifying release '%s' contents", path)
+	}
+
+	payload, err := boshrelsig.Payload(release)
+	if err != nil {
+		return err
+	}
+
+	sig, err := boshrelsig.Sign(payload, opts.PrivateKey.Bytes)
+	if err != nil {
+		return err
+	}
+
+	sigPath := boshrelsig.Path(path)
+
+	err = sig.Write(sigPath, c.fs)
+	if err != nil {
+		return err
+	}
+
+	c.ui.PrintLinef("Signed release '%s/%s' using %s into '%s'", release.Name(), release.Version(), sig.Algorithm, sigPath)
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package cmd_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+	boshman "github.com/cloudfoundry/bosh-cli/release/manifest"
+	fakerel "github.com/cloudfoundry/bosh-cli/release/releasefakes"
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
+	boshrelsig "github.com/cloudfoundry/bosh-cli/release/signature"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+)
+
+var _ = Describe("SignReleaseCmd", func() {
+	var (
+		releaseReader *fakerel.FakeReader
+		fs            *fakesys.FakeFileSystem
+		ui            *fakeui.FakeUI
+		command       SignReleaseCmd
+	)
+
+	BeforeEach(func() {
+		releaseReader = &fakerel.FakeReader{}
+		fs = fakesys.NewFakeFileSystem()
+		ui = &fakeui.FakeUI{}
+		command = NewSignReleaseCmd(releaseReader, fs, ui)
+	})
+
+	Describe("Run", func() {
+		var (
+			opts      SignReleaseOpts
+			release   *fakerel.FakeRelease
+			publicKey *ecdsa.PublicKey
+		)
+
+		BeforeEach(func() {
+			priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+
+			privBytes, err := x509.MarshalECPrivateKey(priv)
+			Expect(err).ToNot(HaveOccurred())
+
+			publicKey = &priv.PublicKey
+
+			opts = SignReleaseOpts{
+				Args:       SignReleaseArgs{Path: "/rel.tgz"},
+				PrivateKey: FileBytesArg{Bytes: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: privBytes})},
+			}
+
+			fs.WriteFileString("/extracted/web.tgz", "web")
+
+			webSHA1 := fmt.Sprintf("%x", sha1.Sum([]byte("web")))
+
+			release = &fakerel.FakeRelease{}
+			release.NameReturns("rel")
+			release.VersionReturns("1")
+			release.JobsReturns([]*boshjob.Job{
+				boshjob.NewJob(boshres.NewResourceWithBuiltArchive("web", "web-fp", "/extracted/web.tgz", webSHA1)),
+			})
+			release.ManifestReturns(boshman.Manifest{
+				Name:    "rel",
+				Version: "1",
+				Jobs:    []boshman.JobRef{{Name: "web", Fingerprint: "web-fp", SHA1: webSHA1}},
+			})
+
+			releaseReader.ReadReturns(release, nil)
+		})
+
+		act := func() error { return command.Run(opts) }
+
+		It("writes signature of release manifest next to release tarball", func() {
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(releaseReader.ReadArgsForCall(0)).To(Equal("/rel.tgz"))
+
+			sig, err := boshrelsig.Read("/rel.tgz.sig", fs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sig.Algorithm).To(Equal("ecdsa-sha256"))
+
+			payload, err := boshrelsig.Payload(release)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sig.Verify(payload, publicKey)).To(BeTrue())
+
+			Expect(ui.Said).To(Equal([]string{"Signed release 'rel/1' using ecdsa-sha256 into '/rel.tgz.sig'"}))
+
+			Expect(release.CleanUpCallCount()).To(Equal(1))
+		})
+
+		It("returns error and does not sign if release contents do not match digests", func() {
+			fs.WriteFileString("/extracted/web.tgz", "tampered")
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Verifying release '/rel.tgz' contents"))
+
+			Expect(fs.FileExists("/rel.tgz.sig")).To(BeFalse())
+		})
+
+		It("returns error if private key is invalid", func() {
+			opts.PrivateKey = FileBytesArg{Bytes: []byte("invalid")}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected private key to be PEM encoded"))
+
+			Expect(fs.FileExists("/rel.tgz.sig")).To(BeFalse())
+		})
+
+		It("returns error if reading release fails", func() {
+			releaseReader.ReadReturns(nil, errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+})
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"github.com/cppforlife/go-patch/patch"
+	"gopkg.in/yaml.v2"
+)
+
+type StrictOps struct {
+	OpsFiles []OpsFileArg
+}
+
+type OpResult struct {
+	FilePath string
+	Index    int
+	Op       patch.Op
+
+	// Err is set when operation did not apply or did not change the document
+	Err error
+}
+
+var _ patch.Op = StrictOps{}
+
+func (o StrictOps) Apply(doc interface{}) (interface{}, error) {
+	doc, results, err := o.ApplyWithResults(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, bosherr.WrapError(bosherr.NewMultiError(errs...), "Validating ops files")
+	}
+
+	return doc, nil
+}
+
+// ApplyWithResults applies all operations that can be applied and
+// records for each operation whether it changed the document
+func (o StrictOps) ApplyWithResults(doc interface{}) (interface{}, []OpResult, error) {
+	var results []OpResult
+
+	for _, opsFile := range o.OpsFiles {
+		for i, op := range opsFile.Ops {
+			result := OpResult{FilePath: opsFile.FilePath, Index: i, Op: op}
+
+			// Operations may modify document in place hence apply to a copy
+			docCopy, err := copyDocument(doc)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			newDoc, err := op.Apply(docCopy)
+			if err != nil {
+				result.Err = err
+			} else if reflect.DeepEqual(doc, newDoc) {
+				result.Err = bosherr.Error("Expected operation to change document")
+			} else {
+				doc = newDoc
+			}
+
+			results = append(results, result)
+		}
+	}
+
+	return doc, results, nil
+}
+
+func copyDocument(doc interface{}) (interface{}, error) {
+	bytes, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Serializing document")
+	}
+
+	var docCopy interface{}
+
+	err = yaml.Unmarshal(bytes, &docCopy)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Deserializing document")
+	}
+
+	return docCopy, nil
+}
+
+func (r OpResult) Description() string {
+	return opDescription(r.Op)
+}
+
+func (r OpResult) Error() error {
+	if r.Err == nil {
+		return nil
+	}
+
+	return bosherr.WrapErrorf(r.Err, "Ops file '%s' operation [%d] (%s)", r.FilePath, r.Index, r.Description())
+}
+
+func opDescription(op patch.Op) string {
+	switch typedOp := op.(type) {
+	case patch.ReplaceOp:
+		return "replace " + typedOp.Path.String()
+	case patch.RemoveOp:
+		return "remove " + typedOp.Path.String()
+	case patch.DescriptiveOp:
+		return opDescription(typedOp.Op)
+	default:
+		return fmt.Sprintf("%T", op)
+	}
+}
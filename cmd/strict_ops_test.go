@@ -0,0 +1,124 @@
+package cmd_test
+
+import (
+	"github.com/cppforlife/go-patch/patch"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+)
+
+var _ = Describe("StrictOps", func() {
+	var (
+		doc interface{}
+	)
+
+	BeforeEach(func() {
+		doc = map[interface{}]interface{}{
+			"name": "dep",
+			"list": []interface{}{"a"},
+		}
+	})
+
+	Describe("ApplyWithResults", func() {
+		It("applies operations and reports results per file and operation", func() {
+			ops := StrictOps{
+				OpsFiles: []OpsFileArg{
+					{
+						FilePath: "/ops1.yml",
+						Ops: patch.Ops{
+							patch.ReplaceOp{Path: patch.MustNewPointerFromString("/name"), Value: "new-dep"},
+							patch.ReplaceOp{Path: patch.MustNewPointerFromString("/missing/key"), Value: "val"},
+						},
+					},
+					{
+						FilePath: "/ops2.yml",
+						Ops: patch.Ops{
+							patch.RemoveOp{Path: patch.MustNewPointerFromString("/missing?")},
+							patch.ReplaceOp{Path: patch.MustNewPointerFromString("/list/-"), Value: "b"},
+							patch.DescriptiveOp{
+								Op:       patch.ReplaceOp{Path: patch.MustNewPointerFromString("/name"), Value: "new-dep"},
+								ErrorMsg: "msg",
+							},
+						},
+					},
+				},
+			}
+
+			newDoc, results, err := ops.ApplyWithResults(doc)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(newDoc).To(Equal(map[interface{}]interface{}{
+				"name": "new-dep",
+				"list": []interface{}{"a", "b"},
+			}))
+
+			Expect(results).To(HaveLen(5))
+
+			Expect(results[0].FilePath).To(Equal("/ops1.yml"))
+			Expect(results[0].Index).To(Equal(0))
+			Expect(results[0].Description()).To(Equal("replace /name"))
+			Expect(results[0].Err).ToNot(HaveOccurred())
+
+			Expect(results[1].Index).To(Equal(1))
+			Expect(results[1].Err).To(HaveOccurred())
+			Expect(results[1].Err.Error()).To(ContainSubstring("Expected to find a map key 'missing'"))
+
+			Expect(results[2].FilePath).To(Equal("/ops2.yml"))
+			Expect(results[2].Index).To(Equal(0))
+			Expect(results[2].Description()).To(Equal("remove /missing?"))
+			Expect(results[2].Err).To(HaveOccurred())
+			Expect(results[2].Err.Error()).To(Equal("Expected operation to change document"))
+
+			Expect(results[3].Err).ToNot(HaveOccurred())
+
+			Expect(results[4].Description()).To(Equal("replace /name"))
+			Expect(results[4].Err).To(HaveOccurred())
+			Expect(results[4].Err.Error()).To(Equal("Expected operation to change document"))
+		})
+
+		It("does not modify original document", func() {
+			ops := StrictOps{
+				OpsFiles: []OpsFileArg{{
+					Ops: patch.Ops{patch.ReplaceOp{Path: patch.MustNewPointerFromString("/list/0"), Value: "z"}},
+				}},
+			}
+
+			_, _, err := ops.ApplyWithResults(doc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(doc.(map[interface{}]interface{})["list"]).To(Equal([]interface{}{"a"}))
+		})
+	})
+
+	Describe("Apply", func() {
+		It("returns document if all operations change it", func() {
+			ops := StrictOps{
+				OpsFiles: []OpsFileArg{{
+					Ops: patch.Ops{patch.ReplaceOp{Path: patch.MustNewPointerFromString("/name"), Value: "new-dep"}},
+				}},
+			}
+
+			newDoc, err := ops.Apply(doc)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(newDoc.(map[interface{}]interface{})["name"]).To(Equal("new-dep"))
+		})
+
+		It("returns an error listing all operations that did not apply or change document", func() {
+			ops := StrictOps{
+				OpsFiles: []OpsFileArg{{
+					FilePath: "/ops.yml",
+					Ops: patch.Ops{
+						patch.RemoveOp{Path: patch.MustNewPointerFromString("/missing?")},
+						patch.ReplaceOp{Path: patch.MustNewPointerFromString("/name"), Value: "dep"},
+					},
+				}},
+			}
+
+			_, err := ops.Apply(doc)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(`Validating ops files: ` +
+				`Ops file '/ops.yml' operation [0] (remove /missing?): Expected operation to change document
+Ops file '/ops.yml' operation [1] (replace /name): Expected operation to change document`))
+		})
+	})
+})
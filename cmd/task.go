@@ -3,6 +3,10 @@ package cmd
 import (
 	"errors"
 
+	"code.cloudfoundry.org/clock"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+
+	cmdconf "github.com/cloudfoundry/bosh-cli/cmd/config"
 	boshdir "github.com/cloudfoundry/bosh-cli/director"
 	boshuit "github.com/cloudfoundry/bosh-cli/ui/task"
 )
@@ -10,18 +14,30 @@ import (
 type TaskCmd struct {
 	eventsTaskReporter boshuit.Reporter
 	plainTaskReporter  boshuit.Reporter
+	taskOffsets        cmdconf.TaskOffsets
+	environment        string
 	director           boshdir.Director
+	timeService        clock.Clock
+	logger             boshlog.Logger
 }
 
 func NewTaskCmd(
 	eventsTaskReporter boshuit.Reporter,
 	plainTaskReporter boshuit.Reporter,
+	taskOffsets cmdconf.TaskOffsets,
+	environment string,
 	director boshdir.Director,
+	timeService clock.Clock,
+	logger boshlog.Logger,
 ) TaskCmd {
 	return TaskCmd{
 		eventsTaskReporter: eventsTaskReporter,
 		plainTaskReporter:  plainTaskReporter,
+		taskOffsets:        taskOffsets,
+		environment:        environment,
 		director:           director,
+		timeService:        timeService,
+		logger:             logger,
 	}
 }
 
@@ -30,6 +46,10 @@ func (c TaskCmd) Run(opts TaskOpts) error {
 
 	var err error
 
+	if opts.Resume && (opts.CPI || opts.Debug || opts.Result) {
+		return errors.New("Expected --resume to be used only with event log")
+	}
+
 	if opts.Args.ID == 0 {
 		filter := boshdir.TasksFilter{
 			All:        opts.All,
@@ -54,7 +74,7 @@ func (c TaskCmd) Run(opts TaskOpts) error {
 
 	switch {
 	case opts.Event:
-		err = task.EventOutput(c.plainTaskReporter)
+		err = c.eventOutput(task, c.plainTaskReporter, opts.Resume)
 	case opts.CPI:
 		err = task.CPIOutput(c.plainTaskReporter)
 	case opts.Debug:
@@ -62,8 +82,30 @@ func (c TaskCmd) Run(opts TaskOpts) error {
 	case opts.Result:
 		err = task.ResultOutput(c.plainTaskReporter)
 	default:
-		err = task.EventOutput(c.eventsTaskReporter)
+		err = c.eventOutput(task, c.eventsTaskReporter, opts.Resume)
 	}
 
 	return err
 }
+
+func (c TaskCmd) eventOutput(task boshdir.Task, reporter boshuit.Reporter, resume bool) error {
+	if c.taskOffsets == nil {
+		if resume {
+			return errors.New("Expected task offsets to be available to resume")
+		}
+
+		return task.EventOutput(reporter)
+	}
+
+	offsetReporter := NewTaskOffsetReporter(reporter, c.taskOffsets, c.environment, c.timeService, c.logger)
+
+	if !resume {
+		return task.EventOutput(offsetReporter)
+	}
+
+	offset := c.taskOffsets.Offset(c.environment, task.ID())
+
+	offsetReporter.ResumeFrom(task.ID(), offset)
+
+	return task.EventOutputFromOffset(offset, offsetReporter)
+}
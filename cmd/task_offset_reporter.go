@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	cmdconf "github.com/cloudfoundry/bosh-cli/cmd/config"
+	boshuit "github.com/cloudfoundry/bosh-cli/ui/task"
+)
+
+// Offsets are saved at most this often while output is consumed
+// since every save rewrites the whole offsets file
+const taskOffsetSaveInterval = 5 * time.Second
+
+// TaskOffsetReporter records how much of task event output was consumed
+// so that following task can be resumed after client got disconnected
+type TaskOffsetReporter struct {
+	reporter    boshuit.Reporter
+	offsets     cmdconf.TaskOffsets
+	environment string
+	timeService clock.Clock
+
+	// current offsets point right after last complete line since wrapped
+	// reporter cannot resume parsing events from the middle of a line
+	current     map[int]int
+	partial     map[int]int
+	savedAt     map[int]time.Time
+	currentLock sync.Mutex
+
+	logTag string
+	logger boshlog.Logger
+}
+
+func NewTaskOffsetReporter(
+	reporter boshuit.Reporter,
+	offsets cmdconf.TaskOffsets,
+	environment string,
+	timeService clock.Clock,
+	logger boshlog.Logger,
+) *TaskOffsetReporter {
+	return &TaskOffsetReporter{
+		reporter:    reporter,
+		offsets:     offsets,
+		environment: environment,
+		timeService: timeService,
+
+		current: map[int]int{},
+		partial: map[int]int{},
+		savedAt: map[int]time.Time{},
+
+		logTag: "TaskOffsetReporter",
+		logger: logger,
+	}
+}
+
+// ResumeFrom indicates that output for task is consumed starting at offset
+func (r *TaskOffsetReporter) ResumeFrom(id, offset int) {
+	r.currentLock.Lock()
+	defer r.currentLock.Unlock()
+
+	r.current[id] = offset
+	r.partial[id] = 0
+}
+
+func (r *TaskOffsetReporter) TaskStarted(id int) {
+	r.currentLock.Lock()
+	r.setOffset(id, r.current[id])
+	r.currentLock.Unlock()
+
+	r.reporter.TaskStarted(id)
+}
+
+func (r *TaskOffsetReporter) TaskFinished(id int, state string) {
+	r.reporter.TaskFinished(id, state)
+
+	r.currentLock.Lock()
+	defer r.currentLock.Unlock()
+
+	// Keep offset if task was still running when client stopped following it
+	if state == "" || state == "queued" || state == "processing" || state == "cancelling" {
+		r.setOffset(id, r.current[id])
+		return
+	}
+
+	delete(r.current, id)
+	delete(r.partial, id)
+	delete(r.savedAt, id)
+
+	err := r.offsets.UnsetOffset(r.environment, id)
+	if err != nil {
+		r.logger.Error(r.logTag, "Unsetting task '%d' offset: %s", id, err)
+	}
+}
+
+func (r *TaskOffsetReporter) TaskOutputChunk(id int, chunk []byte) {
+	r.reporter.TaskOutputChunk(id, chunk)
+
+	r.currentLock.Lock()
+	defer r.currentLock.Unlock()
+
+	lastNewline := bytes.LastIndexByte(chunk, '\n')
+	if lastNewline < 0 {
+		r.partial[id] += len(chunk)
+		return
+	}
+
+	r.current[id] += r.partial[id] + lastNewline + 1
+	r.partial[id] = len(chunk) - lastNewline - 1
+
+	if r.timeService.Since(r.savedAt[id]) >= taskOffsetSaveInterval {
+		r.setOffset(id, r.current[id])
+	}
+}
+
+func (r *TaskOffsetReporter) setOffset(id, offset int) {
+	r.savedAt[id] = r.timeService.Now()
+
+	err := r.offsets.SetOffset(r.environment, id, offset)
+	if err != nil {
+		r.logger.Error(r.logTag, "Saving task '%d' offset: %s", id, err)
+	}
+}
+
+// NewTaskOffsetsFromOpts keeps task offsets next to CLI config
+func NewTaskOffsetsFromOpts(opts BoshOpts, fs boshsys.FileSystem, timeService clock.Clock) (*cmdconf.FSTaskOffsets, error) {
+	path := filepath.Join(filepath.Dir(opts.ConfigPathOpt), "task_offsets")
+
+	return cmdconf.NewFSTaskOffsetsFromPath(path, fs, timeService)
+}
@@ -0,0 +1,154 @@
+package cmd_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	fakecmdconf "github.com/cloudfoundry/bosh-cli/cmd/config/configfakes"
+	fakedir "github.com/cloudfoundry/bosh-cli/director/directorfakes"
+)
+
+var _ = Describe("TaskOffsetReporter", func() {
+	var (
+		reporter    *fakedir.FakeTaskReporter
+		offsets     *fakecmdconf.FakeTaskOffsets
+		timeService *fakeclock.FakeClock
+		offsetRep   *TaskOffsetReporter
+	)
+
+	BeforeEach(func() {
+		reporter = &fakedir.FakeTaskReporter{}
+		offsets = &fakecmdconf.FakeTaskOffsets{}
+		timeService = fakeclock.NewFakeClock(time.Now())
+		offsetRep = NewTaskOffsetReporter(reporter, offsets, "env", timeService, boshlog.NewLogger(boshlog.LevelNone))
+	})
+
+	It("forwards events to wrapped reporter", func() {
+		offsetRep.TaskStarted(123)
+		offsetRep.TaskOutputChunk(123, []byte("chunk"))
+		offsetRep.TaskFinished(123, "done")
+
+		Expect(reporter.TaskStartedArgsForCall(0)).To(Equal(123))
+
+		id, chunk := reporter.TaskOutputChunkArgsForCall(0)
+		Expect(id).To(Equal(123))
+		Expect(chunk).To(Equal([]byte("chunk")))
+
+		id, state := reporter.TaskFinishedArgsForCall(0)
+		Expect(id).To(Equal(123))
+		Expect(state).To(Equal("done"))
+	})
+
+	It("saves offset of consumed output", func() {
+		offsetRep.TaskStarted(123)
+		timeService.Increment(5 * time.Second)
+		offsetRep.TaskOutputChunk(123, []byte("line1\n"))
+		timeService.Increment(5 * time.Second)
+		offsetRep.TaskOutputChunk(123, []byte("line12\n"))
+
+		Expect(offsets.SetOffsetCallCount()).To(Equal(3))
+
+		url, id, offset := offsets.SetOffsetArgsForCall(0)
+		Expect(url).To(Equal("env"))
+		Expect(id).To(Equal(123))
+		Expect(offset).To(Equal(0))
+
+		_, _, offset = offsets.SetOffsetArgsForCall(2)
+		Expect(offset).To(Equal(13))
+	})
+
+	It("saves offset at most every 5 seconds while output is consumed", func() {
+		offsetRep.TaskStarted(123)
+		offsetRep.TaskOutputChunk(123, []byte("line1\n"))
+		offsetRep.TaskOutputChunk(123, []byte("line2\n"))
+
+		Expect(offsets.SetOffsetCallCount()).To(Equal(1))
+
+		timeService.Increment(5 * time.Second)
+		offsetRep.TaskOutputChunk(123, []byte("line3\n"))
+		offsetRep.TaskOutputChunk(123, []byte("line4\n"))
+
+		Expect(offsets.SetOffsetCallCount()).To(Equal(2))
+
+		_, _, offset := offsets.SetOffsetArgsForCall(1)
+		Expect(offset).To(Equal(18))
+
+		offsetRep.TaskFinished(123, "processing")
+
+		Expect(offsets.SetOffsetCallCount()).To(Equal(3))
+
+		_, _, offset = offsets.SetOffsetArgsForCall(2)
+		Expect(offset).To(Equal(24))
+	})
+
+	It("saves offset only up to last complete line", func() {
+		offsetRep.TaskStarted(123)
+		timeService.Increment(5 * time.Second)
+		offsetRep.TaskOutputChunk(123, []byte("line1\npart"))
+		timeService.Increment(5 * time.Second)
+		offsetRep.TaskOutputChunk(123, []byte("ial"))
+		offsetRep.TaskOutputChunk(123, []byte("-line2\nline3"))
+
+		Expect(offsets.SetOffsetCallCount()).To(Equal(3))
+
+		_, _, offset := offsets.SetOffsetArgsForCall(1)
+		Expect(offset).To(Equal(6))
+
+		_, _, offset = offsets.SetOffsetArgsForCall(2)
+		Expect(offset).To(Equal(20))
+
+		offsetRep.TaskFinished(123, "processing")
+
+		Expect(offsets.SetOffsetCallCount()).To(Equal(4))
+
+		_, _, offset = offsets.SetOffsetArgsForCall(3)
+		Expect(offset).To(Equal(20))
+	})
+
+	It("saves offset relative to resumed offset", func() {
+		offsetRep.ResumeFrom(123, 100)
+		offsetRep.TaskStarted(123)
+		timeService.Increment(5 * time.Second)
+		offsetRep.TaskOutputChunk(123, []byte("line\n"))
+
+		_, _, offset := offsets.SetOffsetArgsForCall(0)
+		Expect(offset).To(Equal(100))
+
+		_, _, offset = offsets.SetOffsetArgsForCall(1)
+		Expect(offset).To(Equal(105))
+	})
+
+	It("removes offset when task finishes", func() {
+		offsetRep.TaskStarted(123)
+		offsetRep.TaskFinished(123, "error")
+
+		Expect(offsets.UnsetOffsetCallCount()).To(Equal(1))
+
+		url, id := offsets.UnsetOffsetArgsForCall(0)
+		Expect(url).To(Equal("env"))
+		Expect(id).To(Equal(123))
+	})
+
+	It("keeps offset when client stops following running task", func() {
+		offsetRep.TaskStarted(123)
+		offsetRep.TaskFinished(123, "processing")
+		offsetRep.TaskFinished(123, "")
+
+		Expect(offsets.UnsetOffsetCallCount()).To(Equal(0))
+	})
+
+	It("continues reporting if offset cannot be saved", func() {
+		offsets.SetOffsetReturns(errors.New("fake-err"))
+
+		offsetRep.TaskStarted(123)
+		offsetRep.TaskOutputChunk(123, []byte("chunk"))
+
+		Expect(reporter.TaskOutputChunkCallCount()).To(Equal(1))
+	})
+})
@@ -0,0 +1,18 @@
+package cmd
+
+import (
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshuit "github.com/cloudfoundry/bosh-cli/ui/task"
+)
+
+// NewTaskReporter returns reporter that streams task events as NDJSON
+// through the UI when requested instead of rendering them as text
+func NewTaskReporter(opts BoshOpts, ui *boshui.ConfUI, isForEvents bool, logger boshlog.Logger) boshuit.Reporter {
+	if opts.NDJSONOpt {
+		return boshuit.NewNDJSONReporter(ui.BlockWriter(), isForEvents, logger)
+	}
+
+	return boshuit.NewReporter(ui, isForEvents)
+}
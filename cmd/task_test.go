@@ -2,28 +2,38 @@ package cmd_test
 
 import (
 	"errors"
+	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	. "github.com/cloudfoundry/bosh-cli/cmd"
+	fakecmdconf "github.com/cloudfoundry/bosh-cli/cmd/config/configfakes"
 	boshdir "github.com/cloudfoundry/bosh-cli/director"
 	fakedir "github.com/cloudfoundry/bosh-cli/director/directorfakes"
 )
 
 var _ = Describe("TaskCmd", func() {
 	var (
-		eventsRep *fakedir.FakeTaskReporter
-		plainRep  *fakedir.FakeTaskReporter
-		director  *fakedir.FakeDirector
-		command   TaskCmd
+		eventsRep   *fakedir.FakeTaskReporter
+		plainRep    *fakedir.FakeTaskReporter
+		taskOffsets *fakecmdconf.FakeTaskOffsets
+		director    *fakedir.FakeDirector
+		timeService *fakeclock.FakeClock
+		logger      boshlog.Logger
+		command     TaskCmd
 	)
 
 	BeforeEach(func() {
 		eventsRep = &fakedir.FakeTaskReporter{}
 		plainRep = &fakedir.FakeTaskReporter{}
+		taskOffsets = &fakecmdconf.FakeTaskOffsets{}
 		director = &fakedir.FakeDirector{}
-		command = NewTaskCmd(eventsRep, plainRep, director)
+		timeService = fakeclock.NewFakeClock(time.Now())
+		logger = boshlog.NewLogger(boshlog.LevelNone)
+		command = NewTaskCmd(eventsRep, plainRep, taskOffsets, "env", director, timeService, logger)
 	})
 
 	Describe("Run", func() {
@@ -56,7 +66,7 @@ var _ = Describe("TaskCmd", func() {
 			It("shows task's 'event' output", func() {
 				err := act()
 				Expect(err).ToNot(HaveOccurred())
-				Expect(task.EventOutputArgsForCall(0)).To(Equal(eventsRep))
+				Expect(task.EventOutputArgsForCall(0)).To(Equal(NewTaskOffsetReporter(eventsRep, taskOffsets, "env", timeService, logger)))
 
 				task.EventOutputStub = func(boshdir.TaskReporter) error { return errors.New("fake-err") }
 
@@ -65,12 +75,26 @@ var _ = Describe("TaskCmd", func() {
 				Expect(err.Error()).To(ContainSubstring("fake-err"))
 			})
 
+			It("shows task's 'event' output without recording offsets if they are not available", func() {
+				command = NewTaskCmd(eventsRep, plainRep, nil, "env", director, timeService, logger)
+
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(task.EventOutputArgsForCall(0)).To(Equal(eventsRep))
+
+				opts.Resume = true
+
+				err = act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Expected task offsets to be available to resume"))
+			})
+
 			It("shows task's 'event' output if requested", func() {
 				opts.Event = true
 
 				err := act()
 				Expect(err).ToNot(HaveOccurred())
-				Expect(task.EventOutputArgsForCall(0)).To(Equal(plainRep))
+				Expect(task.EventOutputArgsForCall(0)).To(Equal(NewTaskOffsetReporter(plainRep, taskOffsets, "env", timeService, logger)))
 
 				task.EventOutputStub = func(boshdir.TaskReporter) error { return errors.New("fake-err") }
 
@@ -121,6 +145,43 @@ var _ = Describe("TaskCmd", func() {
 				Expect(err.Error()).To(ContainSubstring("fake-err"))
 			})
 
+			It("continues task's 'event' output from saved offset if resuming", func() {
+				opts.Resume = true
+				task.IDStub = func() int { return 123 }
+				taskOffsets.OffsetReturns(42)
+
+				err := act()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(task.EventOutputCallCount()).To(Equal(0))
+
+				url, id := taskOffsets.OffsetArgsForCall(0)
+				Expect(url).To(Equal("env"))
+				Expect(id).To(Equal(123))
+
+				offset, reporter := task.EventOutputFromOffsetArgsForCall(0)
+				Expect(offset).To(Equal(42))
+
+				expectedRep := NewTaskOffsetReporter(eventsRep, taskOffsets, "env", timeService, logger)
+				expectedRep.ResumeFrom(123, 42)
+				Expect(reporter).To(Equal(expectedRep))
+
+				task.EventOutputFromOffsetStub = func(int, boshdir.TaskReporter) error { return errors.New("fake-err") }
+
+				err = act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("fake-err"))
+			})
+
+			It("returns error if resuming output other than 'event'", func() {
+				opts.Resume = true
+				opts.Debug = true
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Expected --resume to be used only with event log"))
+				Expect(task.DebugOutputCallCount()).To(Equal(0))
+			})
+
 			It("returns error if task cannot be retrieved", func() {
 				director.FindTaskReturns(nil, errors.New("fake-err"))
 
@@ -146,7 +207,7 @@ var _ = Describe("TaskCmd", func() {
 
 				err := act()
 				Expect(err).ToNot(HaveOccurred())
-				Expect(task.EventOutputArgsForCall(0)).To(Equal(eventsRep))
+				Expect(task.EventOutputArgsForCall(0)).To(Equal(NewTaskOffsetReporter(eventsRep, taskOffsets, "env", timeService, logger)))
 
 				task.EventOutputStub = func(boshdir.TaskReporter) error { return errors.New("fake-err") }
 
@@ -160,7 +221,7 @@ var _ = Describe("TaskCmd", func() {
 
 				err := act()
 				Expect(err).ToNot(HaveOccurred())
-				Expect(task.EventOutputArgsForCall(0)).To(Equal(plainRep))
+				Expect(task.EventOutputArgsForCall(0)).To(Equal(NewTaskOffsetReporter(plainRep, taskOffsets, "env", timeService, logger)))
 
 				task.EventOutputStub = func(boshdir.TaskReporter) error { return errors.New("fake-err") }
 
@@ -46,6 +46,10 @@ func NewUploadReleaseCmd(
 }
 
 func (c UploadReleaseCmd) Run(opts UploadReleaseOpts) error {
+	if opts.VerifySignature && (opts.Release != nil || opts.Args.URL.IsRemote() || opts.Args.URL.IsGit() || len(opts.Args.URL.FilePath()) == 0) {
+		return bosherr.Error("Expected local release tarball to be given when verifying release signature")
+	}
+
 	switch {
 	case opts.Release != nil:
 		return c.uploadRelease(opts.Release, opts)
@@ -104,6 +104,16 @@ var _ = Describe("UploadReleaseCmd", func() {
 				Expect(director.UploadReleaseURLCallCount()).To(Equal(1))
 			})
 
+			It("returns error if release signature is expected to be verified", func() {
+				opts.VerifySignature = true
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Expected local release tarball to be given when verifying release signature"))
+
+				Expect(director.UploadReleaseURLCallCount()).To(Equal(0))
+			})
+
 			It("uploads given release with a fix flag without checking if release exists", func() {
 				opts.Fix = true
 
@@ -583,6 +593,16 @@ var _ = Describe("UploadReleaseCmd", func() {
 				Expect(fix).To(BeTrue())
 			})
 
+			It("returns error if release signature is expected to be verified", func() {
+				opts.VerifySignature = true
+
+				err := act()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("Expected local release tarball to be given when verifying release signature"))
+
+				Expect(releaseDir.FindReleaseCallCount()).To(Equal(0))
+			})
+
 			It("returns error if finding release fails", func() {
 				releaseDir.FindReleaseReturns(nil, errors.New("fake-err"))
 
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"gopkg.in/yaml.v2"
+
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+type ValidateOpsCmd struct {
+	ui boshui.UI
+}
+
+func NewValidateOpsCmd(ui boshui.UI) ValidateOpsCmd {
+	return ValidateOpsCmd{ui: ui}
+}
+
+func (c ValidateOpsCmd) Run(opts ValidateOpsOpts) error {
+	var doc interface{}
+
+	err := yaml.Unmarshal(opts.Args.Manifest.Bytes, &doc)
+	if err != nil {
+		return bosherr.WrapError(err, "Deserializing manifest")
+	}
+
+	_, results, err := StrictOps{OpsFiles: opts.OpsFiles}.ApplyWithResults(doc)
+	if err != nil {
+		return err
+	}
+
+	table := boshtbl.Table{
+		Content: "operations",
+
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("File"),
+			boshtbl.NewHeader("Index"),
+			boshtbl.NewHeader("Operation"),
+			boshtbl.NewHeader("Result"),
+		},
+	}
+
+	var failed int
+
+	for _, result := range results {
+		resultVal := boshtbl.NewValueFmt(boshtbl.NewValueString("ok"), false)
+
+		if result.Err != nil {
+			failed++
+			resultVal = boshtbl.NewValueFmt(boshtbl.NewValueString(result.Err.Error()), true)
+		}
+
+		table.Rows = append(table.Rows, []boshtbl.Value{
+			boshtbl.NewValueString(result.FilePath),
+			boshtbl.NewValueInt(result.Index),
+			boshtbl.NewValueString(result.Description()),
+			resultVal,
+		})
+	}
+
+	c.ui.PrintTable(table)
+
+	if failed > 0 {
+		return bosherr.Errorf("Expected all operations to apply and change manifest, but %d did not", failed)
+	}
+
+	return nil
+}
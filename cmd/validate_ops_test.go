@@ -0,0 +1,106 @@
+package cmd_test
+
+import (
+	"github.com/cppforlife/go-patch/patch"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("ValidateOpsCmd", func() {
+	var (
+		ui      *fakeui.FakeUI
+		command ValidateOpsCmd
+	)
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		command = NewValidateOpsCmd(ui)
+	})
+
+	Describe("Run", func() {
+		var (
+			opts ValidateOpsOpts
+		)
+
+		BeforeEach(func() {
+			opts = ValidateOpsOpts{
+				Args: ValidateOpsArgs{
+					Manifest: FileBytesArg{Bytes: []byte("name: dep\nkey: ((var))")},
+				},
+			}
+		})
+
+		act := func() error { return command.Run(opts) }
+
+		It("shows results for all operations", func() {
+			opts.OpsFiles = []OpsFileArg{{
+				FilePath: "/ops.yml",
+				Ops: patch.Ops{
+					patch.ReplaceOp{Path: patch.MustNewPointerFromString("/name"), Value: "new-dep"},
+					patch.ReplaceOp{Path: patch.MustNewPointerFromString("/key"), Value: "((other_var))"},
+				},
+			}}
+
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(ui.Table).To(Equal(boshtbl.Table{
+				Content: "operations",
+
+				Header: []boshtbl.Header{
+					boshtbl.NewHeader("File"),
+					boshtbl.NewHeader("Index"),
+					boshtbl.NewHeader("Operation"),
+					boshtbl.NewHeader("Result"),
+				},
+
+				Rows: [][]boshtbl.Value{
+					{
+						boshtbl.NewValueString("/ops.yml"),
+						boshtbl.NewValueInt(0),
+						boshtbl.NewValueString("replace /name"),
+						boshtbl.NewValueFmt(boshtbl.NewValueString("ok"), false),
+					},
+					{
+						boshtbl.NewValueString("/ops.yml"),
+						boshtbl.NewValueInt(1),
+						boshtbl.NewValueString("replace /key"),
+						boshtbl.NewValueFmt(boshtbl.NewValueString("ok"), false),
+					},
+				},
+			}))
+		})
+
+		It("returns an error if some operations did not apply or change manifest", func() {
+			opts.OpsFiles = []OpsFileArg{{
+				FilePath: "/ops.yml",
+				Ops: patch.Ops{
+					patch.ReplaceOp{Path: patch.MustNewPointerFromString("/missing/key"), Value: "val"},
+					patch.ReplaceOp{Path: patch.MustNewPointerFromString("/name"), Value: "dep"},
+				},
+			}}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected all operations to apply and change manifest, but 2 did not"))
+
+			Expect(ui.Table.Rows).To(HaveLen(2))
+			Expect(ui.Table.Rows[0][3].(boshtbl.ValueFmt).Error).To(BeTrue())
+			Expect(ui.Table.Rows[0][3].String()).To(ContainSubstring("Expected to find a map key 'missing'"))
+			Expect(ui.Table.Rows[1][3]).To(Equal(boshtbl.NewValueFmt(
+				boshtbl.NewValueString("Expected operation to change document"), true)))
+		})
+
+		It("returns an error if manifest cannot be deserialized", func() {
+			opts.Args.Manifest = FileBytesArg{Bytes: []byte("key: [")}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Deserializing manifest"))
+		})
+	})
+})
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	"gopkg.in/yaml.v2"
+
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+	boshjobman "github.com/cloudfoundry/bosh-cli/release/job/manifest"
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
+	boshreldir "github.com/cloudfoundry/bosh-cli/releasedir"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+type ValidatePropertiesCmd struct {
+	releaseReader     boshrel.Reader
+	releaseDirFactory func(string) boshreldir.ReleaseDir
+	fs                boshsys.FileSystem
+	ui                boshui.UI
+}
+
+type validatePropertiesManifest struct {
+	Properties     map[interface{}]interface{}           `yaml:"properties"`
+	InstanceGroups []validatePropertiesManifestInstGroup `yaml:"instance_groups"`
+}
+
+type validatePropertiesManifestInstGroup struct {
+	Name       string                          `yaml:"name"`
+	Jobs       []validatePropertiesManifestJob `yaml:"jobs"`
+	Properties map[interface{}]interface{}     `yaml:"properties"`
+}
+
+type validatePropertiesManifestJob struct {
+	Name       string                       `yaml:"name"`
+	Release    string                       `yaml:"release"`
+	Properties *map[interface{}]interface{} `yaml:"properties"`
+}
+
+func NewValidatePropertiesCmd(
+	releaseReader boshrel.Reader,
+	releaseDirFactory func(string) boshreldir.ReleaseDir,
+	fs boshsys.FileSystem,
+	ui boshui.UI,
+) ValidatePropertiesCmd {
+	return ValidatePropertiesCmd{
+		releaseReader:     releaseReader,
+		releaseDirFactory: releaseDirFactory,
+		fs:                fs,
+		ui:                ui,
+	}
+}
+
+func (c ValidatePropertiesCmd) Run(opts ValidatePropertiesOpts) error {
+	tpl := boshtpl.NewTemplate(opts.Args.Manifest.Bytes)
+
+	bytes, err := tpl.Evaluate(opts.VarFlags.AsVariables(), opts.OpsFlags.AsOp(), boshtpl.EvaluateOpts{})
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Evaluating manifest")
+	}
+
+	var manifest validatePropertiesManifest
+
+	err = yaml.Unmarshal(bytes, &manifest)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Unmarshalling manifest")
+	}
+
+	releaseJobs, err := c.readReleaseJobs(opts.Releases)
+	if err != nil {
+		return err
+	}
+
+	globalProps, err := biproperty.BuildMap(manifest.Properties)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Parsing global properties")
+	}
+
+	table := boshtbl.Table{
+		Content: "property problems",
+		Header: []boshtbl.Header{
+			boshtbl.NewHeader("Instance Group"),
+			boshtbl.NewHeader("Job"),
+			boshtbl.NewHeader("Property"),
+			boshtbl.NewHeader("Problem"),
+		},
+	}
+
+	for _, instGroup := range manifest.InstanceGroups {
+		instGroupProps, err := biproperty.BuildMap(instGroup.Properties)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Parsing instance group '%s' properties", instGroup.Name)
+		}
+
+		var jobs []boshjob.Job
+
+		jobProps := map[string]*biproperty.Map{}
+
+		for _, jobRef := range instGroup.Jobs {
+			job, found := releaseJobs[jobRef.Release][jobRef.Name]
+			if !found {
+				errMsg := "Expected to find job '%s' from release '%s' used by instance group '%s' in given releases"
+				return bosherr.Errorf(errMsg, jobRef.Name, jobRef.Release, instGroup.Name)
+			}
+
+			jobs = append(jobs, job)
+
+			if jobRef.Properties != nil {
+				props, err := biproperty.BuildMap(*jobRef.Properties)
+				if err != nil {
+					return bosherr.WrapErrorf(err, "Parsing instance group '%s' job '%s' properties", instGroup.Name, jobRef.Name)
+				}
+
+				jobProps[jobRef.Name] = &props
+			}
+		}
+
+		for _, problem := range boshjob.ValidateProperties(jobs, jobProps, instGroupProps, globalProps) {
+			table.Rows = append(table.Rows, []boshtbl.Value{
+				boshtbl.NewValueString(instGroup.Name),
+				boshtbl.NewValueString(problem.Job),
+				boshtbl.NewValueString(problem.Property),
+				boshtbl.NewValueString(problem.Problem),
+			})
+		}
+	}
+
+	if len(table.Rows) == 0 {
+		c.ui.PrintLinef("Properties match job specs")
+		return nil
+	}
+
+	c.ui.PrintTable(table)
+
+	return bosherr.Errorf("Expected properties to match job specs")
+}
+
+// readReleaseJobs returns jobs keyed by release name and job name
+func (c ValidatePropertiesCmd) readReleaseJobs(paths []string) (map[string]map[string]boshjob.Job, error) {
+	releaseJobs := map[string]map[string]boshjob.Job{}
+
+	for _, path := range paths {
+		var (
+			name string
+			jobs map[string]boshjob.Job
+			err  error
+		)
+
+		fileInfo, err := c.fs.Stat(path)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Checking release '%s'", path)
+		}
+
+		if fileInfo.IsDir() {
+			name, jobs, err = c.readReleaseDirJobs(path)
+		} else {
+			name, jobs, err = c.readReleaseArchiveJobs(path)
+		}
+
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Reading release '%s'", path)
+		}
+
+		releaseJobs[name] = jobs
+	}
+
+	return releaseJobs, nil
+}
+
+func (c ValidatePropertiesCmd) readReleaseArchiveJobs(path string) (string, map[string]boshjob.Job, error) {
+	release, err := c.releaseReader.Read(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	defer release.CleanUp()
+
+	jobs := map[string]boshjob.Job{}
+
+	for _, job := range release.Jobs() {
+		jobs[job.Name()] = *job
+	}
+
+	return release.Name(), jobs, nil
+}
+
+func (c ValidatePropertiesCmd) readReleaseDirJobs(path string) (string, map[string]boshjob.Job, error) {
+	name, err := c.releaseDirFactory(path).DefaultName()
+	if err != nil {
+		return "", nil, err
+	}
+
+	specPaths, err := c.fs.Glob(filepath.Join(path, "jobs", "*", "spec"))
+	if err != nil {
+		return "", nil, bosherr.WrapErrorf(err, "Finding job specs")
+	}
+
+	jobs := map[string]boshjob.Job{}
+
+	for _, specPath := range specPaths {
+		manifest, err := boshjobman.NewManifestFromPath(specPath, c.fs)
+		if err != nil {
+			return "", nil, err
+		}
+
+		job := boshjob.NewJob(boshres.NewExistingResource(manifest.Name, "", ""))
+
+		job.Properties, err = boshjob.NewPropertyDefinitions(manifest.Name, manifest.Properties)
+		if err != nil {
+			return "", nil, err
+		}
+
+		jobs[manifest.Name] = *job
+	}
+
+	return name, jobs, nil
+}
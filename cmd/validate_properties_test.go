@@ -0,0 +1,176 @@
+package cmd_test
+
+import (
+	"errors"
+	"os"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+	fakerel "github.com/cloudfoundry/bosh-cli/release/releasefakes"
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
+	boshreldir "github.com/cloudfoundry/bosh-cli/releasedir"
+	fakereldir "github.com/cloudfoundry/bosh-cli/releasedir/releasedirfakes"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+	boshtbl "github.com/cloudfoundry/bosh-cli/ui/table"
+)
+
+var _ = Describe("ValidatePropertiesCmd", func() {
+	var (
+		releaseReader *fakerel.FakeReader
+		releaseDir    *fakereldir.FakeReleaseDir
+		fs            *fakesys.FakeFileSystem
+		ui            *fakeui.FakeUI
+		command       ValidatePropertiesCmd
+	)
+
+	BeforeEach(func() {
+		releaseReader = &fakerel.FakeReader{}
+		releaseDir = &fakereldir.FakeReleaseDir{}
+		fs = fakesys.NewFakeFileSystem()
+		ui = &fakeui.FakeUI{}
+
+		releaseDirFactory := func(path string) boshreldir.ReleaseDir {
+			Expect(path).To(Equal("/release-dir"))
+			return releaseDir
+		}
+
+		command = NewValidatePropertiesCmd(releaseReader, releaseDirFactory, fs, ui)
+	})
+
+	Describe("Run", func() {
+		var (
+			opts    ValidatePropertiesOpts
+			release *fakerel.FakeRelease
+		)
+
+		BeforeEach(func() {
+			opts = ValidatePropertiesOpts{
+				Args: ValidatePropertiesArgs{
+					Manifest: FileBytesArg{Bytes: []byte(`
+properties:
+  api: {port: ((port))}
+instance_groups:
+- name: web
+  jobs:
+  - name: api
+    release: app
+  - name: proxy
+    release: proxy
+    properties:
+      backends: [api]
+`)},
+				},
+				Releases: []string{"/app.tgz", "/release-dir"},
+			}
+
+			opts.VarKVs = []boshtpl.VarKV{{Name: "port", Value: 8080}}
+
+			apiJob := boshjob.NewJob(boshres.NewResource("api", "fp", nil))
+			apiJob.Properties = map[string]boshjob.PropertyDefinition{
+				"api.port": boshjob.PropertyDefinition{Type: "integer"},
+			}
+
+			release = &fakerel.FakeRelease{}
+			release.NameReturns("app")
+			release.JobsReturns([]*boshjob.Job{apiJob})
+			releaseReader.ReadReturns(release, nil)
+
+			releaseDir.DefaultNameReturns("proxy", nil)
+
+			fs.WriteFileString("/app.tgz", "")
+			fs.MkdirAll("/release-dir", os.ModePerm)
+			fs.SetGlob("/release-dir/jobs/*/spec", []string{"/release-dir/jobs/proxy/spec"})
+			fs.WriteFileString("/release-dir/jobs/proxy/spec", `---
+name: proxy
+properties:
+  backends:
+    type: array
+`)
+		})
+
+		act := func() error { return command.Run(opts) }
+
+		It("succeeds when properties match job specs from release tarballs and directories", func() {
+			err := act()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(releaseReader.ReadArgsForCall(0)).To(Equal("/app.tgz"))
+			Expect(release.CleanUpCallCount()).To(Equal(1))
+
+			Expect(ui.Said).To(Equal([]string{"Properties match job specs"}))
+			Expect(ui.Tables).To(BeEmpty())
+		})
+
+		It("shows problems per instance group and job and returns error", func() {
+			opts.VarKVs = []boshtpl.VarKV{{Name: "port", Value: "http"}}
+			opts.Args.Manifest.Bytes = append(opts.Args.Manifest.Bytes, []byte(`      backend: [api]
+`)...)
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected properties to match job specs"))
+
+			Expect(ui.Table).To(Equal(boshtbl.Table{
+				Content: "property problems",
+				Header: []boshtbl.Header{
+					boshtbl.NewHeader("Instance Group"),
+					boshtbl.NewHeader("Job"),
+					boshtbl.NewHeader("Property"),
+					boshtbl.NewHeader("Problem"),
+				},
+				Rows: [][]boshtbl.Value{
+					{
+						boshtbl.NewValueString("web"),
+						boshtbl.NewValueString("api"),
+						boshtbl.NewValueString("api.port"),
+						boshtbl.NewValueString("Expected integer but got string"),
+					},
+					{
+						boshtbl.NewValueString("web"),
+						boshtbl.NewValueString("proxy"),
+						boshtbl.NewValueString("backend"),
+						boshtbl.NewValueString("Unknown property"),
+					},
+				},
+			}))
+		})
+
+		It("returns error if job's release is not given", func() {
+			opts.Releases = []string{"/app.tgz"}
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(
+				"Expected to find job 'proxy' from release 'proxy' used by instance group 'web' in given releases"))
+		})
+
+		It("returns error if release tarball cannot be read", func() {
+			releaseReader.ReadReturns(nil, errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Reading release '/app.tgz'"))
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+
+		It("returns error if release directory name cannot be determined", func() {
+			releaseDir.DefaultNameReturns("", errors.New("fake-err"))
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Reading release '/release-dir'"))
+		})
+
+		It("returns error if manifest cannot be evaluated", func() {
+			opts.Args.Manifest.Bytes = []byte("-")
+
+			err := act()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
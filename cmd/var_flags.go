@@ -6,16 +6,41 @@ import (
 	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 )
 
+const VarsStorePassphraseEnv = "BOSH_VARS_STORE_PASSPHRASE"
+
 // Shared
 type VarFlags struct {
-	VarKVs      []boshtpl.VarKV       `long:"var"        short:"v" value-name:"VAR=VALUE" description:"Set variable"`
-	VarFiles    []boshtpl.VarFileArg  `long:"var-file"             value-name:"VAR=PATH"  description:"Set variable to file contents"`
-	VarsFiles   []boshtpl.VarsFileArg `long:"vars-file"  short:"l" value-name:"PATH"      description:"Load variables from a YAML file"`
-	VarsEnvs    []boshtpl.VarsEnvArg  `long:"vars-env"             value-name:"PREFIX"    description:"Load variables from environment variables (e.g.: 'MY' to load MY_var=value)"`
-	VarsFSStore VarsFSStore           `long:"vars-store"           value-name:"PATH"      description:"Load/save variables from/to a YAML file"`
+	VarKVs      []boshtpl.VarKV       `long:"var"         short:"v" value-name:"VAR=VALUE"     description:"Set variable"`
+	VarFiles    []boshtpl.VarFileArg  `long:"var-file"              value-name:"VAR=PATH"      description:"Set variable to file contents"`
+	VarsFiles   []boshtpl.VarsFileArg `long:"vars-file"   short:"l" value-name:"PATH"          description:"Load variables from a YAML file"`
+	VarsEnvs    []boshtpl.VarsEnvArg  `long:"vars-env"              value-name:"PREFIX"        description:"Load variables from environment variables (e.g.: 'MY' to load MY_var=value)"`
+	VarsFSStore VarsFSStore           `long:"vars-store"            value-name:"PATH"          description:"Load/save variables from/to a YAML file"`
+	VarsFSKey   VarsStoreKeyArg       `long:"vars-store-key"        value-name:"PATH"          description:"Encrypt vars store with passphrase from a file (or BOSH_VARS_STORE_PASSPHRASE)" env:"BOSH_VARS_STORE_KEY"`
+	VarsSources []VarsSourceArg       `long:"vars-source"           value-name:"TYPE=LOCATION" description:"Load/save variables from/to an external source (types: http, dir, exec)"`
 }
 
 func (f VarFlags) AsVariables() boshtpl.Variables {
+	vars, _ := f.asMultiVars()
+	return vars
+}
+
+// AsReadOnlyVariables returns variables that are only looked up so that
+// typed variables which are not found are neither generated nor saved
+func (f VarFlags) AsReadOnlyVariables() boshtpl.Variables {
+	vars, _ := f.asMultiVars()
+	return readOnlyVariables{vars: vars}
+}
+
+// AsTracedVariables returns variables that record which flags resolved variables
+func (f VarFlags) AsTracedVariables() (boshtpl.Variables, *VarsTrace) {
+	vars, sources := f.asMultiVars()
+	trace := &VarsTrace{flags: f, sources: sources}
+	return vars.WithTracer(trace), trace
+}
+
+// asMultiVars returns variables and descriptions of their sources in lookup order;
+// static variables (first) are described by VarsTrace since they are merged from several flags
+func (f VarFlags) asMultiVars() (boshtpl.MultiVars, []string) {
 	var firstToUse []boshtpl.Variables
 
 	staticVars := boshtpl.StaticVariables{}
@@ -43,18 +68,52 @@ func (f VarFlags) AsVariables() boshtpl.Variables {
 	}
 
 	firstToUse = append(firstToUse, staticVars)
+	sources := []string{""}
+
+	for _, source := range f.VarsSources {
+		firstToUse = append(firstToUse, source.Source)
+		sources = append(sources, source.description())
+	}
 
 	store := &f.VarsFSStore
 
 	if f.VarsFSStore.IsSet() {
 		firstToUse = append(firstToUse, store)
+		sources = append(sources, "--vars-store "+f.VarsFSStore.path)
+	}
+
+	// Without vars store generated values are saved to the first vars source
+	var generator *VarsSourceGenerator
+
+	if !f.VarsFSStore.IsSet() && len(f.VarsSources) > 0 {
+		generator = &VarsSourceGenerator{Source: f.VarsSources[0].Source}
+		firstToUse = append(firstToUse, generator)
+		sources = append(sources, f.VarsSources[0].description()+" (generated)")
 	}
 
 	vars := boshtpl.NewMultiVars(firstToUse)
 
 	if f.VarsFSStore.IsSet() {
 		store.ValueGeneratorFactory = cfgtypes.NewValueGeneratorConcrete(NewVarsCertLoader(vars))
+		store.Encryptor = f.VarsFSKey.Encryptor(VarsStorePassphraseEnv)
 	}
 
-	return vars
+	if generator != nil {
+		generator.ValueGeneratorFactory = cfgtypes.NewValueGeneratorConcrete(NewVarsCertLoader(vars))
+	}
+
+	return vars, sources
+}
+
+type readOnlyVariables struct {
+	vars boshtpl.Variables
+}
+
+// Get drops variable type since only typed variables are generated
+func (v readOnlyVariables) Get(varDef boshtpl.VariableDefinition) (interface{}, bool, error) {
+	return v.vars.Get(boshtpl.VariableDefinition{Name: varDef.Name})
+}
+
+func (v readOnlyVariables) List() ([]boshtpl.VariableDefinition, error) {
+	return v.vars.List()
 }
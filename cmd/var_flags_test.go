@@ -9,6 +9,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	. "github.com/cloudfoundry/bosh-cli/cmd"
+	"github.com/cloudfoundry/bosh-cli/crypto"
 	. "github.com/cloudfoundry/bosh-cli/director/template"
 )
 
@@ -140,6 +141,109 @@ env: store
 			}
 		})
 
+		It("configures vars store to be encrypted with vars store key", func() {
+			fs := fakesys.NewFakeFileSystem()
+
+			varsStore := &VarsFSStore{FS: fs}
+
+			err := varsStore.UnmarshalFlag("/file")
+			Expect(err).ToNot(HaveOccurred())
+
+			flags := VarFlags{
+				VarsFSStore: *varsStore,
+				VarsFSKey:   VarsStoreKeyArg{Passphrase: []byte("passphrase")},
+			}
+
+			val, found, err := flags.AsVariables().Get(VariableDefinition{Name: "key", Type: "password"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			contents, err := fs.ReadFile("/file")
+			Expect(err).ToNot(HaveOccurred())
+
+			plaintext, err := crypto.NewPassphraseEncryptor([]byte("passphrase")).Decrypt(contents)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(plaintext)).To(Equal(fmt.Sprintf("key: %s\n", val)))
+		})
+
+		It("adds vars sources after static variables and before vars store", func() {
+			fs := fakesys.NewFakeFileSystem()
+
+			varsStore := &VarsFSStore{FS: fs}
+
+			err := varsStore.UnmarshalFlag("/file")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = fs.WriteFileString("/file", "store: store\nsource2: store")
+			Expect(err).ToNot(HaveOccurred())
+
+			fs.WriteFileString("/source1/kv", "source1")
+			fs.WriteFileString("/source1/source1", "source1")
+			fs.WriteFileString("/source2/source1", "source2")
+			fs.WriteFileString("/source2/source2", "source2")
+
+			flags := VarFlags{
+				VarKVs: []VarKV{
+					{Name: "kv", Value: "kv"},
+				},
+				VarsSources: []VarsSourceArg{
+					{Source: NewVarsDirSource("/source1", fs)},
+					{Source: NewVarsDirSource("/source2", fs)},
+				},
+				VarsFSStore: *varsStore,
+			}
+
+			vars := flags.AsVariables()
+
+			expectedVals := map[string]string{
+				"kv":      "kv",
+				"source1": "source1",
+				"source2": "source2",
+				"store":   "store",
+			}
+
+			for key, expectedVal := range expectedVals {
+				val, found, err := vars.Get(VariableDefinition{Name: key})
+				Expect(val).To(Equal(expectedVal), fmt.Sprintf("Expecting key '%s' value to match", key))
+				Expect(found).To(BeTrue())
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			val, found, err := vars.Get(VariableDefinition{Name: "generated", Type: "password"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(fs.FileExists("/source1/generated")).To(BeFalse())
+			Expect(fs.ReadFileString("/file")).To(ContainSubstring(val.(string)))
+		})
+
+		It("saves generated values to the first vars source if vars store is not configured", func() {
+			fs := fakesys.NewFakeFileSystem()
+
+			fs.WriteFileString("/source2/existing", "source2")
+
+			flags := VarFlags{
+				VarsSources: []VarsSourceArg{
+					{Source: NewVarsDirSource("/source1", fs)},
+					{Source: NewVarsDirSource("/source2", fs)},
+				},
+			}
+
+			vars := flags.AsVariables()
+
+			val, found, err := vars.Get(VariableDefinition{Name: "existing", Type: "password"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("source2"))
+
+			val, found, err = vars.Get(VariableDefinition{Name: "generated", Type: "password"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(fs.ReadFileString("/source1/generated")).To(Equal(val.(string) + "\n"))
+			Expect(fs.FileExists("/source2/generated")).To(BeFalse())
+		})
+
 		It("configures vars store to have ability to look up all variables for value generation", func() {
 			varsStore := &VarsFSStore{FS: fakesys.NewFakeFileSystem()}
 			varsStore.UnmarshalFlag("/file")
@@ -178,4 +282,88 @@ env: store
 			Expect(valRaw["ca"].(string)).To(Equal(caCert))
 		})
 	})
+
+	Describe("AsReadOnlyVariables", func() {
+		It("looks up variables without generating and saving missing ones", func() {
+			fs := fakesys.NewFakeFileSystem()
+
+			varsStore := &VarsFSStore{FS: fs}
+			err := varsStore.UnmarshalFlag("/file")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = fs.WriteFileString("/file", "store: store\n")
+			Expect(err).ToNot(HaveOccurred())
+
+			flags := VarFlags{
+				VarKVs:      []VarKV{{Name: "kv", Value: "kv"}},
+				VarsSources: []VarsSourceArg{{Source: NewVarsDirSource("/source", fs)}},
+				VarsFSStore: *varsStore,
+			}
+
+			vars := flags.AsReadOnlyVariables()
+
+			val, found, err := vars.Get(VariableDefinition{Name: "kv", Type: "password"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("kv"))
+
+			val, found, err = vars.Get(VariableDefinition{Name: "store", Type: "password"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("store"))
+
+			_, found, err = vars.Get(VariableDefinition{Name: "missing", Type: "password"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			Expect(fs.ReadFileString("/file")).To(Equal("store: store\n"))
+			Expect(fs.FileExists("/source/missing")).To(BeFalse())
+		})
+	})
+
+	Describe("AsTracedVariables", func() {
+		It("records flags that resolved variables following precedence", func() {
+			fs := fakesys.NewFakeFileSystem()
+			fs.WriteFileString("/vars-dir/from_source", "source")
+			fs.WriteFileString("/store", "from_store: store")
+
+			store := VarsFSStore{FS: fs}
+			err := (&store).UnmarshalFlag("/store")
+			Expect(err).ToNot(HaveOccurred())
+
+			flags := VarFlags{
+				VarKVs: []VarKV{{Name: "from_kv", Value: "kv"}},
+				VarFiles: []VarFileArg{
+					{Vars: StaticVariables{"from_var_file": "var_file", "from_kv": "var_file"}},
+				},
+				VarsFiles: []VarsFileArg{
+					{FilePath: "/vars1.yml", Vars: StaticVariables{"from_vars_file": "file1"}},
+					{FilePath: "/vars2.yml", Vars: StaticVariables{"from_vars_file": "file2"}},
+				},
+				VarsEnvs: []VarsEnvArg{
+					{Vars: StaticVariables{"from_env": "env"}},
+				},
+				VarsSources: []VarsSourceArg{
+					{Type: "dir", Location: "/vars-dir", Source: NewVarsDirSource("/vars-dir", fs)},
+				},
+				VarsFSStore: store,
+			}
+
+			vars, trace := flags.AsTracedVariables()
+
+			for _, name := range []string{"from_kv", "from_var_file", "from_vars_file", "from_env", "from_source", "from_store", "from_kv", "missing"} {
+				_, _, err := vars.Get(VariableDefinition{Name: name})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			Expect(trace.Found).To(Equal([]VarsTraceEntry{
+				{Name: "from_kv", Source: "--var"},
+				{Name: "from_var_file", Source: "--var-file"},
+				{Name: "from_vars_file", Source: "--vars-file /vars2.yml"},
+				{Name: "from_env", Source: "--vars-env"},
+				{Name: "from_source", Source: "--vars-source dir=/vars-dir"},
+				{Name: "from_store", Source: "--vars-store /store"},
+			}))
+		})
+	})
 })
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	"gopkg.in/yaml.v2"
+
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+// VarsDirSource keeps each variable as a YAML file in a directory tree
+// (e.g. variable '/cf/admin_password' is kept in '<dir>/cf/admin_password')
+type VarsDirSource struct {
+	path string
+	fs   boshsys.FileSystem
+}
+
+var _ VarsSource = VarsDirSource{}
+
+func NewVarsDirSource(path string, fs boshsys.FileSystem) VarsDirSource {
+	return VarsDirSource{path: path, fs: fs}
+}
+
+func (s VarsDirSource) Get(varDef boshtpl.VariableDefinition) (interface{}, bool, error) {
+	path := s.varPath(varDef.Name)
+
+	if !s.fs.FileExists(path) {
+		return nil, false, nil
+	}
+
+	bytes, err := s.fs.ReadFile(path)
+	if err != nil {
+		return nil, false, bosherr.WrapErrorf(err, "Reading variable '%s' from vars source", varDef.Name)
+	}
+
+	var val interface{}
+
+	err = yaml.Unmarshal(bytes, &val)
+	if err != nil {
+		return nil, false, bosherr.WrapErrorf(err, "Deserializing variable '%s' from vars source", varDef.Name)
+	}
+
+	return val, true, nil
+}
+
+func (s VarsDirSource) List() ([]boshtpl.VariableDefinition, error) {
+	var defs []boshtpl.VariableDefinition
+
+	if !s.fs.FileExists(s.path) {
+		return defs, nil
+	}
+
+	err := s.fs.Walk(s.path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(s.path, path)
+		if err != nil {
+			return err
+		}
+
+		defs = append(defs, boshtpl.VariableDefinition{Name: filepath.ToSlash(relPath)})
+
+		return nil
+	})
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Listing variables in vars source '%s'", s.path)
+	}
+
+	return defs, nil
+}
+
+func (s VarsDirSource) Set(name string, val interface{}) error {
+	bytes, err := yaml.Marshal(val)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Serializing variable '%s'", name)
+	}
+
+	path := s.varPath(name)
+
+	err = s.fs.MkdirAll(filepath.Dir(path), os.ModePerm)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Creating directory for variable '%s'", name)
+	}
+
+	err = s.fs.WriteFile(path, bytes)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing variable '%s' to vars source", name)
+	}
+
+	return nil
+}
+
+func (s VarsDirSource) varPath(name string) string {
+	return filepath.Join(s.path, filepath.FromSlash(strings.TrimPrefix(name, "/")))
+}
@@ -0,0 +1,92 @@
+package cmd_test
+
+import (
+	"errors"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+var _ = Describe("VarsDirSource", func() {
+	var (
+		fs     *fakesys.FakeFileSystem
+		source VarsDirSource
+	)
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+		source = NewVarsDirSource("/vars", fs)
+	})
+
+	Describe("Get", func() {
+		It("returns value and found if variable file exists", func() {
+			fs.WriteFileString("/vars/dir/name", "key: val")
+
+			val, found, err := source.Get(boshtpl.VariableDefinition{Name: "/dir/name"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal(map[interface{}]interface{}{"key": "val"}))
+		})
+
+		It("returns not found if variable file does not exist", func() {
+			val, found, err := source.Get(boshtpl.VariableDefinition{Name: "name"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+			Expect(val).To(BeNil())
+		})
+
+		It("returns an error if reading file fails", func() {
+			fs.WriteFileString("/vars/name", "val")
+			fs.ReadFileError = errors.New("fake-err")
+
+			_, _, err := source.Get(boshtpl.VariableDefinition{Name: "name"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+
+		It("returns an error if file cannot be deserialized", func() {
+			fs.WriteFileString("/vars/name", "key: [")
+
+			_, _, err := source.Get(boshtpl.VariableDefinition{Name: "name"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Deserializing variable 'name' from vars source"))
+		})
+	})
+
+	Describe("List", func() {
+		It("returns names of all variable files", func() {
+			fs.WriteFileString("/vars/name1", "val")
+			fs.WriteFileString("/vars/dir/name2", "val")
+
+			defs, err := source.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(defs).To(ConsistOf([]boshtpl.VariableDefinition{{Name: "name1"}, {Name: "dir/name2"}}))
+		})
+
+		It("returns no variables if directory does not exist", func() {
+			defs, err := source.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(defs).To(BeEmpty())
+		})
+	})
+
+	Describe("Set", func() {
+		It("writes serialized value", func() {
+			err := source.Set("/dir/name", map[string]string{"key": "val"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fs.ReadFileString("/vars/dir/name")).To(Equal("key: val\n"))
+		})
+
+		It("returns an error if writing file fails", func() {
+			fs.WriteFileError = errors.New("fake-err")
+
+			err := source.Set("name", "val")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+})
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	"gopkg.in/yaml.v2"
+
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+// VarsExecSource delegates to an executable plugin:
+//
+//	<plugin> get NAME  prints {"found": true, "value": ...}
+//	<plugin> set NAME  reads {"value": ...} from stdin
+//	<plugin> list      prints {"names": [...]}
+type VarsExecSource struct {
+	path      string
+	cmdRunner boshsys.CmdRunner
+}
+
+var _ VarsSource = VarsExecSource{}
+
+func NewVarsExecSource(path string, cmdRunner boshsys.CmdRunner) VarsExecSource {
+	return VarsExecSource{path: path, cmdRunner: cmdRunner}
+}
+
+func (s VarsExecSource) Get(varDef boshtpl.VariableDefinition) (interface{}, bool, error) {
+	stdout, err := s.run("", "get", varDef.Name)
+	if err != nil {
+		return nil, false, bosherr.WrapErrorf(err, "Getting variable '%s' from vars source", varDef.Name)
+	}
+
+	var resp struct {
+		Found bool        `yaml:"found"`
+		Value interface{} `yaml:"value"`
+	}
+
+	// YAML is used to deserialize JSON to produce values compatible with templates
+	err = yaml.Unmarshal([]byte(stdout), &resp)
+	if err != nil {
+		return nil, false, bosherr.WrapErrorf(err, "Deserializing variable '%s' from vars source", varDef.Name)
+	}
+
+	if !resp.Found {
+		return nil, false, nil
+	}
+
+	return resp.Value, true, nil
+}
+
+func (s VarsExecSource) List() ([]boshtpl.VariableDefinition, error) {
+	stdout, err := s.run("", "list")
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Listing variables from vars source")
+	}
+
+	var resp struct {
+		Names []string `json:"names"`
+	}
+
+	err = json.Unmarshal([]byte(stdout), &resp)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Deserializing variables list from vars source")
+	}
+
+	var defs []boshtpl.VariableDefinition
+
+	for _, name := range resp.Names {
+		defs = append(defs, boshtpl.VariableDefinition{Name: name})
+	}
+
+	return defs, nil
+}
+
+func (s VarsExecSource) Set(name string, val interface{}) error {
+	reqBytes, err := json.Marshal(map[string]interface{}{"value": val})
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Serializing variable '%s'", name)
+	}
+
+	_, err = s.run(string(reqBytes), "set", name)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Saving variable '%s' to vars source", name)
+	}
+
+	return nil
+}
+
+func (s VarsExecSource) run(stdin string, args ...string) (string, error) {
+	cmd := boshsys.Command{
+		Name: s.path,
+		Args: args,
+	}
+
+	if len(stdin) > 0 {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+
+	stdout, stderr, _, err := s.cmdRunner.RunComplexCommand(cmd)
+	if err != nil {
+		return "", bosherr.WrapErrorf(err, "Running vars source plugin '%s': %s", s.path, stderr)
+	}
+
+	return stdout, nil
+}
@@ -0,0 +1,106 @@
+package cmd_test
+
+import (
+	"errors"
+	"io/ioutil"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+var _ = Describe("VarsExecSource", func() {
+	var (
+		cmdRunner *fakesys.FakeCmdRunner
+		source    VarsExecSource
+	)
+
+	BeforeEach(func() {
+		cmdRunner = fakesys.NewFakeCmdRunner()
+		source = NewVarsExecSource("/plugin", cmdRunner)
+	})
+
+	Describe("Get", func() {
+		It("returns value and found if plugin finds variable", func() {
+			cmdRunner.AddCmdResult("/plugin get name", fakesys.FakeCmdResult{
+				Stdout: `{"found":true,"value":{"key":"val"}}`,
+			})
+
+			val, found, err := source.Get(boshtpl.VariableDefinition{Name: "name"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal(map[interface{}]interface{}{"key": "val"}))
+		})
+
+		It("returns not found if plugin does not find variable", func() {
+			cmdRunner.AddCmdResult("/plugin get name", fakesys.FakeCmdResult{
+				Stdout: `{"found":false}`,
+			})
+
+			val, found, err := source.Get(boshtpl.VariableDefinition{Name: "name"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+			Expect(val).To(BeNil())
+		})
+
+		It("returns an error if plugin fails", func() {
+			cmdRunner.AddCmdResult("/plugin get name", fakesys.FakeCmdResult{
+				Stderr: "fake-stderr",
+				Error:  errors.New("fake-err"),
+			})
+
+			_, _, err := source.Get(boshtpl.VariableDefinition{Name: "name"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(
+				"Getting variable 'name' from vars source: Running vars source plugin '/plugin': fake-stderr: fake-err"))
+		})
+	})
+
+	Describe("List", func() {
+		It("returns all variable names", func() {
+			cmdRunner.AddCmdResult("/plugin list", fakesys.FakeCmdResult{
+				Stdout: `{"names":["name1","name2"]}`,
+			})
+
+			defs, err := source.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(defs).To(Equal([]boshtpl.VariableDefinition{{Name: "name1"}, {Name: "name2"}}))
+		})
+
+		It("returns an error if output cannot be deserialized", func() {
+			cmdRunner.AddCmdResult("/plugin list", fakesys.FakeCmdResult{Stdout: "-"})
+
+			_, err := source.List()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Deserializing variables list from vars source"))
+		})
+	})
+
+	Describe("Set", func() {
+		It("passes serialized value to plugin via stdin", func() {
+			cmdRunner.AddCmdResult("/plugin set name", fakesys.FakeCmdResult{})
+
+			err := source.Set("name", "val")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(cmdRunner.RunComplexCommands).To(HaveLen(1))
+
+			stdin, err := ioutil.ReadAll(cmdRunner.RunComplexCommands[0].Stdin)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(stdin)).To(Equal(`{"value":"val"}`))
+		})
+
+		It("returns an error if plugin fails", func() {
+			cmdRunner.AddCmdResult("/plugin set name", fakesys.FakeCmdResult{
+				Error: errors.New("fake-err"),
+			})
+
+			err := source.Set("name", "val")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Saving variable 'name' to vars source"))
+		})
+	})
+})
@@ -6,6 +6,7 @@ import (
 	cfgtypes "github.com/cloudfoundry/config-server/types"
 	"gopkg.in/yaml.v2"
 
+	"github.com/cloudfoundry/bosh-cli/crypto"
 	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 )
 
@@ -14,13 +15,26 @@ type VarsFSStore struct {
 
 	ValueGeneratorFactory cfgtypes.ValueGeneratorFactory
 
+	// Encryptor is used to save vars store encrypted when configured
+	Encryptor crypto.Encryptor
+
 	path string
+
+	// memVars keeps variables in memory instead of file store when set
+	memVars boshtpl.StaticVariables
 }
 
 var _ boshtpl.Variables = VarsFSStore{}
 
 func (s VarsFSStore) IsSet() bool { return len(s.path) > 0 }
 
+// inMemory returns store that reads and generates variables into vars
+// without writing to file store so that changes can be saved all at once
+func (s VarsFSStore) inMemory(vars boshtpl.StaticVariables) VarsFSStore {
+	s.memVars = vars
+	return s
+}
+
 func (s VarsFSStore) Get(varDef boshtpl.VariableDefinition) (interface{}, bool, error) {
 	vars, err := s.load()
 	if err != nil {
@@ -86,12 +100,31 @@ func (s VarsFSStore) set(key string, val interface{}) error {
 func (s VarsFSStore) load() (boshtpl.StaticVariables, error) {
 	vars := boshtpl.StaticVariables{}
 
+	if s.memVars != nil {
+		for k, v := range s.memVars {
+			vars[k] = v
+		}
+
+		return vars, nil
+	}
+
 	if s.FS.FileExists(s.path) {
 		bytes, err := s.FS.ReadFile(s.path)
 		if err != nil {
 			return vars, err
 		}
 
+		if crypto.IsEncrypted(bytes) {
+			if s.Encryptor == nil {
+				return vars, bosherr.Errorf("Expected vars store key to decrypt variables file store '%s'", s.path)
+			}
+
+			bytes, err = s.Encryptor.Decrypt(bytes)
+			if err != nil {
+				return vars, bosherr.WrapErrorf(err, "Decrypting variables file store '%s'", s.path)
+			}
+		}
+
 		err = yaml.Unmarshal(bytes, &vars)
 		if err != nil {
 			return vars, bosherr.WrapErrorf(err, "Deserializing variables file store '%s'", s.path)
@@ -102,11 +135,30 @@ func (s VarsFSStore) load() (boshtpl.StaticVariables, error) {
 }
 
 func (s VarsFSStore) save(vars boshtpl.StaticVariables) error {
+	if s.memVars != nil {
+		for k := range s.memVars {
+			delete(s.memVars, k)
+		}
+
+		for k, v := range vars {
+			s.memVars[k] = v
+		}
+
+		return nil
+	}
+
 	bytes, err := yaml.Marshal(vars)
 	if err != nil {
 		return bosherr.WrapErrorf(err, "Serializing variables")
 	}
 
+	if s.Encryptor != nil {
+		bytes, err = s.Encryptor.Encrypt(bytes)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Encrypting variables")
+		}
+	}
+
 	err = s.FS.WriteFile(s.path, bytes)
 	if err != nil {
 		return bosherr.WrapErrorf(err, "Writing variables to file store '%s'", s.path)
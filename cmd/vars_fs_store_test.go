@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	. "github.com/cloudfoundry/bosh-cli/cmd"
+	"github.com/cloudfoundry/bosh-cli/crypto"
 	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
 )
 
@@ -122,6 +123,69 @@ var _ = Describe("VarsFSStore", func() {
 		})
 	})
 
+	Context("when store is encrypted", func() {
+		BeforeEach(func() {
+			err := (&store).UnmarshalFlag("/file")
+			Expect(err).ToNot(HaveOccurred())
+
+			store.Encryptor = crypto.NewPassphraseEncryptor([]byte("passphrase"))
+		})
+
+		It("saves generated values encrypted and reads them back", func() {
+			val, found, err := store.Get(boshtpl.VariableDefinition{Name: "key", Type: "password"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			contents, err := fs.ReadFileString("/file")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(contents).ToNot(ContainSubstring(val.(string)))
+			Expect(crypto.IsEncrypted([]byte(contents))).To(BeTrue())
+
+			readVal, found, err := store.Get(boshtpl.VariableDefinition{Name: "key"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(readVal).To(Equal(val))
+		})
+
+		It("encrypts previously unencrypted store when saving", func() {
+			fs.WriteFileString("/file", "key: val")
+
+			_, _, err := store.Get(boshtpl.VariableDefinition{Name: "key2", Type: "password"})
+			Expect(err).ToNot(HaveOccurred())
+
+			contents, err := fs.ReadFileString("/file")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(crypto.IsEncrypted([]byte(contents))).To(BeTrue())
+
+			val, found, err := store.Get(boshtpl.VariableDefinition{Name: "key"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("val"))
+		})
+
+		It("returns an error if store is encrypted but key is not configured", func() {
+			_, _, err := store.Get(boshtpl.VariableDefinition{Name: "key", Type: "password"})
+			Expect(err).ToNot(HaveOccurred())
+
+			store.Encryptor = nil
+
+			_, _, err = store.Get(boshtpl.VariableDefinition{Name: "key"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected vars store key to decrypt variables file store '/file'"))
+		})
+
+		It("returns an error if store cannot be decrypted with configured key", func() {
+			_, _, err := store.Get(boshtpl.VariableDefinition{Name: "key", Type: "password"})
+			Expect(err).ToNot(HaveOccurred())
+
+			store.Encryptor = crypto.NewPassphraseEncryptor([]byte("wrong"))
+
+			_, err = store.List()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Decrypting variables file store '/file'"))
+		})
+	})
+
 	Describe("List", func() {
 		BeforeEach(func() {
 			err := (&store).UnmarshalFlag("/file")
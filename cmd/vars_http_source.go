@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshhttp "github.com/cloudfoundry/bosh-utils/httpclient"
+	"gopkg.in/yaml.v2"
+
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+// VarsHTTPSource keeps variables in an HTTP key-value service:
+//
+//	GET  <url>/<name> returns {"value": ...} or 404 if variable is not set
+//	PUT  <url>/<name> saves {"value": ...}
+//	GET  <url>        returns {"names": [...]}
+type VarsHTTPSource struct {
+	url   string
+	token string
+
+	client boshhttp.Client
+}
+
+var _ VarsSource = VarsHTTPSource{}
+
+func NewVarsHTTPSource(url, token string, client boshhttp.Client) VarsHTTPSource {
+	return VarsHTTPSource{
+		url:    strings.TrimSuffix(url, "/"),
+		token:  token,
+		client: client,
+	}
+}
+
+func (s VarsHTTPSource) Get(varDef boshtpl.VariableDefinition) (interface{}, bool, error) {
+	respBody, status, err := s.request("GET", s.varURL(varDef.Name), nil)
+	if err != nil {
+		return nil, false, bosherr.WrapErrorf(err, "Getting variable '%s' from vars source", varDef.Name)
+	}
+
+	if status == http.StatusNotFound {
+		return nil, false, nil
+	}
+
+	var resp struct {
+		Value interface{} `yaml:"value"`
+	}
+
+	// YAML is used to deserialize JSON to produce values compatible with templates
+	err = yaml.Unmarshal(respBody, &resp)
+	if err != nil {
+		return nil, false, bosherr.WrapErrorf(err, "Deserializing variable '%s' from vars source", varDef.Name)
+	}
+
+	return resp.Value, true, nil
+}
+
+func (s VarsHTTPSource) List() ([]boshtpl.VariableDefinition, error) {
+	respBody, status, err := s.request("GET", s.url, nil)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Listing variables from vars source")
+	}
+
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+
+	var resp struct {
+		Names []string `json:"names"`
+	}
+
+	err = json.Unmarshal(respBody, &resp)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Deserializing variables list from vars source")
+	}
+
+	var defs []boshtpl.VariableDefinition
+
+	for _, name := range resp.Names {
+		defs = append(defs, boshtpl.VariableDefinition{Name: name})
+	}
+
+	return defs, nil
+}
+
+func (s VarsHTTPSource) Set(name string, val interface{}) error {
+	reqBody, err := json.Marshal(map[string]interface{}{"value": val})
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Serializing variable '%s'", name)
+	}
+
+	_, _, err = s.request("PUT", s.varURL(name), reqBody)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Saving variable '%s' to vars source", name)
+	}
+
+	return nil
+}
+
+func (s VarsHTTPSource) varURL(name string) string {
+	var pieces []string
+
+	for _, piece := range strings.Split(strings.TrimPrefix(name, "/"), "/") {
+		pieces = append(pieces, url.PathEscape(piece))
+	}
+
+	return s.url + "/" + strings.Join(pieces, "/")
+}
+
+func (s VarsHTTPSource) request(method, endpoint string, body []byte) ([]byte, int, error) {
+	req, err := http.NewRequest(method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(body) > 0 {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if len(s.token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, bosherr.WrapError(err, "Reading response")
+	}
+
+	if resp.StatusCode == http.StatusNotFound && method == "GET" {
+		return nil, resp.StatusCode, nil
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, resp.StatusCode, fmt.Errorf("Expected response status to be 2xx but was '%d': %s", resp.StatusCode, respBody)
+	}
+
+	return respBody, resp.StatusCode, nil
+}
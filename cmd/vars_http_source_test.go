@@ -0,0 +1,143 @@
+package cmd_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+var _ = Describe("VarsHTTPSource", func() {
+	var (
+		server *ghttp.Server
+		source VarsHTTPSource
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		source = NewVarsHTTPSource(server.URL()+"/vars/", "token", http.DefaultClient)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("Get", func() {
+		It("returns value and found if variable is set", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/vars/dir/name"),
+					ghttp.VerifyHeader(http.Header{"Authorization": []string{"Bearer token"}}),
+					ghttp.RespondWith(http.StatusOK, `{"value":{"key":"val"}}`),
+				),
+			)
+
+			val, found, err := source.Get(boshtpl.VariableDefinition{Name: "/dir/name"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal(map[interface{}]interface{}{"key": "val"}))
+		})
+
+		It("returns not found if variable is not set", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/vars/name"),
+					ghttp.RespondWith(http.StatusNotFound, ""),
+				),
+			)
+
+			val, found, err := source.Get(boshtpl.VariableDefinition{Name: "name"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+			Expect(val).To(BeNil())
+		})
+
+		It("returns an error if response is not successful", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/vars/name"),
+					ghttp.RespondWith(http.StatusInternalServerError, "fake-err"),
+				),
+			)
+
+			_, _, err := source.Get(boshtpl.VariableDefinition{Name: "name"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(
+				"Getting variable 'name' from vars source: Expected response status to be 2xx but was '500': fake-err"))
+		})
+
+		It("returns an error if response cannot be deserialized", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/vars/name"),
+					ghttp.RespondWith(http.StatusOK, "-"),
+				),
+			)
+
+			_, _, err := source.Get(boshtpl.VariableDefinition{Name: "name"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Deserializing variable 'name' from vars source"))
+		})
+	})
+
+	Describe("List", func() {
+		It("returns all variable names", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/vars"),
+					ghttp.RespondWith(http.StatusOK, `{"names":["name1","name2"]}`),
+				),
+			)
+
+			defs, err := source.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(defs).To(Equal([]boshtpl.VariableDefinition{{Name: "name1"}, {Name: "name2"}}))
+		})
+
+		It("returns an error if response is not successful", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/vars"),
+					ghttp.RespondWith(http.StatusInternalServerError, "fake-err"),
+				),
+			)
+
+			_, err := source.List()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Listing variables from vars source"))
+		})
+	})
+
+	Describe("Set", func() {
+		It("saves serialized value", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/vars/name"),
+					ghttp.VerifyJSON(`{"value":"val"}`),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+
+			err := source.Set("name", "val")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns an error if response is not successful", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/vars/name"),
+					ghttp.RespondWith(http.StatusForbidden, "fake-err"),
+				),
+			)
+
+			err := source.Set("name", "val")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(
+				"Saving variable 'name' to vars source: Expected response status to be 2xx but was '403': fake-err"))
+		})
+	})
+})
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	cfgtypes "github.com/cloudfoundry/config-server/types"
+
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+// VarsSource is an external variables backend that can also persist values
+type VarsSource interface {
+	boshtpl.Variables
+	Set(name string, val interface{}) error
+}
+
+// VarsSourceGenerator generates values for typed variables that were not found
+// anywhere else and saves them into a vars source (similar to VarsFSStore)
+type VarsSourceGenerator struct {
+	Source VarsSource
+
+	ValueGeneratorFactory cfgtypes.ValueGeneratorFactory
+}
+
+var _ boshtpl.Variables = VarsSourceGenerator{}
+
+func (g VarsSourceGenerator) Get(varDef boshtpl.VariableDefinition) (interface{}, bool, error) {
+	if len(varDef.Type) == 0 {
+		return nil, false, nil
+	}
+
+	val, err := g.generateAndSet(varDef)
+	if err != nil {
+		return nil, false, bosherr.WrapErrorf(err, "Generating variable '%s'", varDef.Name)
+	}
+
+	return val, true, nil
+}
+
+func (g VarsSourceGenerator) List() ([]boshtpl.VariableDefinition, error) {
+	return nil, nil
+}
+
+func (g VarsSourceGenerator) generateAndSet(varDef boshtpl.VariableDefinition) (interface{}, error) {
+	generator, err := g.ValueGeneratorFactory.GetGenerator(varDef.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := generator.Generate(varDef.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	err = g.Source.Set(varDef.Name, val)
+	if err != nil {
+		return nil, err
+	}
+
+	return val, nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshhttp "github.com/cloudfoundry/bosh-utils/httpclient"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+)
+
+type VarsSourceArg struct {
+	FS        boshsys.FileSystem
+	CmdRunner boshsys.CmdRunner
+
+	Type     string
+	Location string
+	Source   VarsSource
+}
+
+func (a *VarsSourceArg) UnmarshalFlag(data string) error {
+	pieces := strings.SplitN(data, "=", 2)
+	if len(pieces) != 2 {
+		return bosherr.Errorf("Expected vars source '%s' to be in format 'type=location'", data)
+	}
+
+	if len(pieces[1]) == 0 {
+		return bosherr.Errorf("Expected vars source '%s' to specify non-empty location", data)
+	}
+
+	switch pieces[0] {
+	case "http":
+		client := boshhttp.CreateDefaultClient(nil)
+		(*a).Source = NewVarsHTTPSource(pieces[1], os.Getenv("BOSH_VARS_SOURCE_TOKEN"), client)
+
+	case "dir":
+		absPath, err := a.FS.ExpandPath(pieces[1])
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Getting absolute path '%s'", pieces[1])
+		}
+
+		(*a).Source = NewVarsDirSource(absPath, a.FS)
+
+	case "exec":
+		absPath, err := a.FS.ExpandPath(pieces[1])
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Getting absolute path '%s'", pieces[1])
+		}
+
+		(*a).Source = NewVarsExecSource(absPath, a.CmdRunner)
+
+	default:
+		return bosherr.Errorf("Expected vars source '%s' to be of type 'http', 'dir' or 'exec'", data)
+	}
+
+	(*a).Type = pieces[0]
+	(*a).Location = pieces[1]
+
+	return nil
+}
+
+func (a VarsSourceArg) description() string {
+	return "--vars-source " + a.Type + "=" + a.Location
+}
@@ -0,0 +1,71 @@
+package cmd_test
+
+import (
+	"errors"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+)
+
+var _ = Describe("VarsSourceArg", func() {
+	Describe("UnmarshalFlag", func() {
+		var (
+			fs  *fakesys.FakeFileSystem
+			arg VarsSourceArg
+		)
+
+		BeforeEach(func() {
+			fs = fakesys.NewFakeFileSystem()
+			arg = VarsSourceArg{FS: fs, CmdRunner: fakesys.NewFakeCmdRunner()}
+		})
+
+		It("configures http source", func() {
+			err := (&arg).UnmarshalFlag("http=https://vars.example.com/v1/")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(arg.Source).To(BeAssignableToTypeOf(VarsHTTPSource{}))
+			Expect(arg.Type).To(Equal("http"))
+			Expect(arg.Location).To(Equal("https://vars.example.com/v1/"))
+		})
+
+		It("configures dir source", func() {
+			err := (&arg).UnmarshalFlag("dir=/vars")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(arg.Source).To(Equal(NewVarsDirSource("/vars", fs)))
+		})
+
+		It("configures exec source", func() {
+			err := (&arg).UnmarshalFlag("exec=/plugin")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(arg.Source).To(Equal(NewVarsExecSource("/plugin", arg.CmdRunner)))
+		})
+
+		It("returns an error if expanding path fails", func() {
+			fs.ExpandPathErr = errors.New("fake-err")
+
+			err := (&arg).UnmarshalFlag("dir=/vars")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+
+		It("returns an error if type is not known", func() {
+			err := (&arg).UnmarshalFlag("unknown=/vars")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected vars source 'unknown=/vars' to be of type 'http', 'dir' or 'exec'"))
+		})
+
+		It("returns an error if location is empty", func() {
+			err := (&arg).UnmarshalFlag("dir=")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected vars source 'dir=' to specify non-empty location"))
+		})
+
+		It("returns an error if format is wrong", func() {
+			err := (&arg).UnmarshalFlag("dir")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected vars source 'dir' to be in format 'type=location'"))
+		})
+	})
+})
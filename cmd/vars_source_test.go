@@ -0,0 +1,83 @@
+package cmd_test
+
+import (
+	"errors"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	cfgtypes "github.com/cloudfoundry/config-server/types"
+	fakecfgtypes "github.com/cloudfoundry/config-server/types/typesfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+var _ = Describe("VarsSourceGenerator", func() {
+	var (
+		fs        *fakesys.FakeFileSystem
+		generator VarsSourceGenerator
+	)
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+		generator = VarsSourceGenerator{
+			Source:                NewVarsDirSource("/vars", fs),
+			ValueGeneratorFactory: cfgtypes.NewValueGeneratorConcrete(nil),
+		}
+	})
+
+	Describe("Get", func() {
+		It("returns nil and not found if variable type is not available", func() {
+			val, found, err := generator.Get(boshtpl.VariableDefinition{Name: "name"})
+			Expect(val).To(BeNil())
+			Expect(found).To(BeFalse())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("generates value and saves it to the source", func() {
+			val, found, err := generator.Get(boshtpl.VariableDefinition{Name: "name", Type: "password"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(len(val.(string))).To(BeNumerically(">", 10))
+
+			Expect(fs.ReadFileString("/vars/name")).To(Equal(val.(string) + "\n"))
+		})
+
+		It("returns error if variable type is not known", func() {
+			_, _, err := generator.Get(boshtpl.VariableDefinition{Name: "name", Type: "unknown"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Generating variable 'name': Unsupported value type: unknown"))
+		})
+
+		It("returns error if generating value fails", func() {
+			valGenerator := &fakecfgtypes.FakeValueGenerator{}
+			valGenerator.GenerateReturns(nil, errors.New("fake-err"))
+
+			factory := &fakecfgtypes.FakeValueGeneratorFactory{}
+			factory.GetGeneratorReturns(valGenerator, nil)
+
+			generator.ValueGeneratorFactory = factory
+
+			_, _, err := generator.Get(boshtpl.VariableDefinition{Name: "name", Type: "type"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Generating variable 'name': fake-err"))
+		})
+
+		It("returns error if saving value fails", func() {
+			fs.WriteFileError = errors.New("fake-err")
+
+			_, _, err := generator.Get(boshtpl.VariableDefinition{Name: "name", Type: "password"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+
+	Describe("List", func() {
+		It("returns no variables", func() {
+			defs, err := generator.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(defs).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	"github.com/cloudfoundry/bosh-cli/crypto"
+)
+
+type VarsStoreKeyArg struct {
+	FS boshsys.FileSystem
+
+	Passphrase []byte
+}
+
+func (a *VarsStoreKeyArg) UnmarshalFlag(data string) error {
+	if len(data) == 0 {
+		return bosherr.Errorf("Expected file path to be non-empty")
+	}
+
+	absPath, err := a.FS.ExpandPath(data)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Getting absolute path '%s'", data)
+	}
+
+	bytes, err := a.FS.ReadFile(absPath)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Reading vars store key '%s'", absPath)
+	}
+
+	passphrase := strings.TrimSpace(string(bytes))
+	if len(passphrase) == 0 {
+		return bosherr.Errorf("Expected vars store key '%s' to be non-empty", absPath)
+	}
+
+	(*a).Passphrase = []byte(passphrase)
+
+	return nil
+}
+
+// Encryptor falls back to a passphrase from given environment variable
+// and returns nil when no key is configured
+func (a VarsStoreKeyArg) Encryptor(envName string) crypto.Encryptor {
+	passphrase := a.Passphrase
+
+	if len(passphrase) == 0 {
+		passphrase = []byte(os.Getenv(envName))
+	}
+
+	if len(passphrase) == 0 {
+		return nil
+	}
+
+	return crypto.NewPassphraseEncryptor(passphrase)
+}
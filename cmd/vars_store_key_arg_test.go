@@ -0,0 +1,74 @@
+package cmd_test
+
+import (
+	"errors"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	"github.com/cloudfoundry/bosh-cli/crypto"
+)
+
+var _ = Describe("VarsStoreKeyArg", func() {
+	var (
+		fs  *fakesys.FakeFileSystem
+		arg VarsStoreKeyArg
+	)
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+		arg = VarsStoreKeyArg{FS: fs}
+	})
+
+	Describe("UnmarshalFlag", func() {
+		It("sets passphrase from file without surrounding whitespace", func() {
+			fs.WriteFileString("/key", "passphrase\n")
+
+			err := (&arg).UnmarshalFlag("/key")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(arg.Passphrase).To(Equal([]byte("passphrase")))
+		})
+
+		It("returns an error if path is empty", func() {
+			err := (&arg).UnmarshalFlag("")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected file path to be non-empty"))
+		})
+
+		It("returns an error if file is empty", func() {
+			fs.WriteFileString("/key", "\n")
+
+			err := (&arg).UnmarshalFlag("/key")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected vars store key '/key' to be non-empty"))
+		})
+
+		It("returns an error if reading file fails", func() {
+			fs.WriteFileString("/key", "passphrase")
+			fs.ReadFileError = errors.New("fake-err")
+
+			err := (&arg).UnmarshalFlag("/key")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+
+	Describe("Encryptor", func() {
+		It("returns encryptor for configured passphrase", func() {
+			arg.Passphrase = []byte("passphrase")
+
+			ciphertext, err := arg.Encryptor("BOSH_CLI_TEST_UNSET_PASSPHRASE").Encrypt([]byte("data"))
+			Expect(err).ToNot(HaveOccurred())
+
+			plaintext, err := crypto.NewPassphraseEncryptor([]byte("passphrase")).Decrypt(ciphertext)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plaintext).To(Equal([]byte("data")))
+		})
+
+		It("returns nil if passphrase is not configured", func() {
+			Expect(arg.Encryptor("BOSH_CLI_TEST_UNSET_PASSPHRASE")).To(BeNil())
+		})
+	})
+})
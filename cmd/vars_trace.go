@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	boshtpl "github.com/cloudfoundry/bosh-cli/director/template"
+)
+
+// VarsTrace records which flags resolved variables
+type VarsTrace struct {
+	flags   VarFlags
+	sources []string
+
+	Found []VarsTraceEntry
+}
+
+type VarsTraceEntry struct {
+	Name   string
+	Source string
+}
+
+var _ boshtpl.VarsTracer = &VarsTrace{}
+
+func (t *VarsTrace) VarFound(varDef boshtpl.VariableDefinition, idx int) {
+	for _, entry := range t.Found {
+		if entry.Name == varDef.Name {
+			return
+		}
+	}
+
+	source := t.sources[idx]
+
+	if idx == 0 {
+		source = t.staticSource(varDef.Name)
+	}
+
+	t.Found = append(t.Found, VarsTraceEntry{Name: varDef.Name, Source: source})
+}
+
+// staticSource follows precedence used when merging static variables
+func (t *VarsTrace) staticSource(name string) string {
+	for _, kv := range t.flags.VarKVs {
+		if kv.Name == name {
+			return "--var"
+		}
+	}
+
+	for i := len(t.flags.VarFiles) - 1; i >= 0; i-- {
+		if _, found := t.flags.VarFiles[i].Vars[name]; found {
+			return "--var-file"
+		}
+	}
+
+	for i := len(t.flags.VarsFiles) - 1; i >= 0; i-- {
+		if _, found := t.flags.VarsFiles[i].Vars[name]; found {
+			return "--vars-file " + t.flags.VarsFiles[i].FilePath
+		}
+	}
+
+	return "--vars-env"
+}
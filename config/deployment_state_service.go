@@ -15,6 +15,7 @@ type DeploymentState struct {
 	Disks              []DiskRecord     `json:"disks"`
 	Stemcells          []StemcellRecord `json:"stemcells"`
 	Releases           []ReleaseRecord  `json:"releases"`
+	Instances          []InstanceRecord `json:"instances,omitempty"`
 }
 
 type StemcellRecord struct {
@@ -31,6 +32,16 @@ type DiskRecord struct {
 	CloudProperties biproperty.Map `json:"cloud_properties"`
 }
 
+// InstanceRecord tracks VM and disk of an instance other than the first instance
+// of the first job, which uses current VM and disk for compatibility with single instance deployments
+type InstanceRecord struct {
+	Name   string `json:"name"`
+	Index  int    `json:"index"`
+	IP     string `json:"ip"`
+	VMCID  string `json:"vm_cid"`
+	DiskID string `json:"disk_id"`
+}
+
 type ReleaseRecord struct {
 	ID      string `json:"id"`
 	Name    string `json:"name"`
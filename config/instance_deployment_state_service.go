@@ -0,0 +1,173 @@
+package config
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+// instanceDeploymentStateService presents deployment state from the point of view
+// of a single instance: current VM and disk belong to that instance and
+// disks of other instances are hidden so that they are never considered unused.
+type instanceDeploymentStateService struct {
+	parent  DeploymentStateService
+	primary bool
+	name    string
+	index   int
+	ip      string
+}
+
+func NewPrimaryInstanceDeploymentStateService(parent DeploymentStateService) DeploymentStateService {
+	return instanceDeploymentStateService{parent: parent, primary: true}
+}
+
+func NewInstanceDeploymentStateService(parent DeploymentStateService, name string, index int, ip string) DeploymentStateService {
+	return instanceDeploymentStateService{parent: parent, name: name, index: index, ip: ip}
+}
+
+func (s instanceDeploymentStateService) Path() string {
+	return s.parent.Path()
+}
+
+func (s instanceDeploymentStateService) Exists() bool {
+	return s.parent.Exists()
+}
+
+func (s instanceDeploymentStateService) Cleanup() error {
+	return s.parent.Cleanup()
+}
+
+func (s instanceDeploymentStateService) Load() (DeploymentState, error) {
+	deploymentState, err := s.parent.Load()
+	if err != nil {
+		return DeploymentState{}, err
+	}
+
+	otherDiskIDs := s.otherDiskIDs(deploymentState)
+
+	if !s.primary {
+		record, _ := s.findRecord(deploymentState)
+		deploymentState.CurrentVMCID = record.VMCID
+		deploymentState.CurrentDiskID = record.DiskID
+	}
+
+	if len(otherDiskIDs) > 0 {
+		disks := []DiskRecord{}
+
+		for _, disk := range deploymentState.Disks {
+			if !otherDiskIDs[disk.ID] {
+				disks = append(disks, disk)
+			}
+		}
+
+		deploymentState.Disks = disks
+	}
+
+	deploymentState.Instances = nil
+
+	return deploymentState, nil
+}
+
+func (s instanceDeploymentStateService) Save(instanceState DeploymentState) error {
+	deploymentState, err := s.parent.Load()
+	if err != nil {
+		return bosherr.WrapError(err, "Loading deployment state")
+	}
+
+	disks := instanceState.Disks
+
+	// Keep disks of other instances which were hidden from this instance
+	if otherDiskIDs := s.otherDiskIDs(deploymentState); len(otherDiskIDs) > 0 {
+		disks = []DiskRecord{}
+
+		for _, disk := range deploymentState.Disks {
+			if otherDiskIDs[disk.ID] {
+				disks = append(disks, disk)
+			}
+		}
+
+		disks = append(disks, instanceState.Disks...)
+	}
+
+	instances := deploymentState.Instances
+
+	if s.primary {
+		deploymentState.CurrentVMCID = instanceState.CurrentVMCID
+		deploymentState.CurrentDiskID = instanceState.CurrentDiskID
+	} else {
+		instances = s.updateRecords(deploymentState, instanceState.CurrentVMCID, instanceState.CurrentDiskID)
+	}
+
+	instanceState.CurrentVMCID = deploymentState.CurrentVMCID
+	instanceState.CurrentDiskID = deploymentState.CurrentDiskID
+	instanceState.Disks = disks
+	instanceState.Instances = instances
+
+	return s.parent.Save(instanceState)
+}
+
+func (s instanceDeploymentStateService) findRecord(deploymentState DeploymentState) (InstanceRecord, bool) {
+	for _, record := range deploymentState.Instances {
+		if record.Name == s.name && record.Index == s.index {
+			return record, true
+		}
+	}
+
+	return InstanceRecord{}, false
+}
+
+// updateRecords drops instance record once instance has neither VM nor disk
+func (s instanceDeploymentStateService) updateRecords(deploymentState DeploymentState, vmCID, diskID string) []InstanceRecord {
+	var records []InstanceRecord
+
+	keep := len(vmCID) > 0 || len(diskID) > 0
+	found := false
+
+	for _, record := range deploymentState.Instances {
+		if record.Name == s.name && record.Index == s.index {
+			found = true
+
+			if !keep {
+				continue
+			}
+
+			record.VMCID = vmCID
+			record.DiskID = diskID
+
+			if len(s.ip) > 0 {
+				record.IP = s.ip
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	if keep && !found {
+		records = append(records, InstanceRecord{
+			Name:   s.name,
+			Index:  s.index,
+			IP:     s.ip,
+			VMCID:  vmCID,
+			DiskID: diskID,
+		})
+	}
+
+	return records
+}
+
+func (s instanceDeploymentStateService) otherDiskIDs(deploymentState DeploymentState) map[string]bool {
+	ids := map[string]bool{}
+
+	if !s.primary && len(deploymentState.CurrentDiskID) > 0 {
+		ids[deploymentState.CurrentDiskID] = true
+	}
+
+	for _, record := range deploymentState.Instances {
+		if !s.primary && record.Name == s.name && record.Index == s.index {
+			continue
+		}
+		if len(record.DiskID) > 0 {
+			ids[record.DiskID] = true
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,141 @@
+package config_test
+
+import (
+	. "github.com/cloudfoundry/bosh-cli/config"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InstanceDeploymentStateService", func() {
+	var (
+		deploymentStateService DeploymentStateService
+		fakeUUIDGenerator      *fakeuuid.FakeGenerator
+	)
+
+	BeforeEach(func() {
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		fs := fakesys.NewFakeFileSystem()
+		fakeUUIDGenerator = &fakeuuid.FakeGenerator{}
+		deploymentStateService = NewFileSystemDeploymentStateService(fs, fakeUUIDGenerator, logger, "/fake/path")
+
+		err := deploymentStateService.Save(DeploymentState{
+			DirectorID:    "fake-director-id",
+			CurrentVMCID:  "primary-vm-cid",
+			CurrentDiskID: "primary-disk-id",
+			Disks: []DiskRecord{
+				{ID: "primary-disk-id", CID: "primary-disk-cid"},
+				{ID: "other-disk-id", CID: "other-disk-cid"},
+				{ID: "orphan-disk-id", CID: "orphan-disk-cid"},
+			},
+			Instances: []InstanceRecord{
+				{Name: "other", Index: 1, IP: "10.0.0.7", VMCID: "other-vm-cid", DiskID: "other-disk-id"},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("primary instance", func() {
+		var instanceStateService DeploymentStateService
+
+		BeforeEach(func() {
+			instanceStateService = NewPrimaryInstanceDeploymentStateService(deploymentStateService)
+		})
+
+		It("uses current VM and disk and hides disks of other instances", func() {
+			instanceState, err := instanceStateService.Load()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(instanceState.CurrentVMCID).To(Equal("primary-vm-cid"))
+			Expect(instanceState.CurrentDiskID).To(Equal("primary-disk-id"))
+			Expect(instanceState.Disks).To(Equal([]DiskRecord{
+				{ID: "primary-disk-id", CID: "primary-disk-cid"},
+				{ID: "orphan-disk-id", CID: "orphan-disk-cid"},
+			}))
+			Expect(instanceState.Instances).To(BeNil())
+		})
+
+		It("keeps disks and records of other instances when saving", func() {
+			err := NewVMRepo(instanceStateService).ClearCurrent()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = NewDiskRepo(instanceStateService, fakeUUIDGenerator).Delete(DiskRecord{ID: "orphan-disk-id"})
+			Expect(err).ToNot(HaveOccurred())
+
+			deploymentState, err := deploymentStateService.Load()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(deploymentState.CurrentVMCID).To(BeEmpty())
+			Expect(deploymentState.CurrentDiskID).To(Equal("primary-disk-id"))
+			Expect(deploymentState.Disks).To(ConsistOf(
+				DiskRecord{ID: "primary-disk-id", CID: "primary-disk-cid"},
+				DiskRecord{ID: "other-disk-id", CID: "other-disk-cid"},
+			))
+			Expect(deploymentState.Instances).To(Equal([]InstanceRecord{
+				{Name: "other", Index: 1, IP: "10.0.0.7", VMCID: "other-vm-cid", DiskID: "other-disk-id"},
+			}))
+		})
+	})
+
+	Describe("other instance", func() {
+		It("uses VM and disk from instance record and hides disks of other instances", func() {
+			instanceState, err := NewInstanceDeploymentStateService(deploymentStateService, "other", 1, "").Load()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(instanceState.DirectorID).To(Equal("fake-director-id"))
+			Expect(instanceState.CurrentVMCID).To(Equal("other-vm-cid"))
+			Expect(instanceState.CurrentDiskID).To(Equal("other-disk-id"))
+			Expect(instanceState.Disks).To(Equal([]DiskRecord{
+				{ID: "other-disk-id", CID: "other-disk-cid"},
+				{ID: "orphan-disk-id", CID: "orphan-disk-cid"},
+			}))
+		})
+
+		It("records VM and disk of a new instance", func() {
+			instanceStateService := NewInstanceDeploymentStateService(deploymentStateService, "new", 0, "10.0.0.8")
+
+			err := NewVMRepo(instanceStateService).UpdateCurrent("new-vm-cid")
+			Expect(err).ToNot(HaveOccurred())
+
+			err = NewDiskRepo(instanceStateService, fakeUUIDGenerator).UpdateCurrent("orphan-disk-id")
+			Expect(err).ToNot(HaveOccurred())
+
+			deploymentState, err := deploymentStateService.Load()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(deploymentState.CurrentVMCID).To(Equal("primary-vm-cid"))
+			Expect(deploymentState.CurrentDiskID).To(Equal("primary-disk-id"))
+			Expect(deploymentState.Disks).To(HaveLen(3))
+			Expect(deploymentState.Instances).To(Equal([]InstanceRecord{
+				{Name: "other", Index: 1, IP: "10.0.0.7", VMCID: "other-vm-cid", DiskID: "other-disk-id"},
+				{Name: "new", Index: 0, IP: "10.0.0.8", VMCID: "new-vm-cid", DiskID: "orphan-disk-id"},
+			}))
+		})
+
+		It("drops instance record once instance has neither VM nor disk", func() {
+			instanceStateService := NewInstanceDeploymentStateService(deploymentStateService, "other", 1, "")
+
+			err := NewVMRepo(instanceStateService).ClearCurrent()
+			Expect(err).ToNot(HaveOccurred())
+
+			deploymentState, err := deploymentStateService.Load()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deploymentState.Instances).To(Equal([]InstanceRecord{
+				{Name: "other", Index: 1, IP: "10.0.0.7", DiskID: "other-disk-id"},
+			}))
+
+			err = NewDiskRepo(instanceStateService, fakeUUIDGenerator).Delete(DiskRecord{ID: "other-disk-id"})
+			Expect(err).ToNot(HaveOccurred())
+
+			deploymentState, err = deploymentStateService.Load()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deploymentState.Instances).To(BeEmpty())
+			Expect(deploymentState.Disks).To(ConsistOf(
+				DiskRecord{ID: "primary-disk-id", CID: "primary-disk-cid"},
+				DiskRecord{ID: "orphan-disk-id", CID: "orphan-disk-cid"},
+			))
+		})
+	})
+})
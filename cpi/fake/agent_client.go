@@ -0,0 +1,170 @@
+package fake
+
+import (
+	"net/url"
+
+	biagentclient "github.com/cloudfoundry/bosh-agent/agentclient"
+	"github.com/cloudfoundry/bosh-agent/agentclient/applyspec"
+	bihttpagent "github.com/cloudfoundry/bosh-agent/agentclient/http"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+)
+
+type agentClient struct {
+	store  *Store
+	ip     string
+	logTag string
+}
+
+// NewAgentClient returns stub agent of the fake CPI VM with given IP
+func NewAgentClient(store *Store, ip string) biagentclient.AgentClient {
+	return agentClient{
+		store:  store,
+		ip:     ip,
+		logTag: "fakeAgentClient",
+	}
+}
+
+type agentClientFactory struct {
+	store *Store
+}
+
+// NewAgentClientFactory returns factory of stub agents reached via mbus URL host
+func NewAgentClientFactory(store *Store) bihttpagent.AgentClientFactory {
+	return agentClientFactory{store: store}
+}
+
+func (f agentClientFactory) NewAgentClient(directorID, mbusURL, caCert string) (biagentclient.AgentClient, error) {
+	parsedURL, err := url.Parse(mbusURL)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Parsing mbus URL")
+	}
+
+	return NewAgentClient(f.store, parsedURL.Hostname()), nil
+}
+
+func (c agentClient) Ping() (string, error) {
+	return "pong", c.withVM(func(vm *vmRecord) error { return nil })
+}
+
+func (c agentClient) Stop() error {
+	return c.withVM(func(vm *vmRecord) error {
+		vm.Agent.JobState = "stopped"
+		return nil
+	})
+}
+
+func (c agentClient) Apply(spec applyspec.ApplySpec) error {
+	return c.withVM(func(vm *vmRecord) error {
+		vm.Agent.ApplySpec = &spec
+		return nil
+	})
+}
+
+func (c agentClient) Start() error {
+	return c.withVM(func(vm *vmRecord) error {
+		vm.Agent.JobState = "running"
+		return nil
+	})
+}
+
+func (c agentClient) GetState() (biagentclient.AgentState, error) {
+	agentState := biagentclient.AgentState{}
+
+	err := c.withVM(func(vm *vmRecord) error {
+		agentState.JobState = vm.Agent.JobState
+		agentState.NetworkSpecs = map[string]biagentclient.NetworkSpec{}
+
+		if vm.Agent.ApplySpec != nil {
+			for name, network := range vm.Agent.ApplySpec.Networks {
+				networkSpec := biagentclient.NetworkSpec{}
+				if networkMap, ok := network.(map[string]interface{}); ok {
+					networkSpec.IP, _ = networkMap["ip"].(string)
+				}
+				agentState.NetworkSpecs[name] = networkSpec
+			}
+		}
+
+		return nil
+	})
+
+	return agentState, err
+}
+
+func (c agentClient) MountDisk(diskCID string) error {
+	return c.store.update(func(state *storeState) error {
+		vm, err := c.findVM(state)
+		if err != nil {
+			return err
+		}
+
+		disk, found := state.findDisk(diskCID)
+		if !found || disk.VMCID != vm.CID {
+			return bosherr.Errorf("Disk '%s' is not attached to VM '%s'", diskCID, vm.CID)
+		}
+
+		vm.Agent.MountedDiskCIDs = append(withoutString(vm.Agent.MountedDiskCIDs, diskCID), diskCID)
+
+		return nil
+	})
+}
+
+func (c agentClient) UnmountDisk(diskCID string) error {
+	return c.withVM(func(vm *vmRecord) error {
+		vm.Agent.MountedDiskCIDs = withoutString(vm.Agent.MountedDiskCIDs, diskCID)
+		return nil
+	})
+}
+
+func (c agentClient) ListDisk() ([]string, error) {
+	diskCIDs := []string{}
+
+	err := c.withVM(func(vm *vmRecord) error {
+		diskCIDs = append(diskCIDs, vm.Agent.MountedDiskCIDs...)
+		return nil
+	})
+
+	return diskCIDs, err
+}
+
+// MigrateDisk has nothing to copy since fake disks do not hold data
+func (c agentClient) MigrateDisk() error {
+	return c.withVM(func(vm *vmRecord) error { return nil })
+}
+
+// CompilePackage does not compile anything and returns package source as compiled package
+func (c agentClient) CompilePackage(packageSource biagentclient.BlobRef, compiledPackageDependencies []biagentclient.BlobRef) (biagentclient.BlobRef, error) {
+	return packageSource, c.withVM(func(vm *vmRecord) error { return nil })
+}
+
+func (c agentClient) DeleteARPEntries(ips []string) error {
+	return c.withVM(func(vm *vmRecord) error { return nil })
+}
+
+func (c agentClient) SyncDNS(blobID, sha1 string, version uint64) (string, error) {
+	return "synced", c.withVM(func(vm *vmRecord) error { return nil })
+}
+
+func (c agentClient) RunScript(scriptName string, options map[string]interface{}) error {
+	c.store.logger.Debug(c.logTag, "Skipping script '%s' on VM with IP '%s'", scriptName, c.ip)
+	return c.withVM(func(vm *vmRecord) error { return nil })
+}
+
+func (c agentClient) withVM(fn func(*vmRecord) error) error {
+	return c.store.update(func(state *storeState) error {
+		vm, err := c.findVM(state)
+		if err != nil {
+			return err
+		}
+
+		return fn(vm)
+	})
+}
+
+func (c agentClient) findVM(state *storeState) (*vmRecord, error) {
+	vm, found := state.findVMByIP(c.ip)
+	if !found {
+		return nil, bosherr.Errorf("Agent at '%s' is unreachable: no fake VM with this IP", c.ip)
+	}
+
+	return vm, nil
+}
@@ -0,0 +1,73 @@
+package fake
+
+import (
+	"net/http"
+	"path/filepath"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	biblobstore "github.com/cloudfoundry/bosh-cli/blobstore"
+)
+
+type blobstore struct {
+	store *Store
+}
+
+// NewBlobstore returns blobstore of stub agents which keeps blobs in store directory
+func NewBlobstore(store *Store) biblobstore.Blobstore {
+	return blobstore{store: store}
+}
+
+type blobstoreFactory struct {
+	store *Store
+}
+
+func NewBlobstoreFactory(store *Store) biblobstore.Factory {
+	return blobstoreFactory{store: store}
+}
+
+func (f blobstoreFactory) Create(mbusURL string, _ *http.Client) (biblobstore.Blobstore, error) {
+	return NewBlobstore(f.store), nil
+}
+
+func (b blobstore) Get(blobID string) (biblobstore.LocalBlob, error) {
+	fs := b.store.fs
+
+	file, err := fs.TempFile("bosh-init-local-blob")
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Creating temporary file")
+	}
+
+	localPath := file.Name()
+
+	err = file.Close()
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Closing temporary file '%s'", localPath)
+	}
+
+	err = fs.CopyFile(filepath.Join(b.store.blobsPath(), blobID), localPath)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Getting blob '%s'", blobID)
+	}
+
+	return biblobstore.NewLocalBlob(localPath, fs, b.store.logger), nil
+}
+
+func (b blobstore) Add(sourcePath string) (string, error) {
+	blobID, err := b.store.uuidGenerator.Generate()
+	if err != nil {
+		return "", bosherr.WrapError(err, "Generating blob ID")
+	}
+
+	err = b.store.fs.MkdirAll(b.store.blobsPath(), 0700)
+	if err != nil {
+		return "", bosherr.WrapError(err, "Creating blobs directory")
+	}
+
+	err = b.store.fs.CopyFile(sourcePath, filepath.Join(b.store.blobsPath(), blobID))
+	if err != nil {
+		return "", bosherr.WrapErrorf(err, "Adding blob '%s'", sourcePath)
+	}
+
+	return blobID, nil
+}
@@ -0,0 +1,323 @@
+package fake
+
+import (
+	"fmt"
+	"path/filepath"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+
+	bicloud "github.com/cloudfoundry/bosh-cli/cloud"
+	biinstall "github.com/cloudfoundry/bosh-cli/installation"
+)
+
+const cloudError = "Bosh::Clouds::CloudError"
+
+type cloud struct {
+	store      *Store
+	directorID string
+	logTag     string
+}
+
+// NewCloud returns in-process CPI which keeps VMs, disks and stemcells in store
+func NewCloud(store *Store, directorID string) bicloud.Cloud {
+	return cloud{
+		store:      store,
+		directorID: directorID,
+		logTag:     "fakeCloud",
+	}
+}
+
+type cloudFactory struct {
+	store *Store
+}
+
+// NewCloudFactory returns factory which ignores installed CPI and always uses fake cloud
+func NewCloudFactory(store *Store) bicloud.Factory {
+	return cloudFactory{store: store}
+}
+
+func (f cloudFactory) NewCloud(_ biinstall.Installation, directorID string) (bicloud.Cloud, error) {
+	return NewCloud(f.store, directorID), nil
+}
+
+func (c cloud) CreateStemcell(imagePath string, cloudProperties biproperty.Map) (string, error) {
+	c.store.logger.Debug(c.logTag, "Creating stemcell from '%s'", imagePath)
+
+	var cid string
+
+	err := c.store.update(func(state *storeState) error {
+		var err error
+
+		cid, err = c.store.newCID("stemcell")
+		if err != nil {
+			return err
+		}
+
+		storedImagePath := filepath.Join(c.store.stemcellsPath(), cid)
+
+		err = c.store.fs.MkdirAll(c.store.stemcellsPath(), 0700)
+		if err != nil {
+			return bosherr.WrapError(err, "Creating stemcells directory")
+		}
+
+		err = c.store.fs.CopyFile(imagePath, storedImagePath)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Copying stemcell image '%s'", imagePath)
+		}
+
+		state.Stemcells = append(state.Stemcells, stemcellRecord{CID: cid, ImagePath: storedImagePath})
+
+		return nil
+	})
+
+	return cid, err
+}
+
+func (c cloud) DeleteStemcell(stemcellCID string) error {
+	c.store.logger.Debug(c.logTag, "Deleting stemcell '%s'", stemcellCID)
+
+	return c.store.update(func(state *storeState) error {
+		stemcell, found := state.findStemcell(stemcellCID)
+		if !found {
+			return c.notFoundError("delete_stemcell", bicloud.StemcellNotFoundError, "Stemcell", stemcellCID)
+		}
+
+		err := c.store.fs.RemoveAll(stemcell.ImagePath)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Deleting stemcell image '%s'", stemcell.ImagePath)
+		}
+
+		stemcells := []stemcellRecord{}
+		for _, record := range state.Stemcells {
+			if record.CID != stemcellCID {
+				stemcells = append(stemcells, record)
+			}
+		}
+		state.Stemcells = stemcells
+
+		return nil
+	})
+}
+
+func (c cloud) HasVM(vmCID string) (bool, error) {
+	var found bool
+
+	err := c.store.update(func(state *storeState) error {
+		_, found = state.findVM(vmCID)
+		return nil
+	})
+
+	return found, err
+}
+
+func (c cloud) CreateVM(
+	agentID string,
+	stemcellCID string,
+	cloudProperties biproperty.Map,
+	networksInterfaces map[string]biproperty.Map,
+	env biproperty.Map,
+) (string, error) {
+	c.store.logger.Debug(c.logTag, "Creating VM for agent '%s' from stemcell '%s'", agentID, stemcellCID)
+
+	var cid string
+
+	err := c.store.update(func(state *storeState) error {
+		_, found := state.findStemcell(stemcellCID)
+		if !found {
+			return c.error("create_vm", cloudError, fmt.Sprintf("Stemcell '%s' not found", stemcellCID))
+		}
+
+		var err error
+
+		cid, err = c.store.newCID("vm")
+		if err != nil {
+			return err
+		}
+
+		ips := []string{}
+		for _, networkInterface := range networksInterfaces {
+			if ip, ok := networkInterface["ip"].(string); ok {
+				ips = append(ips, ip)
+			}
+		}
+
+		state.VMs = append(state.VMs, vmRecord{
+			CID:         cid,
+			AgentID:     agentID,
+			StemcellCID: stemcellCID,
+			IPs:         ips,
+			Agent:       agentRecord{MountedDiskCIDs: []string{}},
+		})
+
+		return nil
+	})
+
+	return cid, err
+}
+
+func (c cloud) SetVMMetadata(vmCID string, metadata bicloud.VMMetadata) error {
+	return c.store.update(func(state *storeState) error {
+		vm, found := state.findVM(vmCID)
+		if !found {
+			return c.notFoundError("set_vm_metadata", bicloud.VMNotFoundError, "VM", vmCID)
+		}
+
+		vm.Metadata = metadata
+
+		return nil
+	})
+}
+
+func (c cloud) SetDiskMetadata(diskCID string, metadata bicloud.DiskMetadata) error {
+	return c.store.update(func(state *storeState) error {
+		disk, found := state.findDisk(diskCID)
+		if !found {
+			return c.notFoundError("set_disk_metadata", bicloud.DiskNotFoundError, "Disk", diskCID)
+		}
+
+		disk.Metadata = metadata
+
+		return nil
+	})
+}
+
+func (c cloud) DeleteVM(vmCID string) error {
+	c.store.logger.Debug(c.logTag, "Deleting VM '%s'", vmCID)
+
+	return c.store.update(func(state *storeState) error {
+		_, found := state.findVM(vmCID)
+		if !found {
+			return c.notFoundError("delete_vm", bicloud.VMNotFoundError, "VM", vmCID)
+		}
+
+		for i := range state.Disks {
+			if state.Disks[i].VMCID == vmCID {
+				state.Disks[i].VMCID = ""
+			}
+		}
+
+		vms := []vmRecord{}
+		for _, record := range state.VMs {
+			if record.CID != vmCID {
+				vms = append(vms, record)
+			}
+		}
+		state.VMs = vms
+
+		return nil
+	})
+}
+
+func (c cloud) CreateDisk(size int, cloudProperties biproperty.Map, vmCID string) (string, error) {
+	c.store.logger.Debug(c.logTag, "Creating disk of size %d near VM '%s'", size, vmCID)
+
+	var cid string
+
+	err := c.store.update(func(state *storeState) error {
+		var err error
+
+		cid, err = c.store.newCID("disk")
+		if err != nil {
+			return err
+		}
+
+		state.Disks = append(state.Disks, diskRecord{CID: cid, Size: size})
+
+		return nil
+	})
+
+	return cid, err
+}
+
+func (c cloud) AttachDisk(vmCID, diskCID string) error {
+	c.store.logger.Debug(c.logTag, "Attaching disk '%s' to VM '%s'", diskCID, vmCID)
+
+	return c.store.update(func(state *storeState) error {
+		_, found := state.findVM(vmCID)
+		if !found {
+			return c.notFoundError("attach_disk", bicloud.VMNotFoundError, "VM", vmCID)
+		}
+
+		disk, found := state.findDisk(diskCID)
+		if !found {
+			return c.notFoundError("attach_disk", bicloud.DiskNotFoundError, "Disk", diskCID)
+		}
+
+		if len(disk.VMCID) > 0 && disk.VMCID != vmCID {
+			return c.error("attach_disk", cloudError, fmt.Sprintf("Disk '%s' is already attached to VM '%s'", diskCID, disk.VMCID))
+		}
+
+		disk.VMCID = vmCID
+
+		return nil
+	})
+}
+
+func (c cloud) DetachDisk(vmCID, diskCID string) error {
+	c.store.logger.Debug(c.logTag, "Detaching disk '%s' from VM '%s'", diskCID, vmCID)
+
+	return c.store.update(func(state *storeState) error {
+		vm, found := state.findVM(vmCID)
+		if !found {
+			return c.notFoundError("detach_disk", bicloud.VMNotFoundError, "VM", vmCID)
+		}
+
+		disk, found := state.findDisk(diskCID)
+		if !found || disk.VMCID != vmCID {
+			return c.notFoundError("detach_disk", bicloud.DiskNotFoundError, "Disk", diskCID)
+		}
+
+		disk.VMCID = ""
+		vm.Agent.MountedDiskCIDs = withoutString(vm.Agent.MountedDiskCIDs, diskCID)
+
+		return nil
+	})
+}
+
+func (c cloud) DeleteDisk(diskCID string) error {
+	c.store.logger.Debug(c.logTag, "Deleting disk '%s'", diskCID)
+
+	return c.store.update(func(state *storeState) error {
+		disk, found := state.findDisk(diskCID)
+		if !found {
+			return c.notFoundError("delete_disk", bicloud.DiskNotFoundError, "Disk", diskCID)
+		}
+
+		if len(disk.VMCID) > 0 {
+			return c.error("delete_disk", cloudError, fmt.Sprintf("Disk '%s' is attached to VM '%s'", diskCID, disk.VMCID))
+		}
+
+		disks := []diskRecord{}
+		for _, record := range state.Disks {
+			if record.CID != diskCID {
+				disks = append(disks, record)
+			}
+		}
+		state.Disks = disks
+
+		return nil
+	})
+}
+
+func (c cloud) String() string {
+	return fmt.Sprintf("FakeCloud{Path=%s, DirectorID=%s}", c.store.dirPath, c.directorID)
+}
+
+func (c cloud) notFoundError(method, errorType, kind, cid string) error {
+	return c.error(method, errorType, fmt.Sprintf("%s '%s' not found", kind, cid))
+}
+
+func (c cloud) error(method, errorType, message string) error {
+	return bicloud.NewCPIError(method, bicloud.CmdError{Type: errorType, Message: message})
+}
+
+func withoutString(strs []string, str string) []string {
+	result := []string{}
+	for _, s := range strs {
+		if s != str {
+			result = append(result, s)
+		}
+	}
+	return result
+}
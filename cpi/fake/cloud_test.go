@@ -0,0 +1,173 @@
+package fake_test
+
+import (
+	. "github.com/cloudfoundry/bosh-cli/cpi/fake"
+
+	bicloud "github.com/cloudfoundry/bosh-cli/cloud"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cloud", func() {
+	var (
+		fs    *fakesys.FakeFileSystem
+		store *Store
+		cloud bicloud.Cloud
+	)
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		store = NewStore(fs, fakeuuid.NewFakeGenerator(), "/fake-cpi", logger)
+		cloud = NewCloud(store, "fake-director-id")
+
+		fs.WriteFileString("/stemcell/image", "fake-image")
+	})
+
+	expectCloudErrorType := func(err error, errorType string) {
+		Expect(err).To(HaveOccurred())
+		cloudErr, ok := err.(bicloud.Error)
+		Expect(ok).To(BeTrue())
+		Expect(cloudErr.Type()).To(Equal(errorType))
+	}
+
+	createVM := func(ip string) string {
+		stemcellCID, err := cloud.CreateStemcell("/stemcell/image", biproperty.Map{})
+		Expect(err).ToNot(HaveOccurred())
+
+		vmCID, err := cloud.CreateVM("fake-agent-id", stemcellCID, biproperty.Map{}, map[string]biproperty.Map{
+			"fake-network": biproperty.Map{"type": "manual", "ip": ip},
+		}, biproperty.Map{})
+		Expect(err).ToNot(HaveOccurred())
+
+		return vmCID
+	}
+
+	Describe("stemcells", func() {
+		It("copies stemcell image into directory", func() {
+			stemcellCID, err := cloud.CreateStemcell("/stemcell/image", biproperty.Map{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stemcellCID).To(Equal("stemcell-fake-uuid-0"))
+
+			Expect(fs.ReadFileString("/fake-cpi/stemcells/stemcell-fake-uuid-0")).To(Equal("fake-image"))
+		})
+
+		It("deletes stemcell image", func() {
+			stemcellCID, err := cloud.CreateStemcell("/stemcell/image", biproperty.Map{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = cloud.DeleteStemcell(stemcellCID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fs.FileExists("/fake-cpi/stemcells/stemcell-fake-uuid-0")).To(BeFalse())
+
+			err = cloud.DeleteStemcell(stemcellCID)
+			expectCloudErrorType(err, bicloud.StemcellNotFoundError)
+		})
+	})
+
+	Describe("VMs", func() {
+		It("creates VM from existing stemcell", func() {
+			vmCID := createVM("10.0.0.5")
+			Expect(vmCID).To(Equal("vm-fake-uuid-1"))
+
+			found, err := cloud.HasVM(vmCID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("returns an error when stemcell does not exist", func() {
+			_, err := cloud.CreateVM("fake-agent-id", "missing-stemcell-cid", biproperty.Map{}, map[string]biproperty.Map{}, biproperty.Map{})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Stemcell 'missing-stemcell-cid' not found"))
+		})
+
+		It("deletes VM", func() {
+			vmCID := createVM("10.0.0.5")
+
+			err := cloud.DeleteVM(vmCID)
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err := cloud.HasVM(vmCID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			err = cloud.DeleteVM(vmCID)
+			expectCloudErrorType(err, bicloud.VMNotFoundError)
+		})
+
+		It("keeps state across cloud instances using the same directory", func() {
+			vmCID := createVM("10.0.0.5")
+
+			otherStore := NewStore(fs, fakeuuid.NewFakeGenerator(), "/fake-cpi", boshlog.NewLogger(boshlog.LevelNone))
+			found, err := NewCloud(otherStore, "fake-director-id").HasVM(vmCID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+
+	Describe("disks", func() {
+		var vmCID string
+
+		BeforeEach(func() {
+			vmCID = createVM("10.0.0.5")
+		})
+
+		It("attaches and detaches disk", func() {
+			diskCID, err := cloud.CreateDisk(1024, biproperty.Map{}, vmCID)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = cloud.AttachDisk(vmCID, diskCID)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = cloud.DeleteDisk(diskCID)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is attached to VM"))
+
+			err = cloud.DetachDisk(vmCID, diskCID)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = cloud.DeleteDisk(diskCID)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = cloud.DeleteDisk(diskCID)
+			expectCloudErrorType(err, bicloud.DiskNotFoundError)
+		})
+
+		It("detaches disks of deleted VM", func() {
+			diskCID, err := cloud.CreateDisk(1024, biproperty.Map{}, vmCID)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = cloud.AttachDisk(vmCID, diskCID)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = cloud.DeleteVM(vmCID)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = cloud.DeleteDisk(diskCID)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns an error when attaching disk attached to another VM", func() {
+			otherVMCID := createVM("10.0.0.6")
+
+			diskCID, err := cloud.CreateDisk(1024, biproperty.Map{}, vmCID)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = cloud.AttachDisk(vmCID, diskCID)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = cloud.AttachDisk(otherVMCID, diskCID)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is already attached to VM"))
+		})
+
+		It("returns not found error when attaching missing disk", func() {
+			err := cloud.AttachDisk(vmCID, "missing-disk-cid")
+			expectCloudErrorType(err, bicloud.DiskNotFoundError)
+		})
+	})
+})
@@ -0,0 +1,43 @@
+package fake
+
+import (
+	biinstall "github.com/cloudfoundry/bosh-cli/installation"
+	biinstallmanifest "github.com/cloudfoundry/bosh-cli/installation/manifest"
+	biregistry "github.com/cloudfoundry/bosh-cli/registry"
+	biui "github.com/cloudfoundry/bosh-cli/ui"
+)
+
+type installer struct {
+	target                biinstall.Target
+	registryServerManager biregistry.ServerManager
+}
+
+type installerFactory struct {
+	registryServerManager biregistry.ServerManager
+}
+
+// NewInstallerFactory returns factory of installers which skip compiling CPI
+// release since fake cloud runs in-process
+func NewInstallerFactory(registryServerManager biregistry.ServerManager) biinstall.InstallerFactory {
+	return installerFactory{registryServerManager: registryServerManager}
+}
+
+func (f installerFactory) NewInstaller(target biinstall.Target) biinstall.Installer {
+	return installer{
+		target:                target,
+		registryServerManager: f.registryServerManager,
+	}
+}
+
+func (i installer) Install(manifest biinstallmanifest.Manifest, stage biui.Stage) (biinstall.Installation, error) {
+	installedJob := biinstall.NewInstalledJob(
+		biinstall.RenderedJobRef{Name: manifest.Template.Name},
+		"",
+	)
+
+	return biinstall.NewInstallation(i.target, installedJob, manifest, i.registryServerManager), nil
+}
+
+func (i installer) Cleanup(_ biinstall.Installation) error {
+	return nil
+}
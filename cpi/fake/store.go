@@ -0,0 +1,175 @@
+package fake
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sync"
+
+	"github.com/cloudfoundry/bosh-agent/agentclient/applyspec"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	boshuuid "github.com/cloudfoundry/bosh-utils/uuid"
+)
+
+type stemcellRecord struct {
+	CID       string `json:"cid"`
+	ImagePath string `json:"image_path"`
+}
+
+type vmRecord struct {
+	CID         string            `json:"cid"`
+	AgentID     string            `json:"agent_id"`
+	StemcellCID string            `json:"stemcell_cid"`
+	IPs         []string          `json:"ips"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+
+	Agent agentRecord `json:"agent"`
+}
+
+type agentRecord struct {
+	JobState        string               `json:"job_state"`
+	MountedDiskCIDs []string             `json:"mounted_disk_cids"`
+	ApplySpec       *applyspec.ApplySpec `json:"apply_spec,omitempty"`
+}
+
+type diskRecord struct {
+	CID      string            `json:"cid"`
+	Size     int               `json:"size"`
+	VMCID    string            `json:"vm_cid,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type storeState struct {
+	Stemcells []stemcellRecord `json:"stemcells"`
+	VMs       []vmRecord       `json:"vms"`
+	Disks     []diskRecord     `json:"disks"`
+}
+
+func (s *storeState) findVM(cid string) (*vmRecord, bool) {
+	for i := range s.VMs {
+		if s.VMs[i].CID == cid {
+			return &s.VMs[i], true
+		}
+	}
+	return nil, false
+}
+
+// findVMByIP falls back to the only VM so that instances without static IPs are still reachable
+func (s *storeState) findVMByIP(ip string) (*vmRecord, bool) {
+	for i := range s.VMs {
+		for _, vmIP := range s.VMs[i].IPs {
+			if vmIP == ip {
+				return &s.VMs[i], true
+			}
+		}
+	}
+
+	if len(s.VMs) == 1 {
+		return &s.VMs[0], true
+	}
+
+	return nil, false
+}
+
+func (s *storeState) findDisk(cid string) (*diskRecord, bool) {
+	for i := range s.Disks {
+		if s.Disks[i].CID == cid {
+			return &s.Disks[i], true
+		}
+	}
+	return nil, false
+}
+
+func (s *storeState) findStemcell(cid string) (*stemcellRecord, bool) {
+	for i := range s.Stemcells {
+		if s.Stemcells[i].CID == cid {
+			return &s.Stemcells[i], true
+		}
+	}
+	return nil, false
+}
+
+// Store keeps state of fake CPI VMs, disks, stemcells and agents in a local directory
+type Store struct {
+	fs            boshsys.FileSystem
+	uuidGenerator boshuuid.Generator
+	dirPath       string
+	logger        boshlog.Logger
+	logTag        string
+
+	lock sync.Mutex
+}
+
+func NewStore(fs boshsys.FileSystem, uuidGenerator boshuuid.Generator, dirPath string, logger boshlog.Logger) *Store {
+	return &Store{
+		fs:            fs,
+		uuidGenerator: uuidGenerator,
+		dirPath:       dirPath,
+		logger:        logger,
+		logTag:        "fakeCPIStore",
+	}
+}
+
+func (s *Store) statePath() string {
+	return filepath.Join(s.dirPath, "state.json")
+}
+
+func (s *Store) stemcellsPath() string {
+	return filepath.Join(s.dirPath, "stemcells")
+}
+
+func (s *Store) blobsPath() string {
+	return filepath.Join(s.dirPath, "blobs")
+}
+
+func (s *Store) newCID(prefix string) (string, error) {
+	uuid, err := s.uuidGenerator.Generate()
+	if err != nil {
+		return "", bosherr.WrapError(err, "Generating CID")
+	}
+
+	return prefix + "-" + uuid, nil
+}
+
+// update loads state, passes it to fn and saves it unless fn fails
+func (s *Store) update(fn func(*storeState) error) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	state := storeState{}
+
+	if s.fs.FileExists(s.statePath()) {
+		stateBytes, err := s.fs.ReadFile(s.statePath())
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Reading fake CPI state '%s'", s.statePath())
+		}
+
+		err = json.Unmarshal(stateBytes, &state)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Unmarshalling fake CPI state '%s'", s.statePath())
+		}
+	}
+
+	err := fn(&state)
+	if err != nil {
+		return err
+	}
+
+	stateBytes, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return bosherr.WrapError(err, "Marshalling fake CPI state")
+	}
+
+	err = s.fs.MkdirAll(s.dirPath, 0700)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Creating fake CPI directory '%s'", s.dirPath)
+	}
+
+	err = s.fs.WriteFile(s.statePath(), stateBytes)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing fake CPI state '%s'", s.statePath())
+	}
+
+	return nil
+}
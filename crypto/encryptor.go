@@ -0,0 +1,141 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"strconv"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	encryptedPEMType = "BOSH ENCRYPTED DATA"
+
+	encryptedCipher  = "AES-256-GCM"
+	encryptedKDF     = "PBKDF2-SHA256"
+	encryptedKDFIter = 100000
+
+	// Iterations are read from encrypted data hence bounded
+	// to avoid spending unreasonable time deriving a key
+	encryptedKDFMaxIter = 100 * encryptedKDFIter
+
+	encryptedSaltLen = 16
+	encryptedKeyLen  = 32
+)
+
+type Encryptor interface {
+	Encrypt([]byte) ([]byte, error)
+	Decrypt([]byte) ([]byte, error)
+}
+
+// passphraseEncryptor produces PEM encoded AES-256-GCM ciphertext
+// with a key derived from a passphrase via PBKDF2-SHA256
+type passphraseEncryptor struct {
+	passphrase []byte
+	rand       io.Reader
+}
+
+func NewPassphraseEncryptor(passphrase []byte) Encryptor {
+	return passphraseEncryptor{passphrase: passphrase, rand: rand.Reader}
+}
+
+// IsEncrypted checks whether data was produced by an Encryptor
+func IsEncrypted(data []byte) bool {
+	block, _ := pem.Decode(bytes.TrimSpace(data))
+	return block != nil && block.Type == encryptedPEMType
+}
+
+func (e passphraseEncryptor) Encrypt(plaintext []byte) ([]byte, error) {
+	salt := make([]byte, encryptedSaltLen)
+
+	_, err := io.ReadFull(e.rand, salt)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Generating salt")
+	}
+
+	aead, err := e.aead(salt, encryptedKDFIter)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+
+	_, err = io.ReadFull(e.rand, nonce)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Generating nonce")
+	}
+
+	block := &pem.Block{
+		Type: encryptedPEMType,
+		Headers: map[string]string{
+			"Cipher":     encryptedCipher,
+			"KDF":        encryptedKDF,
+			"Iterations": strconv.Itoa(encryptedKDFIter),
+			"Salt":       base64.StdEncoding.EncodeToString(salt),
+		},
+		Bytes: append(nonce, aead.Seal(nil, nonce, plaintext, nil)...),
+	}
+
+	return pem.EncodeToMemory(block), nil
+}
+
+func (e passphraseEncryptor) Decrypt(data []byte) ([]byte, error) {
+	block, _ := pem.Decode(bytes.TrimSpace(data))
+	if block == nil || block.Type != encryptedPEMType {
+		return nil, bosherr.Error("Expected data to be encrypted")
+	}
+
+	if block.Headers["Cipher"] != encryptedCipher || block.Headers["KDF"] != encryptedKDF {
+		return nil, bosherr.Errorf("Unsupported encryption '%s' with '%s'", block.Headers["Cipher"], block.Headers["KDF"])
+	}
+
+	iter, err := strconv.Atoi(block.Headers["Iterations"])
+	if err != nil || iter < 1 {
+		return nil, bosherr.Errorf("Expected iterations '%s' to be a positive integer", block.Headers["Iterations"])
+	}
+
+	if iter > encryptedKDFMaxIter {
+		return nil, bosherr.Errorf("Expected iterations '%d' to be at most '%d'", iter, encryptedKDFMaxIter)
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(block.Headers["Salt"])
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Decoding salt")
+	}
+
+	aead, err := e.aead(salt, iter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(block.Bytes) < aead.NonceSize() {
+		return nil, bosherr.Error("Expected encrypted data to include nonce")
+	}
+
+	nonce, ciphertext := block.Bytes[:aead.NonceSize()], block.Bytes[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, bosherr.Error("Decrypting data: wrong passphrase or corrupted data")
+	}
+
+	return plaintext, nil
+}
+
+func (e passphraseEncryptor) aead(salt []byte, iter int) (cipher.AEAD, error) {
+	key := pbkdf2.Key(e.passphrase, salt, iter, encryptedKeyLen, sha256.New)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Building cipher")
+	}
+
+	return cipher.NewGCM(block)
+}
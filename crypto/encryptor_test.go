@@ -0,0 +1,96 @@
+package crypto_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/crypto"
+)
+
+var _ = Describe("PassphraseEncryptor", func() {
+	var (
+		encryptor Encryptor
+	)
+
+	BeforeEach(func() {
+		encryptor = NewPassphraseEncryptor([]byte("passphrase"))
+	})
+
+	It("encrypts data that can be decrypted with the same passphrase", func() {
+		ciphertext, err := encryptor.Encrypt([]byte("key: val\n"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(ciphertext)).ToNot(ContainSubstring("key: val"))
+		Expect(string(ciphertext)).To(HavePrefix("-----BEGIN BOSH ENCRYPTED DATA-----\n"))
+		Expect(IsEncrypted(ciphertext)).To(BeTrue())
+
+		plaintext, err := encryptor.Decrypt(ciphertext)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(plaintext)).To(Equal("key: val\n"))
+	})
+
+	It("uses unique salt and nonce for each encryption", func() {
+		ciphertext1, err := encryptor.Encrypt([]byte("data"))
+		Expect(err).ToNot(HaveOccurred())
+
+		ciphertext2, err := encryptor.Encrypt([]byte("data"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ciphertext1).ToNot(Equal(ciphertext2))
+	})
+
+	It("returns an error if passphrase is wrong", func() {
+		ciphertext, err := encryptor.Encrypt([]byte("data"))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = NewPassphraseEncryptor([]byte("wrong")).Decrypt(ciphertext)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Decrypting data: wrong passphrase or corrupted data"))
+	})
+
+	It("returns an error if encrypted data was tampered with", func() {
+		ciphertext, err := encryptor.Encrypt([]byte("data"))
+		Expect(err).ToNot(HaveOccurred())
+
+		lines := strings.Split(string(ciphertext), "\n")
+		body := []byte(lines[len(lines)-3])
+		body[0] ^= 1
+		lines[len(lines)-3] = string(body)
+
+		_, err = encryptor.Decrypt([]byte(strings.Join(lines, "\n")))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("returns an error if data is not encrypted", func() {
+		Expect(IsEncrypted([]byte("key: val"))).To(BeFalse())
+
+		_, err := encryptor.Decrypt([]byte("key: val"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected data to be encrypted"))
+	})
+
+	It("returns an error if encryption is not supported", func() {
+		data := "-----BEGIN BOSH ENCRYPTED DATA-----\nCipher: DES\nKDF: PBKDF2-SHA256\n\nZGF0YQ==\n-----END BOSH ENCRYPTED DATA-----\n"
+
+		_, err := encryptor.Decrypt([]byte(data))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Unsupported encryption 'DES' with 'PBKDF2-SHA256'"))
+	})
+
+	It("decrypts data encrypted by earlier versions", func() {
+		data := "-----BEGIN BOSH ENCRYPTED DATA-----\nCipher: AES-256-GCM\nIterations: 100000\nKDF: PBKDF2-SHA256\nSalt: bAfWuAYL+CKVw8ds9L4cQA==\n\n/59R3K2t2rr6fJ0bnQXyq1IN+3g2aObs9Chn4KxsQ3vhJOq/mw==\n-----END BOSH ENCRYPTED DATA-----\n"
+
+		plaintext, err := encryptor.Decrypt([]byte(data))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(plaintext)).To(Equal("key: val\n"))
+	})
+
+	It("returns an error if iterations are too high", func() {
+		data := "-----BEGIN BOSH ENCRYPTED DATA-----\nCipher: AES-256-GCM\nIterations: 2000000000\nKDF: PBKDF2-SHA256\nSalt: bAfWuAYL+CKVw8ds9L4cQA==\n\nZGF0YQ==\n-----END BOSH ENCRYPTED DATA-----\n"
+
+		_, err := encryptor.Decrypt([]byte(data))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected iterations '2000000000' to be at most '10000000'"))
+	})
+})
@@ -1,14 +1,13 @@
 package deployment
 
 import (
+	"fmt"
 	"time"
 
-	biblobstore "github.com/cloudfoundry/bosh-cli/blobstore"
 	bicloud "github.com/cloudfoundry/bosh-cli/cloud"
 	bidisk "github.com/cloudfoundry/bosh-cli/deployment/disk"
 	biinstance "github.com/cloudfoundry/bosh-cli/deployment/instance"
 	bideplmanifest "github.com/cloudfoundry/bosh-cli/deployment/manifest"
-	bivm "github.com/cloudfoundry/bosh-cli/deployment/vm"
 	biinstallmanifest "github.com/cloudfoundry/bosh-cli/installation/manifest"
 	bistemcell "github.com/cloudfoundry/bosh-cli/stemcell"
 	biui "github.com/cloudfoundry/bosh-cli/ui"
@@ -22,30 +21,30 @@ type Deployer interface {
 		bideplmanifest.Manifest,
 		bistemcell.CloudStemcell,
 		biinstallmanifest.Registry,
-		bivm.Manager,
-		biblobstore.Blobstore,
+		InstanceEnvProvider,
 		biui.Stage,
 	) (Deployment, error)
 }
 
 type deployer struct {
-	vmManagerFactory       bivm.ManagerFactory
 	instanceManagerFactory biinstance.ManagerFactory
 	deploymentFactory      Factory
+	pingTimeout            time.Duration
+	pingDelay              time.Duration
 	logger                 boshlog.Logger
 	logTag                 string
 }
 
 func NewDeployer(
-	vmManagerFactory bivm.ManagerFactory,
 	instanceManagerFactory biinstance.ManagerFactory,
 	deploymentFactory Factory,
 	logger boshlog.Logger,
 ) Deployer {
 	return &deployer{
-		vmManagerFactory:       vmManagerFactory,
 		instanceManagerFactory: instanceManagerFactory,
 		deploymentFactory:      deploymentFactory,
+		pingTimeout:            10 * time.Second,
+		pingDelay:              500 * time.Millisecond,
 		logger:                 logger,
 		logTag:                 "deployer",
 	}
@@ -56,19 +55,19 @@ func (d *deployer) Deploy(
 	deploymentManifest bideplmanifest.Manifest,
 	cloudStemcell bistemcell.CloudStemcell,
 	registryConfig biinstallmanifest.Registry,
-	vmManager bivm.Manager,
-	blobstore biblobstore.Blobstore,
+	instanceEnvs InstanceEnvProvider,
 	deployStage biui.Stage,
 ) (Deployment, error) {
-	instanceManager := d.instanceManagerFactory.NewManager(cloud, vmManager, blobstore)
+	recordedEnvs, err := instanceEnvs.Recorded()
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Finding recorded instances")
+	}
 
-	pingTimeout := 10 * time.Second
-	pingDelay := 500 * time.Millisecond
-	if err := instanceManager.DeleteAll(pingTimeout, pingDelay, deployStage); err != nil {
+	if err := d.deleteRemovedInstances(cloud, deploymentManifest, recordedEnvs, deployStage); err != nil {
 		return nil, err
 	}
 
-	instances, disks, err := d.createAllInstances(deploymentManifest, instanceManager, cloudStemcell, registryConfig, deployStage)
+	instances, disks, err := d.recreateAllInstances(cloud, deploymentManifest, instanceEnvs, cloudStemcell, registryConfig, deployStage)
 	if err != nil {
 		return nil, err
 	}
@@ -77,9 +76,55 @@ func (d *deployer) Deploy(
 	return d.deploymentFactory.NewDeployment(instances, disks, stemcells), nil
 }
 
-func (d *deployer) createAllInstances(
+// deleteRemovedInstances deletes VMs and persistent disks of instances which are no longer in the manifest.
+// The first instance is kept since it is recreated as the first instance of the first job.
+func (d *deployer) deleteRemovedInstances(
+	cloud bicloud.Cloud,
 	deploymentManifest bideplmanifest.Manifest,
-	instanceManager biinstance.Manager,
+	recordedEnvs []InstanceEnv,
+	deployStage biui.Stage,
+) error {
+	for i, env := range recordedEnvs {
+		if i == 0 {
+			continue
+		}
+
+		job, found := deploymentManifest.FindJobByName(env.JobName)
+		if found && env.ID < job.Instances {
+			continue
+		}
+
+		instanceManager := d.instanceManagerFactory.NewManager(cloud, env.VMManager, env.Blobstore)
+
+		if err := instanceManager.DeleteAll(d.pingTimeout, d.pingDelay, deployStage); err != nil {
+			return err
+		}
+
+		disks, err := env.DiskManager.FindCurrent()
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Finding disks of removed instance '%s/%d'", env.JobName, env.ID)
+		}
+
+		for _, disk := range disks {
+			stepName := fmt.Sprintf("Deleting disk '%s' of removed instance '%s/%d'", disk.CID(), env.JobName, env.ID)
+			err = deployStage.Perform(stepName, func() error {
+				return disk.Delete()
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// recreateAllInstances deletes and creates instances one at a time
+// so that instances which were not reached yet keep running if one fails
+func (d *deployer) recreateAllInstances(
+	cloud bicloud.Cloud,
+	deploymentManifest bideplmanifest.Manifest,
+	instanceEnvs InstanceEnvProvider,
 	cloudStemcell bistemcell.CloudStemcell,
 	registryConfig biinstallmanifest.Registry,
 	deployStage biui.Stage,
@@ -87,15 +132,26 @@ func (d *deployer) createAllInstances(
 	instances := []biinstance.Instance{}
 	disks := []bidisk.Disk{}
 
-	if len(deploymentManifest.Jobs) != 1 {
-		return instances, disks, bosherr.Errorf("There must only be one job, found %d", len(deploymentManifest.Jobs))
+	if len(deploymentManifest.Jobs) == 0 {
+		return instances, disks, bosherr.Error("There must be at least one job")
 	}
 
 	for _, jobSpec := range deploymentManifest.Jobs {
-		if jobSpec.Instances != 1 {
-			return instances, disks, bosherr.Errorf("Job '%s' must have only one instance, found %d", jobSpec.Name, jobSpec.Instances)
+		if jobSpec.Instances < 1 {
+			return instances, disks, bosherr.Errorf("Job '%s' must have at least one instance, found %d", jobSpec.Name, jobSpec.Instances)
 		}
 		for instanceID := 0; instanceID < jobSpec.Instances; instanceID++ {
+			env, err := instanceEnvs.ForInstance(deploymentManifest, jobSpec.Name, instanceID)
+			if err != nil {
+				return instances, disks, bosherr.WrapErrorf(err, "Preparing instance '%s/%d'", jobSpec.Name, instanceID)
+			}
+
+			instanceManager := d.instanceManagerFactory.NewManager(cloud, env.VMManager, env.Blobstore)
+
+			if err := instanceManager.DeleteAll(d.pingTimeout, d.pingDelay, deployStage); err != nil {
+				return instances, disks, err
+			}
+
 			instance, instanceDisks, err := instanceManager.Create(jobSpec.Name, instanceID, deploymentManifest, cloudStemcell, registryConfig, deployStage)
 			if err != nil {
 				return instances, disks, bosherr.WrapErrorf(err, "Creating instance '%s/%d'", jobSpec.Name, instanceID)
@@ -6,17 +6,17 @@ import (
 
 	. "github.com/cloudfoundry/bosh-cli/deployment"
 
-	mock_httpagent "github.com/cloudfoundry/bosh-agent/agentclient/http/mocks"
 	mock_agentclient "github.com/cloudfoundry/bosh-cli/agentclient/mocks"
 	mock_blobstore "github.com/cloudfoundry/bosh-cli/blobstore/mocks"
 	mock_instance_state "github.com/cloudfoundry/bosh-cli/deployment/instance/state/mocks"
-	mock_vm "github.com/cloudfoundry/bosh-cli/deployment/vm/mocks"
+	mock_deployment "github.com/cloudfoundry/bosh-cli/deployment/mocks"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	bias "github.com/cloudfoundry/bosh-agent/agentclient/applyspec"
 	biconfig "github.com/cloudfoundry/bosh-cli/config"
+	bidisk "github.com/cloudfoundry/bosh-cli/deployment/disk"
 	biinstance "github.com/cloudfoundry/bosh-cli/deployment/instance"
 	bideplmanifest "github.com/cloudfoundry/bosh-cli/deployment/manifest"
 	bisshtunnel "github.com/cloudfoundry/bosh-cli/deployment/sshtunnel"
@@ -29,6 +29,7 @@ import (
 	"github.com/cloudfoundry/bosh-agent/agentclient"
 	fakebicloud "github.com/cloudfoundry/bosh-cli/cloud/fakes"
 	fakebiconfig "github.com/cloudfoundry/bosh-cli/config/fakes"
+	fakebidisk "github.com/cloudfoundry/bosh-cli/deployment/disk/fakes"
 	fakebisshtunnel "github.com/cloudfoundry/bosh-cli/deployment/sshtunnel/fakes"
 	fakebivm "github.com/cloudfoundry/bosh-cli/deployment/vm/fakes"
 	fakebiui "github.com/cloudfoundry/bosh-cli/ui/fakes"
@@ -46,19 +47,20 @@ var _ = Describe("Deployer", func() {
 	})
 
 	var (
-		deployer               Deployer
-		mockVMManagerFactory   *mock_vm.MockManagerFactory
-		fakeVMManager          *fakebivm.FakeManager
-		mockAgentClient        *mock_agentclient.MockAgentClient
-		mockAgentClientFactory *mock_httpagent.MockAgentClientFactory
-		fakeSSHTunnelFactory   *fakebisshtunnel.FakeFactory
-		fakeSSHTunnel          *fakebisshtunnel.FakeTunnel
-		cloud                  *fakebicloud.FakeCloud
-		deploymentManifest     bideplmanifest.Manifest
-		diskPool               bideplmanifest.DiskPool
-		registryConfig         biinstallmanifest.Registry
-		fakeStage              *fakebiui.FakeStage
-		fakeVM                 *fakebivm.FakeVM
+		deployer             Deployer
+		mockInstanceEnvs     *mock_deployment.MockInstanceEnvProvider
+		primaryEnv           InstanceEnv
+		recordedEnvs         []InstanceEnv
+		fakeVMManager        *fakebivm.FakeManager
+		mockAgentClient      *mock_agentclient.MockAgentClient
+		fakeSSHTunnelFactory *fakebisshtunnel.FakeFactory
+		fakeSSHTunnel        *fakebisshtunnel.FakeTunnel
+		cloud                *fakebicloud.FakeCloud
+		deploymentManifest   bideplmanifest.Manifest
+		diskPool             bideplmanifest.DiskPool
+		registryConfig       biinstallmanifest.Registry
+		fakeStage            *fakebiui.FakeStage
+		fakeVM               *fakebivm.FakeVM
 
 		cloudStemcell bistemcell.CloudStemcell
 
@@ -109,13 +111,9 @@ var _ = Describe("Deployer", func() {
 
 		cloud = fakebicloud.NewFakeCloud()
 
-		mockAgentClientFactory = mock_httpagent.NewMockAgentClientFactory(mockCtrl)
 		mockAgentClient = mock_agentclient.NewMockAgentClient(mockCtrl)
-		mockAgentClientFactory.EXPECT().NewAgentClient(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockAgentClient, nil).AnyTimes()
 
-		mockVMManagerFactory = mock_vm.NewMockManagerFactory(mockCtrl)
 		fakeVMManager = fakebivm.NewFakeManager()
-		mockVMManagerFactory.EXPECT().NewManager(cloud, mockAgentClient).Return(fakeVMManager).AnyTimes()
 
 		fakeSSHTunnelFactory = fakebisshtunnel.NewFakeFactory()
 		fakeSSHTunnel = fakebisshtunnel.NewFakeTunnel()
@@ -151,12 +149,20 @@ var _ = Describe("Deployer", func() {
 
 		mockBlobstore = mock_blobstore.NewMockBlobstore(mockCtrl)
 
+		primaryEnv = InstanceEnv{
+			JobName:   "fake-job-name",
+			VMManager: fakeVMManager,
+			Blobstore: mockBlobstore,
+		}
+		recordedEnvs = []InstanceEnv{primaryEnv}
+
+		mockInstanceEnvs = mock_deployment.NewMockInstanceEnvProvider(mockCtrl)
+
 		pingTimeout := 10 * time.Second
 		pingDelay := 500 * time.Millisecond
 		deploymentFactory := NewFactory(pingTimeout, pingDelay)
 
 		deployer = NewDeployer(
-			mockVMManagerFactory,
 			instanceManagerFactory,
 			deploymentFactory,
 			logger,
@@ -179,6 +185,155 @@ var _ = Describe("Deployer", func() {
 		mockStateBuilder.EXPECT().Build(jobName, jobIndex, deploymentManifest, fakeStage, fakeAgentState).Return(mockState, nil).AnyTimes()
 		mockStateBuilder.EXPECT().BuildInitialState(jobName, jobIndex, deploymentManifest).Return(mockState, nil).AnyTimes()
 		mockState.EXPECT().ToApplySpec().Return(applySpec).AnyTimes()
+
+		mockInstanceEnvs.EXPECT().Recorded().Return(recordedEnvs, nil).AnyTimes()
+		mockInstanceEnvs.EXPECT().ForInstance(gomock.Any(), "fake-job-name", 0).Return(primaryEnv, nil).AnyTimes()
+	})
+
+	Context("when a job has multiple instances", func() {
+		var (
+			otherVMManager *fakebivm.FakeManager
+			otherVM        *fakebivm.FakeVM
+		)
+
+		BeforeEach(func() {
+			deploymentManifest.Jobs[0].Instances = 2
+
+			otherVM = fakebivm.NewFakeVM("fake-other-vm-cid")
+			otherVM.AgentClientReturn = mockAgentClient
+			otherVM.GetStateResult = agentclient.AgentState{}
+
+			otherVMManager = fakebivm.NewFakeManager()
+			otherVMManager.CreateVM = otherVM
+
+			mockInstanceEnvs.EXPECT().ForInstance(gomock.Any(), "fake-job-name", 1).Return(InstanceEnv{
+				JobName:   "fake-job-name",
+				ID:        1,
+				VMManager: otherVMManager,
+				Blobstore: mockBlobstore,
+			}, nil).AnyTimes()
+		})
+
+		JustBeforeEach(func() {
+			mockStateBuilder.EXPECT().Build("fake-job-name", 1, deploymentManifest, fakeStage, agentclient.AgentState{}).Return(mockState, nil).AnyTimes()
+			mockStateBuilder.EXPECT().BuildInitialState("fake-job-name", 1, deploymentManifest).Return(mockState, nil).AnyTimes()
+		})
+
+		It("creates and updates instances in order with their own vm managers", func() {
+			deployment, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deployment).ToNot(BeNil())
+
+			Expect(fakeVMManager.CreateInput.Index).To(Equal(0))
+			Expect(otherVMManager.CreateInput.JobName).To(Equal("fake-job-name"))
+			Expect(otherVMManager.CreateInput.Index).To(Equal(1))
+
+			var names []string
+			for _, call := range fakeStage.PerformCalls {
+				names = append(names, call.Name)
+			}
+			Expect(names).To(ContainElement("Updating instance 'fake-job-name/1'"))
+			Expect(names[0]).To(Equal("Creating VM for instance 'fake-job-name/0' from stemcell 'fake-stemcell-cid'"))
+		})
+
+		Context("when previous instances exist", func() {
+			var (
+				existingVM      *fakebivm.FakeVM
+				otherExistingVM *fakebivm.FakeVM
+			)
+
+			BeforeEach(func() {
+				existingVM = fakebivm.NewFakeVM("existing-vm-cid")
+				existingVM.AgentClientReturn = mockAgentClient
+				fakeVMManager.SetFindCurrentBehavior(existingVM, true, nil)
+
+				otherExistingVM = fakebivm.NewFakeVM("other-existing-vm-cid")
+				otherExistingVM.AgentClientReturn = mockAgentClient
+				otherVMManager.SetFindCurrentBehavior(otherExistingVM, true, nil)
+			})
+
+			It("deletes and creates instances one at a time", func() {
+				_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(existingVM.DeleteCalled).To(Equal(1))
+				Expect(otherExistingVM.DeleteCalled).To(Equal(1))
+
+				var names []string
+				for _, call := range fakeStage.PerformCalls {
+					names = append(names, call.Name)
+				}
+				Expect(names).To(ContainElement("Deleting VM 'existing-vm-cid'"))
+				Expect(names).To(ContainElement("Creating VM for instance 'fake-job-name/0' from stemcell 'fake-stemcell-cid'"))
+				Expect(names).To(ContainElement("Deleting VM 'other-existing-vm-cid'"))
+				Expect(names).To(ContainElement("Creating VM for instance 'fake-job-name/1' from stemcell 'fake-stemcell-cid'"))
+
+				indexOf := func(name string) int {
+					for i, n := range names {
+						if n == name {
+							return i
+						}
+					}
+					return -1
+				}
+				Expect(indexOf("Deleting VM 'other-existing-vm-cid'")).To(BeNumerically(">", indexOf("Updating instance 'fake-job-name/0'")))
+			})
+
+			Context("when creating an instance fails", func() {
+				BeforeEach(func() {
+					fakeVMManager.CreateErr = errors.New("fake-create-error")
+				})
+
+				It("keeps instances which were not reached yet", func() {
+					_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("fake-create-error"))
+
+					Expect(existingVM.DeleteCalled).To(Equal(1))
+					Expect(otherExistingVM.DeleteCalled).To(Equal(0))
+				})
+			})
+		})
+	})
+
+	Context("when a recorded instance was removed from the manifest", func() {
+		var (
+			removedVM   *fakebivm.FakeVM
+			removedDisk *fakebidisk.FakeDisk
+		)
+
+		BeforeEach(func() {
+			removedVM = fakebivm.NewFakeVM("fake-removed-vm-cid")
+			removedVM.AgentClientReturn = mockAgentClient
+
+			removedVMManager := fakebivm.NewFakeManager()
+			removedVMManager.SetFindCurrentBehavior(removedVM, true, nil)
+
+			removedDisk = fakebidisk.NewFakeDisk("fake-removed-disk-cid")
+
+			removedDiskManager := fakebidisk.NewFakeManager()
+			removedDiskManager.SetFindCurrentBehavior([]bidisk.Disk{removedDisk}, nil)
+
+			recordedEnvs = append(recordedEnvs, InstanceEnv{
+				JobName:     "fake-removed-job-name",
+				ID:          0,
+				VMManager:   removedVMManager,
+				DiskManager: removedDiskManager,
+				Blobstore:   mockBlobstore,
+			})
+		})
+
+		It("deletes its VM and disks", func() {
+			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(removedVM.DeleteCalled).To(Equal(1))
+			Expect(removedDisk.DeleteCalledTimes).To(Equal(1))
+			Expect(fakeStage.PerformCalls[2:4]).To(Equal([]*fakebiui.PerformCall{
+				{Name: "Deleting VM 'fake-removed-vm-cid'"},
+				{Name: "Deleting disk 'fake-removed-disk-cid' of removed instance 'fake-removed-job-name/0'"},
+			}))
+		})
 	})
 
 	Context("when a previous instance exists", func() {
@@ -191,7 +346,7 @@ var _ = Describe("Deployer", func() {
 		})
 
 		It("deletes existing vm", func() {
-			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, fakeStage)
+			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeExistingVM.DeleteCalled).To(Equal(1))
@@ -205,12 +360,14 @@ var _ = Describe("Deployer", func() {
 	})
 
 	It("creates a vm", func() {
-		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, fakeStage)
+		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(fakeVMManager.CreateInput).To(Equal(fakebivm.CreateInput{
 			Stemcell: cloudStemcell,
 			Manifest: deploymentManifest,
+			JobName:  "fake-job-name",
+			Index:    0,
 		}))
 	})
 
@@ -232,7 +389,7 @@ var _ = Describe("Deployer", func() {
 		})
 
 		It("starts the SSH tunnel", func() {
-			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, fakeStage)
+			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeSSHTunnel.Started).To(BeTrue())
 			Expect(fakeSSHTunnelFactory.NewSSHTunnelOptions).To(Equal(bisshtunnel.Options{
@@ -252,7 +409,7 @@ var _ = Describe("Deployer", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, fakeStage)
+				_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("fake-ssh-tunnel-start-error"))
 			})
@@ -260,7 +417,7 @@ var _ = Describe("Deployer", func() {
 	})
 
 	It("waits for the vm", func() {
-		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, fakeStage)
+		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(fakeVM.WaitUntilReadyInputs).To(ContainElement(fakebivm.WaitUntilReadyInput{
 			Timeout: 10 * time.Minute,
@@ -269,7 +426,7 @@ var _ = Describe("Deployer", func() {
 	})
 
 	It("logs start and stop events to the eventLogger", func() {
-		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, fakeStage)
+		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(fakeStage.PerformCalls[1]).To(Equal(&fakebiui.PerformCall{
@@ -287,7 +444,7 @@ var _ = Describe("Deployer", func() {
 		})
 
 		It("logs start and stop events to the eventLogger", func() {
-			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, fakeStage)
+			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("fake-wait-error"))
 
@@ -299,7 +456,7 @@ var _ = Describe("Deployer", func() {
 	})
 
 	It("updates the vm", func() {
-		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, fakeStage)
+		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(fakeVM.ApplyInputs).To(Equal([]fakebivm.ApplyInput{
@@ -309,14 +466,14 @@ var _ = Describe("Deployer", func() {
 	})
 
 	It("starts the agent", func() {
-		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, fakeStage)
+		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(fakeVM.StartCalled).To(Equal(1))
 	})
 
 	It("waits until agent reports state as running", func() {
-		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, fakeStage)
+		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(fakeVM.WaitToBeRunningInputs).To(ContainElement(fakebivm.WaitInput{
@@ -331,13 +488,13 @@ var _ = Describe("Deployer", func() {
 		})
 
 		It("returns an error", func() {
-			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, fakeStage)
+			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
 			Expect(err).To(HaveOccurred())
 		})
 	})
 
 	It("logs instance update ui stages", func() {
-		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, fakeStage)
+		_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
 		Expect(err).NotTo(HaveOccurred())
 
 		Expect(fakeStage.PerformCalls[2:4]).To(Equal([]*fakebiui.PerformCall{
@@ -352,7 +509,7 @@ var _ = Describe("Deployer", func() {
 		})
 
 		It("fails with descriptive error", func() {
-			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, fakeStage)
+			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(Equal("Applying the initial agent state: fake-apply-error"))
 		})
@@ -364,7 +521,7 @@ var _ = Describe("Deployer", func() {
 		})
 
 		It("logs start and stop events to the eventLogger", func() {
-			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, fakeStage)
+			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("fake-start-error"))
 
@@ -384,7 +541,7 @@ var _ = Describe("Deployer", func() {
 		})
 
 		It("logs start and stop events to the eventLogger", func() {
-			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, fakeVMManager, mockBlobstore, fakeStage)
+			_, err := deployer.Deploy(cloud, deploymentManifest, cloudStemcell, registryConfig, mockInstanceEnvs, fakeStage)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("fake-wait-running-error"))
 
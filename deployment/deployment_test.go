@@ -7,6 +7,7 @@ import (
 	. "github.com/onsi/gomega"
 	"time"
 
+	mock_httpagent "github.com/cloudfoundry/bosh-agent/agentclient/http/mocks"
 	mock_agentclient "github.com/cloudfoundry/bosh-cli/agentclient/mocks"
 	mock_blobstore "github.com/cloudfoundry/bosh-cli/blobstore/mocks"
 	mock_cloud "github.com/cloudfoundry/bosh-cli/cloud/mocks"
@@ -149,12 +150,21 @@ var _ = Describe("Deployment", func() {
 
 			mockBlobstore = mock_blobstore.NewMockBlobstore(mockCtrl)
 
-			deploymentManagerFactory := NewManagerFactory(vmManagerFactory, instanceManagerFactory, diskManagerFactory, stemcellManagerFactory, deploymentFactory)
-			deploymentManager := deploymentManagerFactory.NewManager(mockCloud, mockAgentClient, mockBlobstore)
+			mockAgentClientFactory := mock_httpagent.NewMockAgentClientFactory(mockCtrl)
+			mockAgentClientFactory.EXPECT().NewAgentClient(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockAgentClient, nil).AnyTimes()
+
+			mockBlobstoreFactory := mock_blobstore.NewMockFactory(mockCtrl)
+			mockBlobstoreFactory.EXPECT().Create(gomock.Any(), gomock.Any()).Return(mockBlobstore, nil).AnyTimes()
+
+			instanceEnvProviderFactory := NewInstanceEnvProviderFactory(deploymentStateService, vmManagerFactory, mockAgentClientFactory, mockBlobstoreFactory, fakeRepoUUIDGenerator, logger)
+			instanceEnvs := instanceEnvProviderFactory.NewProvider(mockCloud, "fake-director-id", "https://fake-mbus-host:6868", "")
+
+			deploymentManagerFactory := NewManagerFactory(instanceManagerFactory, stemcellManagerFactory, deploymentFactory)
+			deploymentManager, err := deploymentManagerFactory.NewManager(mockCloud, instanceEnvs)
+			Expect(err).ToNot(HaveOccurred())
 
 			allowApplySpecToBeCreated()
 
-			var err error
 			deployment, _, err = deploymentManager.FindCurrent()
 			Expect(err).ToNot(HaveOccurred())
 			//Note: deployment will be nil if the config has no vms, disks, or stemcells
@@ -104,7 +104,7 @@ func (m *manager) Create(
 	stepName := fmt.Sprintf("Creating VM for instance '%s/%d' from stemcell '%s'", jobName, id, cloudStemcell.CID())
 	err := eventLoggerStage.Perform(stepName, func() error {
 		var err error
-		vm, err = m.vmManager.Create(cloudStemcell, deploymentManifest)
+		vm, err = m.vmManager.Create(cloudStemcell, deploymentManifest, jobName, id)
 		if err != nil {
 			return bosherr.WrapError(err, "Creating VM")
 		}
@@ -207,6 +207,8 @@ var _ = Describe("Manager", func() {
 			Expect(fakeVMManager.CreateInput).To(Equal(fakebivm.CreateInput{
 				Stemcell: fakeCloudStemcell,
 				Manifest: deploymentManifest,
+				JobName:  "fake-job-name",
+				Index:    0,
 			}))
 		})
 
@@ -0,0 +1,240 @@
+package deployment
+
+import (
+	"net"
+	"net/url"
+
+	bihttpagent "github.com/cloudfoundry/bosh-agent/agentclient/http"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	bihttpclient "github.com/cloudfoundry/bosh-utils/httpclient"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshuuid "github.com/cloudfoundry/bosh-utils/uuid"
+
+	biblobstore "github.com/cloudfoundry/bosh-cli/blobstore"
+	bicloud "github.com/cloudfoundry/bosh-cli/cloud"
+	biconfig "github.com/cloudfoundry/bosh-cli/config"
+	bidisk "github.com/cloudfoundry/bosh-cli/deployment/disk"
+	bideplmanifest "github.com/cloudfoundry/bosh-cli/deployment/manifest"
+	bivm "github.com/cloudfoundry/bosh-cli/deployment/vm"
+)
+
+// InstanceEnv holds managers which keep track of VM and disks of a single instance
+type InstanceEnv struct {
+	JobName string
+	ID      int
+
+	VMManager   bivm.Manager
+	DiskManager bidisk.Manager
+	Blobstore   biblobstore.Blobstore
+}
+
+type InstanceEnvProvider interface {
+	// Recorded returns environments of all instances found in deployment state,
+	// starting with the first instance of the first job
+	Recorded() ([]InstanceEnv, error)
+	ForInstance(deploymentManifest bideplmanifest.Manifest, jobName string, id int) (InstanceEnv, error)
+}
+
+type InstanceEnvProviderFactory interface {
+	NewProvider(cloud bicloud.Cloud, directorID, mbusURL, caCert string) InstanceEnvProvider
+}
+
+type instanceEnvProviderFactory struct {
+	deploymentStateService biconfig.DeploymentStateService
+	vmManagerFactory       bivm.ManagerFactory
+	agentClientFactory     bihttpagent.AgentClientFactory
+	blobstoreFactory       biblobstore.Factory
+	uuidGenerator          boshuuid.Generator
+	logger                 boshlog.Logger
+}
+
+func NewInstanceEnvProviderFactory(
+	deploymentStateService biconfig.DeploymentStateService,
+	vmManagerFactory bivm.ManagerFactory,
+	agentClientFactory bihttpagent.AgentClientFactory,
+	blobstoreFactory biblobstore.Factory,
+	uuidGenerator boshuuid.Generator,
+	logger boshlog.Logger,
+) InstanceEnvProviderFactory {
+	return &instanceEnvProviderFactory{
+		deploymentStateService: deploymentStateService,
+		vmManagerFactory:       vmManagerFactory,
+		agentClientFactory:     agentClientFactory,
+		blobstoreFactory:       blobstoreFactory,
+		uuidGenerator:          uuidGenerator,
+		logger:                 logger,
+	}
+}
+
+func (f *instanceEnvProviderFactory) NewProvider(cloud bicloud.Cloud, directorID, mbusURL, caCert string) InstanceEnvProvider {
+	return &instanceEnvProvider{
+		factory:    f,
+		cloud:      cloud,
+		directorID: directorID,
+		mbusURL:    mbusURL,
+		caCert:     caCert,
+		logTag:     "instanceEnvProvider",
+	}
+}
+
+type instanceEnvProvider struct {
+	factory    *instanceEnvProviderFactory
+	cloud      bicloud.Cloud
+	directorID string
+	mbusURL    string
+	caCert     string
+	logTag     string
+
+	primary *InstanceEnv
+}
+
+func (p *instanceEnvProvider) Recorded() ([]InstanceEnv, error) {
+	deploymentState, err := p.factory.deploymentStateService.Load()
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Loading deployment state")
+	}
+
+	// First instance does not have an instance record and its job name is unknown
+	primaryEnv, err := p.primaryEnv("unknown")
+	if err != nil {
+		return nil, err
+	}
+
+	envs := []InstanceEnv{primaryEnv}
+
+	for _, record := range deploymentState.Instances {
+		env, err := p.instanceEnv(record.Name, record.Index, record.IP)
+		if err != nil {
+			return nil, err
+		}
+
+		envs = append(envs, env)
+	}
+
+	return envs, nil
+}
+
+func (p *instanceEnvProvider) ForInstance(deploymentManifest bideplmanifest.Manifest, jobName string, id int) (InstanceEnv, error) {
+	if jobName == deploymentManifest.JobName() && id == 0 {
+		return p.primaryEnv(jobName)
+	}
+
+	ip, err := p.instanceIP(deploymentManifest, jobName, id)
+	if err != nil {
+		return InstanceEnv{}, err
+	}
+
+	return p.instanceEnv(jobName, id, ip)
+}
+
+func (p *instanceEnvProvider) primaryEnv(jobName string) (InstanceEnv, error) {
+	if p.primary == nil {
+		stateService := biconfig.NewPrimaryInstanceDeploymentStateService(p.factory.deploymentStateService)
+
+		env, err := p.newEnv(jobName, 0, stateService, p.mbusURL)
+		if err != nil {
+			return InstanceEnv{}, err
+		}
+
+		p.primary = &env
+	}
+
+	env := *p.primary
+	env.JobName = jobName
+
+	return env, nil
+}
+
+func (p *instanceEnvProvider) instanceEnv(jobName string, id int, ip string) (InstanceEnv, error) {
+	mbusURL, err := p.instanceMbusURL(ip)
+	if err != nil {
+		return InstanceEnv{}, err
+	}
+
+	stateService := biconfig.NewInstanceDeploymentStateService(p.factory.deploymentStateService, jobName, id, ip)
+
+	return p.newEnv(jobName, id, stateService, mbusURL)
+}
+
+func (p *instanceEnvProvider) newEnv(jobName string, id int, stateService biconfig.DeploymentStateService, mbusURL string) (InstanceEnv, error) {
+	f := p.factory
+
+	vmRepo := biconfig.NewVMRepo(stateService)
+	diskRepo := biconfig.NewDiskRepo(stateService, f.uuidGenerator)
+	diskManagerFactory := bidisk.NewManagerFactory(diskRepo, f.logger)
+	diskDeployer := bivm.NewDiskDeployer(diskManagerFactory, diskRepo, f.logger)
+
+	f.logger.Debug(p.logTag, "Creating agent and blobstore clients for instance '%s/%d'", jobName, id)
+
+	agentClient, err := f.agentClientFactory.NewAgentClient(p.directorID, mbusURL, p.caCert)
+	if err != nil {
+		return InstanceEnv{}, bosherr.WrapErrorf(err, "Creating agent client for instance '%s/%d'", jobName, id)
+	}
+
+	blobstore, err := f.blobstoreFactory.Create(mbusURL, bihttpclient.CreateDefaultClientInsecureSkipVerify())
+	if err != nil {
+		return InstanceEnv{}, bosherr.WrapErrorf(err, "Creating blobstore client for instance '%s/%d'", jobName, id)
+	}
+
+	return InstanceEnv{
+		JobName:     jobName,
+		ID:          id,
+		VMManager:   f.vmManagerFactory.NewInstanceManager(p.cloud, agentClient, vmRepo, diskDeployer),
+		DiskManager: diskManagerFactory.NewManager(p.cloud),
+		Blobstore:   blobstore,
+	}, nil
+}
+
+// instanceIP picks static IP of an instance on the network through which
+// the first instance is reachable via mbus, falling back to any static IP
+func (p *instanceEnvProvider) instanceIP(deploymentManifest bideplmanifest.Manifest, jobName string, id int) (string, error) {
+	job, found := deploymentManifest.FindJobByName(jobName)
+	if !found {
+		return "", bosherr.Errorf("Could not find job with name: %s", jobName)
+	}
+
+	mbusNetwork := ""
+
+	if parsedURL, err := url.Parse(p.mbusURL); err == nil {
+		for _, jobNetwork := range deploymentManifest.Jobs[0].Networks {
+			if len(jobNetwork.StaticIPs) > 0 && jobNetwork.StaticIPs[0] == parsedURL.Hostname() {
+				mbusNetwork = jobNetwork.Name
+			}
+		}
+	}
+
+	var ip string
+
+	for _, jobNetwork := range job.Networks {
+		if id >= len(jobNetwork.StaticIPs) {
+			continue
+		}
+		if jobNetwork.Name == mbusNetwork {
+			return jobNetwork.StaticIPs[id], nil
+		}
+		if len(ip) == 0 {
+			ip = jobNetwork.StaticIPs[id]
+		}
+	}
+
+	if len(ip) == 0 {
+		return "", bosherr.Errorf("Instance '%s/%d' must have a static IP to be reachable via mbus", jobName, id)
+	}
+
+	return ip, nil
+}
+
+func (p *instanceEnvProvider) instanceMbusURL(ip string) (string, error) {
+	parsedURL, err := url.Parse(p.mbusURL)
+	if err != nil {
+		return "", bosherr.WrapError(err, "Parsing mbus URL")
+	}
+
+	if port := parsedURL.Port(); len(port) > 0 {
+		parsedURL.Host = net.JoinHostPort(ip, port)
+	} else {
+		parsedURL.Host = ip
+	}
+
+	return parsedURL.String(), nil
+}
@@ -14,35 +14,47 @@ type Manager interface {
 }
 
 type manager struct {
-	instanceManager   biinstance.Manager
-	diskManager       bidisk.Manager
+	instanceManagers  []biinstance.Manager
+	diskManagers      []bidisk.Manager
 	stemcellManager   bistemcell.Manager
 	deploymentFactory Factory
 }
 
 func NewManager(
-	instanceManager biinstance.Manager,
-	diskManager bidisk.Manager,
+	instanceManagers []biinstance.Manager,
+	diskManagers []bidisk.Manager,
 	stemcellManager bistemcell.Manager,
 	deploymentFactory Factory,
 ) Manager {
 	return &manager{
-		instanceManager:   instanceManager,
-		diskManager:       diskManager,
+		instanceManagers:  instanceManagers,
+		diskManagers:      diskManagers,
 		stemcellManager:   stemcellManager,
 		deploymentFactory: deploymentFactory,
 	}
 }
 
 func (m *manager) FindCurrent() (deployment Deployment, found bool, err error) {
-	instances, err := m.instanceManager.FindCurrent()
-	if err != nil {
-		return nil, false, bosherr.WrapError(err, "Finding current deployment instances")
+	instances := []biinstance.Instance{}
+
+	for _, instanceManager := range m.instanceManagers {
+		managerInstances, err := instanceManager.FindCurrent()
+		if err != nil {
+			return nil, false, bosherr.WrapError(err, "Finding current deployment instances")
+		}
+
+		instances = append(instances, managerInstances...)
 	}
 
-	disks, err := m.diskManager.FindCurrent()
-	if err != nil {
-		return nil, false, bosherr.WrapError(err, "Finding current deployment disks")
+	disks := []bidisk.Disk{}
+
+	for _, diskManager := range m.diskManagers {
+		managerDisks, err := diskManager.FindCurrent()
+		if err != nil {
+			return nil, false, bosherr.WrapError(err, "Finding current deployment disks")
+		}
+
+		disks = append(disks, managerDisks...)
 	}
 
 	stemcells, err := m.stemcellManager.FindCurrent()
@@ -58,8 +70,10 @@ func (m *manager) FindCurrent() (deployment Deployment, found bool, err error) {
 }
 
 func (m *manager) Cleanup(stage biui.Stage) error {
-	if err := m.diskManager.DeleteUnused(stage); err != nil {
-		return err
+	for _, diskManager := range m.diskManagers {
+		if err := diskManager.DeleteUnused(stage); err != nil {
+			return err
+		}
 	}
 
 	if err := m.stemcellManager.DeleteUnused(stage); err != nil {
@@ -3,6 +3,7 @@ package deployment_test
 import (
 	. "github.com/cloudfoundry/bosh-cli/deployment"
 
+	mock_httpagent "github.com/cloudfoundry/bosh-agent/agentclient/http/mocks"
 	mock_agentclient "github.com/cloudfoundry/bosh-cli/agentclient/mocks"
 	mock_blobstore "github.com/cloudfoundry/bosh-cli/blobstore/mocks"
 	mock_cloud "github.com/cloudfoundry/bosh-cli/cloud/mocks"
@@ -77,7 +78,7 @@ var _ = Describe("Manager", func() {
 
 			expectNewDeployment = mockDeploymentFactory.EXPECT().NewDeployment(expectedInstances, expectedDisks, expectedStemcells).Return(mockDeployment).AnyTimes()
 
-			deploymentManager = NewManager(mockInstanceManager, mockDiskManager, mockStemcellManager, mockDeploymentFactory)
+			deploymentManager = NewManager([]biinstance.Manager{mockInstanceManager}, []bidisk.Manager{mockDiskManager}, mockStemcellManager, mockDeploymentFactory)
 		})
 
 		Context("when no current instances, disks, or stemcells exist", func() {
@@ -198,8 +199,20 @@ var _ = Describe("Manager", func() {
 
 			mockBlobstore = mock_blobstore.NewMockBlobstore(mockCtrl)
 
-			deploymentManagerFactory := NewManagerFactory(vmManagerFactory, instanceManagerFactory, diskManagerFactory, stemcellManagerFactory, mockDeploymentFactory)
-			deploymentManager = deploymentManagerFactory.NewManager(mockCloud, mockAgentClient, mockBlobstore)
+			mockAgentClientFactory := mock_httpagent.NewMockAgentClientFactory(mockCtrl)
+			mockAgentClientFactory.EXPECT().NewAgentClient(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockAgentClient, nil).AnyTimes()
+
+			mockBlobstoreFactory := mock_blobstore.NewMockFactory(mockCtrl)
+			mockBlobstoreFactory.EXPECT().Create(gomock.Any(), gomock.Any()).Return(mockBlobstore, nil).AnyTimes()
+
+			instanceEnvProviderFactory := NewInstanceEnvProviderFactory(deploymentStateService, vmManagerFactory, mockAgentClientFactory, mockBlobstoreFactory, fakeRepoUUIDGenerator, logger)
+			instanceEnvs := instanceEnvProviderFactory.NewProvider(mockCloud, "fake-director-id", "https://fake-mbus-host:6868", "")
+
+			deploymentManagerFactory := NewManagerFactory(instanceManagerFactory, stemcellManagerFactory, mockDeploymentFactory)
+
+			var err error
+			deploymentManager, err = deploymentManagerFactory.NewManager(mockCloud, instanceEnvs)
+			Expect(err).ToNot(HaveOccurred())
 		})
 
 		Context("no orphan disk or stemcell records exist", func() {
@@ -302,6 +315,33 @@ var _ = Describe("Manager", func() {
 			})
 		})
 
+		Context("disks of other instances exist", func() {
+			BeforeEach(func() {
+				otherDiskRecord, err := diskRepo.Save("other-instance-disk-cid", 100, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				deploymentState, err := deploymentStateService.Load()
+				Expect(err).ToNot(HaveOccurred())
+
+				deploymentState.Instances = []biconfig.InstanceRecord{
+					{Name: "fake-job-name", Index: 1, IP: "10.0.0.2", DiskID: otherDiskRecord.ID},
+				}
+
+				err = deploymentStateService.Save(deploymentState)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not delete them", func() {
+				err := deploymentManager.Cleanup(fakeStage)
+				Expect(err).ToNot(HaveOccurred())
+
+				diskRecords, err := diskRepo.All()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(diskRecords).To(HaveLen(1))
+				Expect(diskRecords[0].CID).To(Equal("other-instance-disk-cid"))
+			})
+		})
+
 		Context("orphan stemcell records exist", func() {
 			BeforeEach(func() {
 				_, err := stemcellRepo.Save("orphan-stemcell-name", "orphan-stemcell-version", "orphan-stemcell-cid")
@@ -24,6 +24,12 @@ type Update struct {
 // We can't use map[string]NetworkInterface, because it's impossible to down-cast to what the cloud client requires.
 //TODO: refactor to NetworkInterfaces(Job) and use FindJobByName before using (then remove error)
 func (d Manifest) NetworkInterfaces(jobName string) (map[string]biproperty.Map, error) {
+	return d.InstanceNetworkInterfaces(jobName, 0)
+}
+
+// InstanceNetworkInterfaces returns network interfaces of a single job instance.
+// Instance with a given index is assigned static IP with the same index.
+func (d Manifest) InstanceNetworkInterfaces(jobName string, index int) (map[string]biproperty.Map, error) {
 	job, found := d.FindJobByName(jobName)
 	if !found {
 		return map[string]biproperty.Map{}, bosherr.Errorf("Could not find job with name: %s", jobName)
@@ -35,7 +41,11 @@ func (d Manifest) NetworkInterfaces(jobName string) (map[string]biproperty.Map,
 	var err error
 	for _, jobNetwork := range job.Networks {
 		network := networkMap[jobNetwork.Name]
-		ifaceMap[jobNetwork.Name], err = network.Interface(jobNetwork.StaticIPs, jobNetwork.Defaults)
+		var staticIPs []string
+		if index < len(jobNetwork.StaticIPs) {
+			staticIPs = jobNetwork.StaticIPs[index:]
+		}
+		ifaceMap[jobNetwork.Name], err = network.Interface(staticIPs, jobNetwork.Defaults)
 		if err != nil {
 			return map[string]biproperty.Map{}, bosherr.WrapError(err, "Building network interface")
 		}
@@ -47,8 +57,8 @@ func (d Manifest) NetworkInterfaces(jobName string) (map[string]biproperty.Map,
 	return ifaceMap, nil
 }
 
+// JobName returns name of the first job which is used to pick deployment stemcell
 func (d Manifest) JobName() string {
-	// Currently we deploy only one job
 	return d.Jobs[0].Name
 }
 
@@ -163,6 +163,22 @@ var _ = Describe("Manifest", func() {
 				Expect(err.Error()).To(ContainSubstring("Could not find job with name: non-existant-job"))
 			})
 
+			It("assigns static ip with instance index to an instance", func() {
+				deploymentManifest.Jobs[0].Networks[0].StaticIPs = []string{"1.2.3.4", "1.2.3.5"}
+
+				networkInterfaces, err := deploymentManifest.InstanceNetworkInterfaces("fake-job-name", 1)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(networkInterfaces["vip"]).To(Equal(biproperty.Map{
+					"type":             "vip",
+					"ip":               "1.2.3.5",
+					"cloud_properties": biproperty.Map{},
+				}))
+				Expect(networkInterfaces["fake-network-name"]).To(Equal(biproperty.Map{
+					"type":             "dynamic",
+					"cloud_properties": biproperty.Map{},
+				}))
+			})
+
 			It("returns an empty map when job does not specify networks", func() {
 				Expect(deploymentManifest.NetworkInterfaces("job-without-networks")).To(Equal(map[string]biproperty.Map{}))
 			})
@@ -70,14 +70,15 @@ func (v *validator) Validate(deploymentManifest Manifest, releaseSetManifest bir
 		}
 	}
 
-	if len(deploymentManifest.Jobs) > 1 {
-		errs = append(errs, bosherr.Error("jobs must be of size 1"))
-	}
-
+	jobNames := map[string]struct{}{}
 	for idx, job := range deploymentManifest.Jobs {
 		if v.isBlank(job.Name) {
 			errs = append(errs, bosherr.Errorf("jobs[%d].name must be provided", idx))
+		} else if _, found := jobNames[job.Name]; found {
+			errs = append(errs, bosherr.Errorf("jobs[%d].name '%s' must be unique", idx, job.Name))
 		}
+		jobNames[job.Name] = struct{}{}
+
 		if job.PersistentDisk < 0 {
 			errs = append(errs, bosherr.Errorf("jobs[%d].persistent_disk must be >= 0", idx))
 		}
@@ -97,10 +98,13 @@ func (v *validator) Validate(deploymentManifest Manifest, releaseSetManifest bir
 		} else {
 			if _, ok := v.resourcePoolNames(deploymentManifest)[job.ResourcePool]; !ok {
 				errs = append(errs, bosherr.Errorf("jobs[%d].resource_pool must be the name of a resource pool", idx))
+			} else if idx > 0 && !v.hasSameStemcell(deploymentManifest, job.Name, deploymentManifest.Jobs[0].Name) {
+				errs = append(errs, bosherr.Errorf("jobs[%d].resource_pool must use the same stemcell as jobs[0].resource_pool", idx))
 			}
 		}
 
 		errs = append(errs, v.validateJobNetworks(job.Networks, deploymentManifest.Networks, idx)...)
+		errs = append(errs, v.validateJobInstanceIPs(job, idx)...)
 
 		if job.Lifecycle != "" && job.Lifecycle != JobLifecycleService {
 			errs = append(errs, bosherr.Errorf("jobs[%d].lifecycle must be 'service' ('%s' not supported)", idx, job.Lifecycle))
@@ -318,6 +322,46 @@ func (v *validator) validateJobNetworks(jobNetworks []JobNetwork, networks []Net
 	return errs
 }
 
+// validateJobInstanceIPs makes sure that every instance, except for the first instance
+// of the first job which is reachable via installation mbus, has its own static IP
+func (v *validator) validateJobInstanceIPs(job Job, jobIdx int) []error {
+	errs := []error{}
+	hasStaticIPs := false
+
+	for networkIdx, jobNetwork := range job.Networks {
+		if len(jobNetwork.StaticIPs) == 0 {
+			continue
+		}
+		hasStaticIPs = true
+
+		if len(jobNetwork.StaticIPs) < job.Instances {
+			errs = append(errs, bosherr.Errorf("jobs[%d].networks[%d].static_ips must have an IP for each of %d instances", jobIdx, networkIdx, job.Instances))
+		}
+	}
+
+	additionalInstances := job.Instances > 1 || (jobIdx > 0 && job.Instances > 0)
+
+	if additionalInstances && !hasStaticIPs {
+		errs = append(errs, bosherr.Errorf("jobs[%d].networks must specify static_ips for each instance", jobIdx))
+	}
+
+	return errs
+}
+
+func (v *validator) hasSameStemcell(deploymentManifest Manifest, jobName, firstJobName string) bool {
+	stemcell, err := deploymentManifest.Stemcell(jobName)
+	if err != nil {
+		return false
+	}
+
+	firstStemcell, err := deploymentManifest.Stemcell(firstJobName)
+	if err != nil {
+		return true
+	}
+
+	return stemcell == firstStemcell
+}
+
 func (v *validator) validateStaticIP(ip string, jobNetwork JobNetwork, network Network, jobIdx, networkIdx, ipIdx int) []error {
 	if !v.isValidIP(ip) {
 		return []error{bosherr.Errorf("jobs[%d].networks[%d].static_ips[%d] must be a valid IP", jobIdx, networkIdx, ipIdx)}
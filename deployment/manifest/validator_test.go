@@ -572,17 +572,79 @@ var _ = Describe("Validator", func() {
 			})
 		})
 
-		It("validates that there is only one job", func() {
+		It("validates that job names are unique", func() {
 			deploymentManifest := Manifest{
 				Jobs: []Job{
-					{},
-					{},
+					{Name: "fake-job-name"},
+					{Name: "fake-job-name"},
 				},
 			}
 
 			err := validator.Validate(deploymentManifest, validReleaseSetManifest)
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("jobs must be of size 1"))
+			Expect(err.Error()).To(ContainSubstring("jobs[1].name 'fake-job-name' must be unique"))
+		})
+
+		It("allows multiple jobs and instances with static ips", func() {
+			deploymentManifest := validManifest
+			deploymentManifest.Jobs = []Job{validManifest.Jobs[0], validManifest.Jobs[0]}
+			deploymentManifest.Jobs[0].Instances = 1
+			deploymentManifest.Jobs[1].Name = "fake-other-job-name"
+			deploymentManifest.Jobs[1].Instances = 2
+			deploymentManifest.Jobs[1].Networks = []JobNetwork{
+				{
+					Name:      "fake-network-name",
+					StaticIPs: []string{"10.0.0.2", "10.0.0.3"},
+				},
+			}
+
+			err := validator.Validate(deploymentManifest, validReleaseSetManifest)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("validates that additional instances have static ips", func() {
+			deploymentManifest := validManifest
+			deploymentManifest.Jobs = []Job{validManifest.Jobs[0]}
+			deploymentManifest.Jobs[0].Instances = 2
+
+			err := validator.Validate(deploymentManifest, validReleaseSetManifest)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("jobs[0].networks must specify static_ips for each instance"))
+		})
+
+		It("validates that there is a static ip for each instance", func() {
+			deploymentManifest := validManifest
+			deploymentManifest.Jobs = []Job{validManifest.Jobs[0]}
+			deploymentManifest.Jobs[0].Instances = 2
+			deploymentManifest.Jobs[0].Networks = []JobNetwork{
+				{
+					Name:      "fake-network-name",
+					StaticIPs: []string{"10.0.0.2"},
+				},
+			}
+
+			err := validator.Validate(deploymentManifest, validReleaseSetManifest)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("jobs[0].networks[0].static_ips must have an IP for each of 2 instances"))
+		})
+
+		It("validates that all jobs use the same stemcell", func() {
+			deploymentManifest := validManifest
+			deploymentManifest.ResourcePools = []ResourcePool{
+				validManifest.ResourcePools[0],
+				{
+					Name:     "fake-other-resource-pool-name",
+					Network:  "fake-network-name",
+					Stemcell: StemcellRef{URL: "file://fake-other-stemcell-url"},
+				},
+			}
+			deploymentManifest.Jobs = []Job{validManifest.Jobs[0], validManifest.Jobs[0]}
+			deploymentManifest.Jobs[1].Name = "fake-other-job-name"
+			deploymentManifest.Jobs[1].ResourcePool = "fake-other-resource-pool-name"
+
+			err := validator.Validate(deploymentManifest, validReleaseSetManifest)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("jobs[1].resource_pool must use the same stemcell as jobs[0].resource_pool"))
 		})
 
 		It("validates job name", func() {
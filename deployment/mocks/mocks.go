@@ -1,17 +1,14 @@
 // Automatically generated by MockGen. DO NOT EDIT!
-// Source: github.com/cloudfoundry/bosh-cli/deployment (interfaces: Deployment,Factory,Deployer,Manager,ManagerFactory)
+// Source: github.com/cloudfoundry/bosh-cli/deployment (interfaces: Deployment,Factory,Deployer,Manager,ManagerFactory,InstanceEnvProvider,InstanceEnvProviderFactory)
 
 package mocks
 
 import (
-	agentclient "github.com/cloudfoundry/bosh-agent/agentclient"
-	blobstore "github.com/cloudfoundry/bosh-cli/blobstore"
 	cloud "github.com/cloudfoundry/bosh-cli/cloud"
 	deployment "github.com/cloudfoundry/bosh-cli/deployment"
 	disk "github.com/cloudfoundry/bosh-cli/deployment/disk"
 	instance "github.com/cloudfoundry/bosh-cli/deployment/instance"
 	manifest "github.com/cloudfoundry/bosh-cli/deployment/manifest"
-	vm "github.com/cloudfoundry/bosh-cli/deployment/vm"
 	manifest0 "github.com/cloudfoundry/bosh-cli/installation/manifest"
 	stemcell "github.com/cloudfoundry/bosh-cli/stemcell"
 	ui "github.com/cloudfoundry/bosh-cli/ui"
@@ -101,15 +98,15 @@ func (_m *MockDeployer) EXPECT() *_MockDeployerRecorder {
 	return _m.recorder
 }
 
-func (_m *MockDeployer) Deploy(_param0 cloud.Cloud, _param1 manifest.Manifest, _param2 stemcell.CloudStemcell, _param3 manifest0.Registry, _param4 vm.Manager, _param5 blobstore.Blobstore, _param6 ui.Stage) (deployment.Deployment, error) {
-	ret := _m.ctrl.Call(_m, "Deploy", _param0, _param1, _param2, _param3, _param4, _param5, _param6)
+func (_m *MockDeployer) Deploy(_param0 cloud.Cloud, _param1 manifest.Manifest, _param2 stemcell.CloudStemcell, _param3 manifest0.Registry, _param4 deployment.InstanceEnvProvider, _param5 ui.Stage) (deployment.Deployment, error) {
+	ret := _m.ctrl.Call(_m, "Deploy", _param0, _param1, _param2, _param3, _param4, _param5)
 	ret0, _ := ret[0].(deployment.Deployment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (_mr *_MockDeployerRecorder) Deploy(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "Deploy", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+func (_mr *_MockDeployerRecorder) Deploy(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Deploy", arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // Mock of Manager interface
@@ -176,12 +173,87 @@ func (_m *MockManagerFactory) EXPECT() *_MockManagerFactoryRecorder {
 	return _m.recorder
 }
 
-func (_m *MockManagerFactory) NewManager(_param0 cloud.Cloud, _param1 agentclient.AgentClient, _param2 blobstore.Blobstore) deployment.Manager {
-	ret := _m.ctrl.Call(_m, "NewManager", _param0, _param1, _param2)
+func (_m *MockManagerFactory) NewManager(_param0 cloud.Cloud, _param1 deployment.InstanceEnvProvider) (deployment.Manager, error) {
+	ret := _m.ctrl.Call(_m, "NewManager", _param0, _param1)
 	ret0, _ := ret[0].(deployment.Manager)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockManagerFactoryRecorder) NewManager(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "NewManager", arg0, arg1)
+}
+
+// Mock of InstanceEnvProvider interface
+type MockInstanceEnvProvider struct {
+	ctrl     *gomock.Controller
+	recorder *_MockInstanceEnvProviderRecorder
+}
+
+// Recorder for MockInstanceEnvProvider (not exported)
+type _MockInstanceEnvProviderRecorder struct {
+	mock *MockInstanceEnvProvider
+}
+
+func NewMockInstanceEnvProvider(ctrl *gomock.Controller) *MockInstanceEnvProvider {
+	mock := &MockInstanceEnvProvider{ctrl: ctrl}
+	mock.recorder = &_MockInstanceEnvProviderRecorder{mock}
+	return mock
+}
+
+func (_m *MockInstanceEnvProvider) EXPECT() *_MockInstanceEnvProviderRecorder {
+	return _m.recorder
+}
+
+func (_m *MockInstanceEnvProvider) ForInstance(_param0 manifest.Manifest, _param1 string, _param2 int) (deployment.InstanceEnv, error) {
+	ret := _m.ctrl.Call(_m, "ForInstance", _param0, _param1, _param2)
+	ret0, _ := ret[0].(deployment.InstanceEnv)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockInstanceEnvProviderRecorder) ForInstance(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ForInstance", arg0, arg1, arg2)
+}
+
+func (_m *MockInstanceEnvProvider) Recorded() ([]deployment.InstanceEnv, error) {
+	ret := _m.ctrl.Call(_m, "Recorded")
+	ret0, _ := ret[0].([]deployment.InstanceEnv)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockInstanceEnvProviderRecorder) Recorded() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Recorded")
+}
+
+// Mock of InstanceEnvProviderFactory interface
+type MockInstanceEnvProviderFactory struct {
+	ctrl     *gomock.Controller
+	recorder *_MockInstanceEnvProviderFactoryRecorder
+}
+
+// Recorder for MockInstanceEnvProviderFactory (not exported)
+type _MockInstanceEnvProviderFactoryRecorder struct {
+	mock *MockInstanceEnvProviderFactory
+}
+
+func NewMockInstanceEnvProviderFactory(ctrl *gomock.Controller) *MockInstanceEnvProviderFactory {
+	mock := &MockInstanceEnvProviderFactory{ctrl: ctrl}
+	mock.recorder = &_MockInstanceEnvProviderFactoryRecorder{mock}
+	return mock
+}
+
+func (_m *MockInstanceEnvProviderFactory) EXPECT() *_MockInstanceEnvProviderFactoryRecorder {
+	return _m.recorder
+}
+
+func (_m *MockInstanceEnvProviderFactory) NewProvider(_param0 cloud.Cloud, _param1 string, _param2 string, _param3 string) deployment.InstanceEnvProvider {
+	ret := _m.ctrl.Call(_m, "NewProvider", _param0, _param1, _param2, _param3)
+	ret0, _ := ret[0].(deployment.InstanceEnvProvider)
 	return ret0
 }
 
-func (_mr *_MockManagerFactoryRecorder) NewManager(arg0, arg1, arg2 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "NewManager", arg0, arg1, arg2)
+func (_mr *_MockInstanceEnvProviderFactoryRecorder) NewProvider(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "NewProvider", arg0, arg1, arg2, arg3)
 }
@@ -0,0 +1,293 @@
+package deployment
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	biconfig "github.com/cloudfoundry/bosh-cli/config"
+	bideplmanifest "github.com/cloudfoundry/bosh-cli/deployment/manifest"
+	birel "github.com/cloudfoundry/bosh-cli/release"
+	bistemcell "github.com/cloudfoundry/bosh-cli/stemcell"
+)
+
+// Plan lists CPI actions that would be taken to converge deployment state.
+// It is built only from recorded deployment state hence it never calls the CPI.
+type Plan struct {
+	Steps []PlanStep
+}
+
+type PlanStep struct {
+	Action      string // CPI method
+	Description string
+	Reason      string
+}
+
+func (p *Plan) add(action, reason, descPattern string, args ...interface{}) {
+	p.Steps = append(p.Steps, PlanStep{
+		Action:      action,
+		Description: fmt.Sprintf(descPattern, args...),
+		Reason:      reason,
+	})
+}
+
+// NewDeployPlan mirrors steps taken by Deployer and DiskDeployer
+// when deploying given manifest over recorded deployment state
+func NewDeployPlan(
+	state biconfig.DeploymentState,
+	deploymentManifest bideplmanifest.Manifest,
+	manifestSHA string,
+	stemcell bistemcell.Manifest,
+	releases []birel.Release,
+	recreate bool,
+) (Plan, error) {
+	var plan Plan
+
+	stemcellName := fmt.Sprintf("%s/%s", stemcell.Name, stemcell.Version)
+
+	stemcellRec, found := findStemcell(state, stemcell.Name, stemcell.Version)
+	if found {
+		stemcellName = fmt.Sprintf("%s (%s)", stemcellName, stemcellRec.CID)
+	} else {
+		plan.add("create_stemcell", "stemcell was not uploaded before", "Upload stemcell '%s'", stemcellName)
+	}
+
+	reason := strings.Join(recreateReasons(state, manifestSHA, stemcell, releases, recreate), ", ")
+
+	for _, vmCID := range recordedVMCIDs(state) {
+		plan.add("delete_vm", reason, "Delete VM '%s'", vmCID)
+	}
+
+	usedDiskIDs := map[string]bool{state.CurrentDiskID: true}
+
+	for _, record := range state.Instances {
+		usedDiskIDs[record.DiskID] = true
+
+		job, found := deploymentManifest.FindJobByName(record.Name)
+		if found && record.Index < job.Instances {
+			continue
+		}
+
+		if disk, found := findDisk(state, record.DiskID); found {
+			plan.add("delete_disk", "instance was removed from manifest", "Delete disk '%s' of instance '%s/%d'", disk.CID, record.Name, record.Index)
+		}
+	}
+
+	deleteUnusedDisks := false
+
+	for jobIdx, job := range deploymentManifest.Jobs {
+		for index := 0; index < job.Instances; index++ {
+			plan.add("create_vm", "", "Create VM for instance '%s/%d' from stemcell '%s'", job.Name, index, stemcellName)
+
+			diskPool, err := deploymentManifest.DiskPool(job.Name)
+			if err != nil {
+				return plan, bosherr.WrapErrorf(err, "Finding disk pool for job '%s'", job.Name)
+			}
+
+			if diskPool.DiskSize == 0 {
+				continue
+			}
+
+			deleteUnusedDisks = true
+
+			diskID := state.CurrentDiskID
+			if jobIdx > 0 || index > 0 {
+				diskID = instanceDiskID(state, job.Name, index)
+			}
+
+			if currentDisk, found := findDisk(state, diskID); found {
+				plan.add("attach_disk", "", "Attach disk '%s'", currentDisk.CID)
+
+				if reason, needed := migrationReason(currentDisk, diskPool); needed {
+					plan.add("create_disk", reason, "Create disk of size %d MB", diskPool.DiskSize)
+					plan.add("attach_disk", "", "Attach new disk")
+					plan.add("detach_disk", "data was migrated to new disk", "Detach disk '%s'", currentDisk.CID)
+					plan.add("delete_disk", "data was migrated to new disk", "Delete disk '%s'", currentDisk.CID)
+				}
+			} else {
+				plan.add("create_disk", "persistent disk is requested", "Create disk of size %d MB", diskPool.DiskSize)
+				plan.add("attach_disk", "", "Attach new disk")
+			}
+		}
+	}
+
+	if deleteUnusedDisks {
+		for _, disk := range state.Disks {
+			if !usedDiskIDs[disk.ID] {
+				plan.add("delete_disk", "disk is not used", "Delete disk '%s'", disk.CID)
+			}
+		}
+	}
+
+	for _, rec := range state.Stemcells {
+		if rec.Name != stemcell.Name || rec.Version != stemcell.Version {
+			plan.add("delete_stemcell", "stemcell is not used", "Delete stemcell '%s/%s' (%s)", rec.Name, rec.Version, rec.CID)
+		}
+	}
+
+	return plan, nil
+}
+
+// NewDeletePlan mirrors steps taken by deployment Manager when deleting recorded deployment
+func NewDeletePlan(state biconfig.DeploymentState) Plan {
+	var plan Plan
+
+	for _, vmCID := range recordedVMCIDs(state) {
+		plan.add("delete_vm", "", "Delete VM '%s'", vmCID)
+	}
+
+	for _, disk := range state.Disks {
+		plan.add("delete_disk", "", "Delete disk '%s'", disk.CID)
+	}
+
+	for _, rec := range state.Stemcells {
+		plan.add("delete_stemcell", "", "Delete stemcell '%s/%s' (%s)", rec.Name, rec.Version, rec.CID)
+	}
+
+	return plan
+}
+
+func recreateReasons(
+	state biconfig.DeploymentState,
+	manifestSHA string,
+	stemcell bistemcell.Manifest,
+	releases []birel.Release,
+	recreate bool,
+) []string {
+	var reasons []string
+
+	if recreate {
+		reasons = append(reasons, "recreate was requested")
+	}
+
+	if len(state.CurrentManifestSHA) == 0 {
+		// Deployment record is cleared at the start of each deploy
+		reasons = append(reasons, "previous deploy did not finish")
+	} else if state.CurrentManifestSHA != manifestSHA {
+		reasons = append(reasons, "manifest SHA changed")
+	}
+
+	if currentStemcell, found := findCurrentStemcell(state); found {
+		if currentStemcell.Name != stemcell.Name || currentStemcell.Version != stemcell.Version {
+			reasons = append(reasons, fmt.Sprintf("stemcell changed from '%s/%s' to '%s/%s'",
+				currentStemcell.Name, currentStemcell.Version, stemcell.Name, stemcell.Version))
+		}
+	}
+
+	if releasesChanged(state, releases) {
+		reasons = append(reasons, "releases changed")
+	}
+
+	if len(reasons) == 0 {
+		reasons = append(reasons, "deployment changed")
+	}
+
+	return reasons
+}
+
+func releasesChanged(state biconfig.DeploymentState, releases []birel.Release) bool {
+	var current []biconfig.ReleaseRecord
+
+	for _, id := range state.CurrentReleaseIDs {
+		for _, rec := range state.Releases {
+			if rec.ID == id {
+				current = append(current, rec)
+			}
+		}
+	}
+
+	if len(current) != len(releases) {
+		return true
+	}
+
+	for _, release := range releases {
+		found := false
+
+		for _, rec := range current {
+			if rec.Name == release.Name() && rec.Version == release.Version() {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return true
+		}
+	}
+
+	return false
+}
+
+func migrationReason(disk biconfig.DiskRecord, diskPool bideplmanifest.DiskPool) (string, bool) {
+	if disk.Size != diskPool.DiskSize {
+		return fmt.Sprintf("disk pool size changed from %d MB to %d MB", disk.Size, diskPool.DiskSize), true
+	}
+
+	if !reflect.DeepEqual(disk.CloudProperties, diskPool.CloudProperties) {
+		return "disk pool cloud properties changed", true
+	}
+
+	return "", false
+}
+
+func findStemcell(state biconfig.DeploymentState, name, version string) (biconfig.StemcellRecord, bool) {
+	for _, rec := range state.Stemcells {
+		if rec.Name == name && rec.Version == version {
+			return rec, true
+		}
+	}
+
+	return biconfig.StemcellRecord{}, false
+}
+
+func findCurrentStemcell(state biconfig.DeploymentState) (biconfig.StemcellRecord, bool) {
+	for _, rec := range state.Stemcells {
+		if len(state.CurrentStemcellID) > 0 && rec.ID == state.CurrentStemcellID {
+			return rec, true
+		}
+	}
+
+	return biconfig.StemcellRecord{}, false
+}
+
+func recordedVMCIDs(state biconfig.DeploymentState) []string {
+	var cids []string
+
+	if len(state.CurrentVMCID) > 0 {
+		cids = append(cids, state.CurrentVMCID)
+	}
+
+	for _, record := range state.Instances {
+		if len(record.VMCID) > 0 {
+			cids = append(cids, record.VMCID)
+		}
+	}
+
+	return cids
+}
+
+func instanceDiskID(state biconfig.DeploymentState, jobName string, index int) string {
+	for _, record := range state.Instances {
+		if record.Name == jobName && record.Index == index {
+			return record.DiskID
+		}
+	}
+
+	return ""
+}
+
+func findDisk(state biconfig.DeploymentState, id string) (biconfig.DiskRecord, bool) {
+	if len(id) == 0 {
+		return biconfig.DiskRecord{}, false
+	}
+
+	for _, rec := range state.Disks {
+		if rec.ID == id {
+			return rec, true
+		}
+	}
+
+	return biconfig.DiskRecord{}, false
+}
@@ -0,0 +1,262 @@
+package deployment_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	biconfig "github.com/cloudfoundry/bosh-cli/config"
+	. "github.com/cloudfoundry/bosh-cli/deployment"
+	bideplmanifest "github.com/cloudfoundry/bosh-cli/deployment/manifest"
+	birel "github.com/cloudfoundry/bosh-cli/release"
+	fakerel "github.com/cloudfoundry/bosh-cli/release/releasefakes"
+	bistemcell "github.com/cloudfoundry/bosh-cli/stemcell"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+)
+
+var _ = Describe("Plan", func() {
+	var (
+		state              biconfig.DeploymentState
+		deploymentManifest bideplmanifest.Manifest
+		stemcell           bistemcell.Manifest
+		releases           []birel.Release
+	)
+
+	BeforeEach(func() {
+		state = biconfig.DeploymentState{}
+
+		deploymentManifest = bideplmanifest.Manifest{
+			Name: "fake-deployment",
+			Jobs: []bideplmanifest.Job{
+				{Name: "fake-job", Instances: 1, PersistentDisk: 1024},
+			},
+		}
+
+		stemcell = bistemcell.Manifest{Name: "fake-stemcell", Version: "1"}
+
+		release := &fakerel.FakeRelease{}
+		release.NameReturns("fake-release")
+		release.VersionReturns("1")
+		releases = []birel.Release{release}
+	})
+
+	actions := func(plan Plan) []string {
+		var result []string
+		for _, step := range plan.Steps {
+			result = append(result, step.Action)
+		}
+		return result
+	}
+
+	Describe("NewDeployPlan", func() {
+		It("creates stemcell, VM and disk when nothing was deployed", func() {
+			plan, err := NewDeployPlan(state, deploymentManifest, "fake-sha", stemcell, releases, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(plan.Steps).To(Equal([]PlanStep{
+				{
+					Action:      "create_stemcell",
+					Description: "Upload stemcell 'fake-stemcell/1'",
+					Reason:      "stemcell was not uploaded before",
+				},
+				{
+					Action:      "create_vm",
+					Description: "Create VM for instance 'fake-job/0' from stemcell 'fake-stemcell/1'",
+				},
+				{
+					Action:      "create_disk",
+					Description: "Create disk of size 1024 MB",
+					Reason:      "persistent disk is requested",
+				},
+				{
+					Action:      "attach_disk",
+					Description: "Attach new disk",
+				},
+			}))
+		})
+
+		It("does not plan disk steps when persistent disk is not requested", func() {
+			deploymentManifest.Jobs[0].PersistentDisk = 0
+
+			plan, err := NewDeployPlan(state, deploymentManifest, "fake-sha", stemcell, releases, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actions(plan)).To(Equal([]string{"create_stemcell", "create_vm"}))
+		})
+
+		Context("when deployment was deployed before", func() {
+			BeforeEach(func() {
+				state = biconfig.DeploymentState{
+					CurrentVMCID:       "fake-vm-cid",
+					CurrentStemcellID:  "stemcell-id",
+					CurrentDiskID:      "disk-id",
+					CurrentReleaseIDs:  []string{"release-id"},
+					CurrentManifestSHA: "fake-sha",
+					Disks: []biconfig.DiskRecord{
+						{ID: "disk-id", CID: "fake-disk-cid", Size: 1024, CloudProperties: biproperty.Map{}},
+					},
+					Stemcells: []biconfig.StemcellRecord{
+						{ID: "stemcell-id", Name: "fake-stemcell", Version: "1", CID: "fake-stemcell-cid"},
+					},
+					Releases: []biconfig.ReleaseRecord{
+						{ID: "release-id", Name: "fake-release", Version: "1"},
+					},
+				}
+			})
+
+			It("recreates VM and reattaches existing disk", func() {
+				plan, err := NewDeployPlan(state, deploymentManifest, "fake-sha", stemcell, releases, true)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(plan.Steps).To(Equal([]PlanStep{
+					{
+						Action:      "delete_vm",
+						Description: "Delete VM 'fake-vm-cid'",
+						Reason:      "recreate was requested",
+					},
+					{
+						Action:      "create_vm",
+						Description: "Create VM for instance 'fake-job/0' from stemcell 'fake-stemcell/1 (fake-stemcell-cid)'",
+					},
+					{
+						Action:      "attach_disk",
+						Description: "Attach disk 'fake-disk-cid'",
+					},
+				}))
+			})
+
+			It("explains why VM is recreated", func() {
+				stemcell.Version = "2"
+
+				release := &fakerel.FakeRelease{}
+				release.NameReturns("fake-release")
+				release.VersionReturns("2")
+
+				plan, err := NewDeployPlan(state, deploymentManifest, "new-sha", stemcell, []birel.Release{release}, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(actions(plan)).To(Equal([]string{
+					"create_stemcell", "delete_vm", "create_vm", "attach_disk", "delete_stemcell",
+				}))
+				Expect(plan.Steps[1].Reason).To(Equal(
+					"manifest SHA changed, stemcell changed from 'fake-stemcell/1' to 'fake-stemcell/2', releases changed"))
+				Expect(plan.Steps[4]).To(Equal(PlanStep{
+					Action:      "delete_stemcell",
+					Description: "Delete stemcell 'fake-stemcell/1' (fake-stemcell-cid)",
+					Reason:      "stemcell is not used",
+				}))
+			})
+
+			It("explains that previous deploy did not finish", func() {
+				state.CurrentManifestSHA = ""
+
+				plan, err := NewDeployPlan(state, deploymentManifest, "fake-sha", stemcell, releases, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(plan.Steps[0].Reason).To(Equal("previous deploy did not finish"))
+			})
+
+			It("migrates disk when disk pool size changes", func() {
+				deploymentManifest.Jobs[0].PersistentDisk = 2048
+
+				plan, err := NewDeployPlan(state, deploymentManifest, "new-sha", stemcell, releases, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(actions(plan)).To(Equal([]string{
+					"delete_vm", "create_vm", "attach_disk", "create_disk", "attach_disk", "detach_disk", "delete_disk",
+				}))
+				Expect(plan.Steps[3].Reason).To(Equal("disk pool size changed from 1024 MB to 2048 MB"))
+				Expect(plan.Steps[6].Description).To(Equal("Delete disk 'fake-disk-cid'"))
+			})
+
+			It("deletes unused disks", func() {
+				state.Disks = append(state.Disks, biconfig.DiskRecord{ID: "orphan-id", CID: "orphan-disk-cid"})
+
+				plan, err := NewDeployPlan(state, deploymentManifest, "new-sha", stemcell, releases, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(plan.Steps[len(plan.Steps)-1]).To(Equal(PlanStep{
+					Action:      "delete_disk",
+					Description: "Delete disk 'orphan-disk-cid'",
+					Reason:      "disk is not used",
+				}))
+			})
+		})
+
+		Context("when deployment has multiple instances", func() {
+			BeforeEach(func() {
+				deploymentManifest.Jobs[0].Instances = 2
+
+				state = biconfig.DeploymentState{
+					CurrentVMCID:  "fake-vm-cid",
+					CurrentDiskID: "disk-id",
+					Disks: []biconfig.DiskRecord{
+						{ID: "disk-id", CID: "fake-disk-cid", Size: 1024, CloudProperties: biproperty.Map{}},
+						{ID: "other-disk-id", CID: "fake-other-disk-cid", Size: 1024, CloudProperties: biproperty.Map{}},
+						{ID: "removed-disk-id", CID: "fake-removed-disk-cid", Size: 1024},
+					},
+					Instances: []biconfig.InstanceRecord{
+						{Name: "fake-job", Index: 1, VMCID: "fake-other-vm-cid", DiskID: "other-disk-id"},
+						{Name: "fake-removed-job", Index: 0, DiskID: "removed-disk-id"},
+					},
+				}
+			})
+
+			It("recreates VMs of all instances and deletes disks of removed instances", func() {
+				plan, err := NewDeployPlan(state, deploymentManifest, "fake-sha", stemcell, releases, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				var descriptions []string
+				for _, step := range plan.Steps {
+					descriptions = append(descriptions, step.Description)
+				}
+
+				Expect(descriptions).To(Equal([]string{
+					"Upload stemcell 'fake-stemcell/1'",
+					"Delete VM 'fake-vm-cid'",
+					"Delete VM 'fake-other-vm-cid'",
+					"Delete disk 'fake-removed-disk-cid' of instance 'fake-removed-job/0'",
+					"Create VM for instance 'fake-job/0' from stemcell 'fake-stemcell/1'",
+					"Attach disk 'fake-disk-cid'",
+					"Create VM for instance 'fake-job/1' from stemcell 'fake-stemcell/1'",
+					"Attach disk 'fake-other-disk-cid'",
+				}))
+			})
+		})
+
+		It("returns error if disk pool cannot be found", func() {
+			deploymentManifest.Jobs[0].PersistentDiskPool = "missing-pool"
+
+			_, err := NewDeployPlan(state, deploymentManifest, "fake-sha", stemcell, releases, false)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Finding disk pool for job 'fake-job'"))
+		})
+	})
+
+	Describe("NewDeletePlan", func() {
+		It("deletes VM, disks and stemcells", func() {
+			state = biconfig.DeploymentState{
+				CurrentVMCID: "fake-vm-cid",
+				Disks:        []biconfig.DiskRecord{{CID: "fake-disk-cid"}},
+				Stemcells:    []biconfig.StemcellRecord{{Name: "fake-stemcell", Version: "1", CID: "fake-stemcell-cid"}},
+			}
+
+			Expect(NewDeletePlan(state).Steps).To(Equal([]PlanStep{
+				{Action: "delete_vm", Description: "Delete VM 'fake-vm-cid'"},
+				{Action: "delete_disk", Description: "Delete disk 'fake-disk-cid'"},
+				{Action: "delete_stemcell", Description: "Delete stemcell 'fake-stemcell/1' (fake-stemcell-cid)"},
+			}))
+		})
+
+		It("deletes VMs of all instances", func() {
+			state = biconfig.DeploymentState{
+				CurrentVMCID: "fake-vm-cid",
+				Instances:    []biconfig.InstanceRecord{{Name: "fake-job", Index: 1, VMCID: "fake-other-vm-cid"}},
+			}
+
+			Expect(actions(NewDeletePlan(state))).To(Equal([]string{"delete_vm", "delete_vm"}))
+			Expect(NewDeletePlan(state).Steps[1].Description).To(Equal("Delete VM 'fake-other-vm-cid'"))
+		})
+
+		It("returns empty plan when nothing was deployed", func() {
+			Expect(NewDeletePlan(biconfig.DeploymentState{}).Steps).To(BeEmpty())
+		})
+	})
+})
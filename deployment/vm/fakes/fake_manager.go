@@ -9,6 +9,8 @@ import (
 type CreateInput struct {
 	Stemcell bistemcell.CloudStemcell
 	Manifest bideplmanifest.Manifest
+	JobName  string
+	Index    int
 }
 
 type FakeManager struct {
@@ -33,10 +35,12 @@ func (m *FakeManager) FindCurrent() (bivm.VM, bool, error) {
 	return m.findCurrentBehaviour.vm, m.findCurrentBehaviour.found, m.findCurrentBehaviour.err
 }
 
-func (m *FakeManager) Create(stemcell bistemcell.CloudStemcell, deploymentManifest bideplmanifest.Manifest) (bivm.VM, error) {
+func (m *FakeManager) Create(stemcell bistemcell.CloudStemcell, deploymentManifest bideplmanifest.Manifest, jobName string, index int) (bivm.VM, error) {
 	input := CreateInput{
 		Stemcell: stemcell,
 		Manifest: deploymentManifest,
+		JobName:  jobName,
+		Index:    index,
 	}
 	m.CreateInput = input
 
@@ -13,12 +13,13 @@ import (
 	biproperty "github.com/cloudfoundry/bosh-utils/property"
 	boshsys "github.com/cloudfoundry/bosh-utils/system"
 	boshuuid "github.com/cloudfoundry/bosh-utils/uuid"
+	"strconv"
 	"time"
 )
 
 type Manager interface {
 	FindCurrent() (VM, bool, error)
-	Create(bistemcell.CloudStemcell, bideplmanifest.Manifest) (VM, error)
+	Create(stemcell bistemcell.CloudStemcell, deploymentManifest bideplmanifest.Manifest, jobName string, index int) (VM, error)
 }
 
 type manager struct {
@@ -85,9 +86,8 @@ func (m *manager) FindCurrent() (VM, bool, error) {
 	return vm, true, err
 }
 
-func (m *manager) Create(stemcell bistemcell.CloudStemcell, deploymentManifest bideplmanifest.Manifest) (VM, error) {
-	jobName := deploymentManifest.JobName()
-	networkInterfaces, err := deploymentManifest.NetworkInterfaces(jobName)
+func (m *manager) Create(stemcell bistemcell.CloudStemcell, deploymentManifest bideplmanifest.Manifest, jobName string, index int) (VM, error) {
+	networkInterfaces, err := deploymentManifest.InstanceNetworkInterfaces(jobName, index)
 	m.logger.Debug(m.logTag, "Creating VM with network interfaces: %#v", networkInterfaces)
 	if err != nil {
 		return nil, bosherr.WrapError(err, "Getting network spec")
@@ -110,9 +110,9 @@ func (m *manager) Create(stemcell bistemcell.CloudStemcell, deploymentManifest b
 
 	metadata := bicloud.VMMetadata{
 		"deployment":     deploymentManifest.Name,
-		"job":            deploymentManifest.JobName(),
-		"instance_group": deploymentManifest.JobName(),
-		"index":          "0",
+		"job":            jobName,
+		"instance_group": jobName,
+		"index":          strconv.Itoa(index),
 		"director":       "bosh-init",
 		"created_at":     m.timeService.Now().Format(time.RFC3339),
 	}
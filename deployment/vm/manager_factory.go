@@ -12,6 +12,7 @@ import (
 
 type ManagerFactory interface {
 	NewManager(cloud bicloud.Cloud, agentClient biagentclient.AgentClient) Manager
+	NewInstanceManager(cloud bicloud.Cloud, agentClient biagentclient.AgentClient, vmRepo biconfig.VMRepo, diskDeployer DiskDeployer) Manager
 }
 
 type managerFactory struct {
@@ -54,3 +55,19 @@ func (f *managerFactory) NewManager(cloud bicloud.Cloud, agentClient biagentclie
 		clock.NewClock(),
 	)
 }
+
+// NewInstanceManager returns a manager which keeps track of VM and disks
+// of a single instance in given repos
+func (f *managerFactory) NewInstanceManager(cloud bicloud.Cloud, agentClient biagentclient.AgentClient, vmRepo biconfig.VMRepo, diskDeployer DiskDeployer) Manager {
+	return NewManager(
+		vmRepo,
+		f.stemcellRepo,
+		diskDeployer,
+		agentClient,
+		cloud,
+		f.uuidGenerator,
+		f.fs,
+		f.logger,
+		clock.NewClock(),
+	)
+}
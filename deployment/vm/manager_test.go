@@ -123,7 +123,7 @@ var _ = Describe("Manager", func() {
 
 	Describe("Create", func() {
 		It("creates a VM", func() {
-			vm, err := manager.Create(stemcell, deploymentManifest)
+			vm, err := manager.Create(stemcell, deploymentManifest, "fake-job", 0)
 			Expect(err).ToNot(HaveOccurred())
 			expectedVM := NewVMWithMetadata(
 				"fake-vm-cid",
@@ -158,7 +158,7 @@ var _ = Describe("Manager", func() {
 		})
 
 		It("sets the vm metadata", func() {
-			_, err := manager.Create(stemcell, deploymentManifest)
+			_, err := manager.Create(stemcell, deploymentManifest, "fake-job", 0)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(fakeCloud.SetVMMetadataCid).To(Equal("fake-vm-cid"))
@@ -172,6 +172,16 @@ var _ = Describe("Manager", func() {
 			}))
 		})
 
+		It("uses static ip and metadata of given instance", func() {
+			deploymentManifest.Jobs[0].Networks[0].StaticIPs = []string{"fake-ip", "fake-other-ip"}
+
+			_, err := manager.Create(stemcell, deploymentManifest, "fake-job", 1)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeCloud.CreateVMInput.NetworksInterfaces["fake-network-name"]["ip"]).To(Equal("fake-other-ip"))
+			Expect(fakeCloud.SetVMMetadataMetadata["index"]).To(Equal("1"))
+		})
+
 		Context("deployment-configured tags", func() {
 			It("sets additional tags on vms", func() {
 				deploymentManifest.Tags = map[string]string{
@@ -179,7 +189,7 @@ var _ = Describe("Manager", func() {
 					"key1":   "value1",
 				}
 
-				_, err := manager.Create(stemcell, deploymentManifest)
+				_, err := manager.Create(stemcell, deploymentManifest, "fake-job", 0)
 				Expect(err).ToNot(HaveOccurred())
 
 				Expect(fakeCloud.SetVMMetadataMetadata).To(Equal(cloud.VMMetadata{
@@ -204,7 +214,7 @@ var _ = Describe("Manager", func() {
 						"director":       "manifest-director",
 					}
 
-					_, err := manager.Create(stemcell, deploymentManifest)
+					_, err := manager.Create(stemcell, deploymentManifest, "fake-job", 0)
 					Expect(err).ToNot(HaveOccurred())
 
 					Expect(fakeCloud.SetVMMetadataMetadata).To(Equal(cloud.VMMetadata{
@@ -220,7 +230,7 @@ var _ = Describe("Manager", func() {
 		})
 
 		It("updates the current vm record", func() {
-			_, err := manager.Create(stemcell, deploymentManifest)
+			_, err := manager.Create(stemcell, deploymentManifest, "fake-job", 0)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(fakeVMRepo.UpdateCurrentCID).To(Equal("fake-vm-cid"))
@@ -232,13 +242,13 @@ var _ = Describe("Manager", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := manager.Create(stemcell, deploymentManifest)
+				_, err := manager.Create(stemcell, deploymentManifest, "fake-job", 0)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("fake-set-metadata-error"))
 			})
 
 			It("still updates the current vm record", func() {
-				_, err := manager.Create(stemcell, deploymentManifest)
+				_, err := manager.Create(stemcell, deploymentManifest, "fake-job", 0)
 				Expect(err).To(HaveOccurred())
 				Expect(fakeVMRepo.UpdateCurrentCID).To(Equal("fake-vm-cid"))
 			})
@@ -251,7 +261,7 @@ var _ = Describe("Manager", func() {
 				})
 				fakeCloud.SetVMMetadataError = notImplementedCloudError
 
-				_, err := manager.Create(stemcell, deploymentManifest)
+				_, err := manager.Create(stemcell, deploymentManifest, "fake-job", 0)
 				Expect(err).ToNot(HaveOccurred())
 			})
 		})
@@ -262,7 +272,7 @@ var _ = Describe("Manager", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := manager.Create(stemcell, deploymentManifest)
+				_, err := manager.Create(stemcell, deploymentManifest, "fake-job", 0)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("fake-create-error"))
 			})
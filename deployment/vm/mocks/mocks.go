@@ -6,6 +6,7 @@ package mocks
 import (
 	agentclient "github.com/cloudfoundry/bosh-agent/agentclient"
 	cloud "github.com/cloudfoundry/bosh-cli/cloud"
+	config "github.com/cloudfoundry/bosh-cli/config"
 	vm "github.com/cloudfoundry/bosh-cli/deployment/vm"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -40,3 +41,13 @@ func (_m *MockManagerFactory) NewManager(_param0 cloud.Cloud, _param1 agentclien
 func (_mr *_MockManagerFactoryRecorder) NewManager(arg0, arg1 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "NewManager", arg0, arg1)
 }
+
+func (_m *MockManagerFactory) NewInstanceManager(_param0 cloud.Cloud, _param1 agentclient.AgentClient, _param2 config.VMRepo, _param3 vm.DiskDeployer) vm.Manager {
+	ret := _m.ctrl.Call(_m, "NewInstanceManager", _param0, _param1, _param2, _param3)
+	ret0, _ := ret[0].(vm.Manager)
+	return ret0
+}
+
+func (_mr *_MockManagerFactoryRecorder) NewInstanceManager(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "NewInstanceManager", arg0, arg1, arg2, arg3)
+}
@@ -110,6 +110,18 @@ type FakeTask struct {
 	eventOutputReturnsOnCall map[int]struct {
 		result1 error
 	}
+	EventOutputFromOffsetStub        func(int, director.TaskReporter) error
+	eventOutputFromOffsetMutex       sync.RWMutex
+	eventOutputFromOffsetArgsForCall []struct {
+		arg1 int
+		arg2 director.TaskReporter
+	}
+	eventOutputFromOffsetReturns struct {
+		result1 error
+	}
+	eventOutputFromOffsetReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CPIOutputStub        func(director.TaskReporter) error
 	cPIOutputMutex       sync.RWMutex
 	cPIOutputArgsForCall []struct {
@@ -604,6 +616,55 @@ func (fake *FakeTask) EventOutputReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTask) EventOutputFromOffset(arg1 int, arg2 director.TaskReporter) error {
+	fake.eventOutputFromOffsetMutex.Lock()
+	ret, specificReturn := fake.eventOutputFromOffsetReturnsOnCall[len(fake.eventOutputFromOffsetArgsForCall)]
+	fake.eventOutputFromOffsetArgsForCall = append(fake.eventOutputFromOffsetArgsForCall, struct {
+		arg1 int
+		arg2 director.TaskReporter
+	}{arg1, arg2})
+	fake.recordInvocation("EventOutputFromOffset", []interface{}{arg1, arg2})
+	fake.eventOutputFromOffsetMutex.Unlock()
+	if fake.EventOutputFromOffsetStub != nil {
+		return fake.EventOutputFromOffsetStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.eventOutputFromOffsetReturns.result1
+}
+
+func (fake *FakeTask) EventOutputFromOffsetCallCount() int {
+	fake.eventOutputFromOffsetMutex.RLock()
+	defer fake.eventOutputFromOffsetMutex.RUnlock()
+	return len(fake.eventOutputFromOffsetArgsForCall)
+}
+
+func (fake *FakeTask) EventOutputFromOffsetArgsForCall(i int) (int, director.TaskReporter) {
+	fake.eventOutputFromOffsetMutex.RLock()
+	defer fake.eventOutputFromOffsetMutex.RUnlock()
+	return fake.eventOutputFromOffsetArgsForCall[i].arg1, fake.eventOutputFromOffsetArgsForCall[i].arg2
+}
+
+func (fake *FakeTask) EventOutputFromOffsetReturns(result1 error) {
+	fake.EventOutputFromOffsetStub = nil
+	fake.eventOutputFromOffsetReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTask) EventOutputFromOffsetReturnsOnCall(i int, result1 error) {
+	fake.EventOutputFromOffsetStub = nil
+	if fake.eventOutputFromOffsetReturnsOnCall == nil {
+		fake.eventOutputFromOffsetReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.eventOutputFromOffsetReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTask) CPIOutput(arg1 director.TaskReporter) error {
 	fake.cPIOutputMutex.Lock()
 	ret, specificReturn := fake.cPIOutputReturnsOnCall[len(fake.cPIOutputArgsForCall)]
@@ -813,6 +874,8 @@ func (fake *FakeTask) Invocations() map[string][][]interface{} {
 	defer fake.resultMutex.RUnlock()
 	fake.eventOutputMutex.RLock()
 	defer fake.eventOutputMutex.RUnlock()
+	fake.eventOutputFromOffsetMutex.RLock()
+	defer fake.eventOutputFromOffsetMutex.RUnlock()
 	fake.cPIOutputMutex.RLock()
 	defer fake.cPIOutputMutex.RUnlock()
 	fake.debugOutputMutex.RLock()
@@ -247,6 +247,7 @@ type Task interface {
 	Result() string
 
 	EventOutput(TaskReporter) error
+	EventOutputFromOffset(int, TaskReporter) error
 	CPIOutput(TaskReporter) error
 	DebugOutput(TaskReporter) error
 	ResultOutput(TaskReporter) error
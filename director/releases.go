@@ -117,6 +117,8 @@ type Package struct {
 	BlobstoreID string `json:"blobstore_id"`
 	SHA1        string `json:"sha1"`
 
+	Dependencies []string `json:"dependencies"`
+
 	CompiledPackages []CompiledPackage `json:"compiled_packages"`
 }
 
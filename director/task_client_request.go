@@ -86,10 +86,15 @@ func (r TaskClientRequest) DeleteResult(path string) ([]byte, error) {
 }
 
 func (r TaskClientRequest) WaitForCompletion(id int, type_ string, taskReporter TaskReporter) error {
+	return r.WaitForCompletionFromOffset(id, type_, 0, taskReporter)
+}
+
+// WaitForCompletionFromOffset skips task output before offset (in bytes)
+func (r TaskClientRequest) WaitForCompletionFromOffset(id int, type_ string, offset int, taskReporter TaskReporter) error {
 	taskReporter.TaskStarted(id)
 
 	var taskResp taskShortResp
-	var outputOffset int
+	var outputOffset = offset
 
 	defer func() {
 		taskReporter.TaskFinished(id, taskResp.State)
@@ -135,6 +135,10 @@ func (t TaskImpl) EventOutput(taskReporter TaskReporter) error {
 	return t.client.TaskOutput(t.id, "event", taskReporter)
 }
 
+func (t TaskImpl) EventOutputFromOffset(offset int, taskReporter TaskReporter) error {
+	return t.client.TaskOutputFromOffset(t.id, "event", offset, taskReporter)
+}
+
 func (t TaskImpl) CPIOutput(taskReporter TaskReporter) error {
 	return t.client.TaskOutput(t.id, "cpi", taskReporter)
 }
@@ -227,7 +231,11 @@ func (c Client) Task(id int) (TaskResp, error) {
 }
 
 func (c Client) TaskOutput(id int, type_ string, taskReporter TaskReporter) error {
-	err := c.taskClientRequest.WaitForCompletion(id, type_, taskReporter)
+	return c.TaskOutputFromOffset(id, type_, 0, taskReporter)
+}
+
+func (c Client) TaskOutputFromOffset(id int, type_ string, offset int, taskReporter TaskReporter) error {
+	err := c.taskClientRequest.WaitForCompletionFromOffset(id, type_, offset, taskReporter)
 	if err != nil {
 		return bosherr.WrapErrorf(err, "Capturing task '%d' output", id)
 	}
@@ -427,6 +427,59 @@ var _ = Describe("Task", func() {
 		}
 	})
 
+	Describe("EventOutputFromOffset", func() {
+		var (
+			reporter *fakedir.FakeTaskReporter
+		)
+
+		BeforeEach(func() {
+			reporter = &fakedir.FakeTaskReporter{}
+		})
+
+		It("reports task event output starting at offset", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/tasks/123"),
+					ghttp.VerifyBasicAuth("username", "password"),
+					ghttp.RespondWith(http.StatusOK, `{"id":123, "state":"done"}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/tasks/123/output", "type=event"),
+					ghttp.VerifyBasicAuth("username", "password"),
+					ghttp.VerifyHeader(http.Header{"Range": []string{"bytes=42-"}}),
+					ghttp.RespondWith(http.StatusOK, "chunk"),
+				),
+			)
+
+			Expect(task.EventOutputFromOffset(42, reporter)).ToNot(HaveOccurred())
+
+			taskID, chunk := reporter.TaskOutputChunkArgsForCall(0)
+			Expect(taskID).To(Equal(123))
+			Expect(chunk).To(Equal([]byte("chunk")))
+
+			taskID, state := reporter.TaskFinishedArgsForCall(0)
+			Expect(taskID).To(Equal(123))
+			Expect(state).To(Equal("done"))
+		})
+
+		It("returns error if task did not succeed", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/tasks/123"),
+					ghttp.RespondWith(http.StatusOK, `{"id":123, "state":"error"}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/tasks/123/output", "type=event"),
+					ghttp.RespondWith(http.StatusRequestedRangeNotSatisfiable, ""),
+				),
+			)
+
+			err := task.EventOutputFromOffset(42, reporter)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected task '123' to succeed but state is 'error'"))
+		})
+	})
+
 	Describe("Cancel", func() {
 		It("cancels task", func() {
 			server.AppendHandlers(
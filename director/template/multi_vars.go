@@ -1,18 +1,33 @@
 package template
 
 type MultiVars struct {
-	varss []Variables
+	varss  []Variables
+	tracer VarsTracer
+}
+
+// VarsTracer is notified which of the variables (by index) resolved a variable
+type VarsTracer interface {
+	VarFound(varDef VariableDefinition, idx int)
 }
 
 func NewMultiVars(varss []Variables) MultiVars {
-	return MultiVars{varss}
+	return MultiVars{varss: varss}
+}
+
+func (m MultiVars) WithTracer(tracer VarsTracer) MultiVars {
+	m.tracer = tracer
+	return m
 }
 
 var _ Variables = MultiVars{}
 
 func (m MultiVars) Get(varDef VariableDefinition) (interface{}, bool, error) {
-	for _, vars := range m.varss {
+	for i, vars := range m.varss {
 		val, found, err := vars.Get(varDef)
+		if found && err == nil && m.tracer != nil {
+			m.tracer.VarFound(varDef, i)
+		}
+
 		if found || err != nil {
 			return val, found, err
 		}
@@ -68,6 +68,22 @@ var _ = Describe("MultiVariables", func() {
 
 			Expect(vars1.GetVarDef).To(Equal(VariableDefinition{Name: "key2", Type: "type", Options: "opts"}))
 		})
+
+		It("notifies tracer which source resolved variable", func() {
+			tracer := &FakeVarsTracer{}
+			vars1 := StaticVariables{"key1": "val"}
+			vars2 := StaticVariables{"key2": "val"}
+			vars := NewMultiVars([]Variables{vars1, vars2}).WithTracer(tracer)
+
+			_, _, err := vars.Get(VariableDefinition{Name: "key2"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = vars.Get(VariableDefinition{Name: "key3"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(tracer.Found).To(Equal([]VariableDefinition{{Name: "key2"}}))
+			Expect(tracer.Idxs).To(Equal([]int{1}))
+		})
 	})
 
 	Describe("List", func() {
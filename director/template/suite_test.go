@@ -32,3 +32,13 @@ func (v *FakeVariables) Get(varDef VariableDefinition) (interface{}, bool, error
 func (v *FakeVariables) List() ([]VariableDefinition, error) {
 	return nil, nil
 }
+
+type FakeVarsTracer struct {
+	Found []VariableDefinition
+	Idxs  []int
+}
+
+func (t *FakeVarsTracer) VarFound(varDef VariableDefinition, idx int) {
+	t.Found = append(t.Found, varDef)
+	t.Idxs = append(t.Idxs, idx)
+}
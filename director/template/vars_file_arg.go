@@ -9,7 +9,8 @@ import (
 type VarsFileArg struct {
 	FS boshsys.FileSystem
 
-	Vars StaticVariables
+	FilePath string
+	Vars     StaticVariables
 }
 
 func (a *VarsFileArg) UnmarshalFlag(filePath string) error {
@@ -29,6 +30,7 @@ func (a *VarsFileArg) UnmarshalFlag(filePath string) error {
 		return bosherr.WrapErrorf(err, "Deserializing variables file '%s'", filePath)
 	}
 
+	(*a).FilePath = filePath
 	(*a).Vars = vars
 
 	return nil
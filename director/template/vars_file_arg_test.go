@@ -31,6 +31,7 @@ var _ = Describe("VarsFileArg", func() {
 				"name1": "var1",
 				"name2": "var2",
 			}))
+			Expect(arg.FilePath).To(Equal("/some/path"))
 		})
 
 		It("returns objects", func() {
@@ -11,6 +11,7 @@ import (
 type Installation interface {
 	Target() Target
 	Job() InstalledJob
+	Manifest() biinstallmanifest.Manifest
 	WithRunningRegistry(boshlog.Logger, biui.Stage, func() error) error
 	StartRegistry() error
 	StopRegistry() error
@@ -47,6 +48,10 @@ func (i *installation) Job() InstalledJob {
 	return i.job
 }
 
+func (i *installation) Manifest() biinstallmanifest.Manifest {
+	return i.manifest
+}
+
 func (i *installation) WithRunningRegistry(logger boshlog.Logger, stage biui.Stage, fn func() error) error {
 	err := stage.Perform("Starting registry", func() error {
 		return i.StartRegistry()
@@ -5,6 +5,7 @@ import (
 	biindex "github.com/cloudfoundry/bosh-cli/index"
 	"github.com/cloudfoundry/bosh-cli/installation/blobextract"
 	biinstallpkg "github.com/cloudfoundry/bosh-cli/installation/pkg"
+	bipkgcache "github.com/cloudfoundry/bosh-cli/installation/pkgcache"
 	biregistry "github.com/cloudfoundry/bosh-cli/registry"
 	bistatejob "github.com/cloudfoundry/bosh-cli/state/job"
 	bistatepkg "github.com/cloudfoundry/bosh-cli/state/pkg"
@@ -30,6 +31,7 @@ type installerFactory struct {
 	releaseJobResolver     bideplrel.JobResolver
 	uuidGenerator          boshuuid.Generator
 	registryServerManager  biregistry.ServerManager
+	compiledPackageCache   bipkgcache.Cache
 	logger                 boshlog.Logger
 	logTag                 string
 	fs                     boshsys.FileSystem
@@ -43,33 +45,36 @@ func NewInstallerFactory(
 	releaseJobResolver bideplrel.JobResolver,
 	uuidGenerator boshuuid.Generator,
 	registryServerManager biregistry.ServerManager,
+	compiledPackageCache bipkgcache.Cache,
 	logger boshlog.Logger,
 	fs boshsys.FileSystem,
 	digestCreateAlgorithms []boshcrypto.Algorithm,
 ) InstallerFactory {
 	return &installerFactory{
-		ui:                    ui,
-		runner:                runner,
-		extractor:             extractor,
-		releaseJobResolver:    releaseJobResolver,
-		uuidGenerator:         uuidGenerator,
-		registryServerManager: registryServerManager,
-		logger:                logger,
-		logTag:                "installer",
-		fs:                    fs,
+		ui:                     ui,
+		runner:                 runner,
+		extractor:              extractor,
+		releaseJobResolver:     releaseJobResolver,
+		uuidGenerator:          uuidGenerator,
+		registryServerManager:  registryServerManager,
+		compiledPackageCache:   compiledPackageCache,
+		logger:                 logger,
+		logTag:                 "installer",
+		fs:                     fs,
 		digestCreateAlgorithms: digestCreateAlgorithms,
 	}
 }
 
 func (f *installerFactory) NewInstaller(target Target) Installer {
 	context := &installerFactoryContext{
-		target:             target,
-		runner:             f.runner,
-		logger:             f.logger,
-		extractor:          f.extractor,
-		uuidGenerator:      f.uuidGenerator,
-		releaseJobResolver: f.releaseJobResolver,
-		fs:                 f.fs,
+		target:                 target,
+		runner:                 f.runner,
+		logger:                 f.logger,
+		extractor:              f.extractor,
+		uuidGenerator:          f.uuidGenerator,
+		releaseJobResolver:     f.releaseJobResolver,
+		compiledPackageCache:   f.compiledPackageCache,
+		fs:                     f.fs,
 		digestCreateAlgorithms: f.digestCreateAlgorithms,
 	}
 
@@ -93,6 +98,8 @@ type installerFactoryContext struct {
 	uuidGenerator      boshuuid.Generator
 	releaseJobResolver bideplrel.JobResolver
 
+	compiledPackageCache bipkgcache.Cache
+
 	jobDependencyCompiler  bistatejob.DependencyCompiler
 	packageCompiler        bistatepkg.Compiler
 	blobstore              boshblob.DigestBlobstore
@@ -150,6 +157,7 @@ func (c *installerFactoryContext) InstallationStatePackageCompiler() bistatepkg.
 		c.extractor,
 		c.Blobstore(),
 		c.CompiledPackageRepo(),
+		c.compiledPackageCache,
 		c.BlobExtractor(),
 		c.logger,
 	)
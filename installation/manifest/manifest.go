@@ -1,6 +1,8 @@
 package manifest
 
 import (
+	"time"
+
 	biproperty "github.com/cloudfoundry/bosh-utils/property"
 )
 
@@ -11,6 +13,8 @@ type Manifest struct {
 	Mbus       string
 	Cert       Certificate
 	Registry   Registry
+
+	CPIPolicies CPIPolicies
 }
 
 type Certificate struct {
@@ -34,6 +38,68 @@ func (r Registry) IsEmpty() bool {
 	return r == Registry{}
 }
 
+// CPIPolicy configures retries and timeout of calls to a CPI method.
+// Fields that are not set are taken from default policy.
+type CPIPolicy struct {
+	MaxAttempts     *int           `yaml:"max_attempts"`
+	Backoff         *time.Duration `yaml:"backoff"`
+	RetryableErrors []string       `yaml:"retryable_errors"`
+	Timeout         *time.Duration `yaml:"timeout"`
+}
+
+// CPIPolicies are keyed by CPI method name; DefaultCPIPolicy key applies to
+// methods and fields without own configuration
+type CPIPolicies map[string]CPIPolicy
+
+const DefaultCPIPolicy = "default"
+
+// CPICallPolicy is CPIPolicy resolved for a single CPI method.
+// Zero values mean a single attempt without timeout.
+type CPICallPolicy struct {
+	MaxAttempts     int
+	Backoff         time.Duration
+	RetryableErrors []string
+	Timeout         time.Duration
+}
+
+func (p CPIPolicies) For(method string) CPICallPolicy {
+	var callPolicy CPICallPolicy
+
+	for _, policy := range []CPIPolicy{p[DefaultCPIPolicy], p[method]} {
+		if policy.MaxAttempts != nil {
+			callPolicy.MaxAttempts = *policy.MaxAttempts
+		}
+		if policy.Backoff != nil {
+			callPolicy.Backoff = *policy.Backoff
+		}
+		if policy.RetryableErrors != nil {
+			callPolicy.RetryableErrors = policy.RetryableErrors
+		}
+		if policy.Timeout != nil {
+			callPolicy.Timeout = *policy.Timeout
+		}
+	}
+
+	return callPolicy
+}
+
+// IsRetryable reports whether CPI error of given type should be retried.
+// Without configured retryable error types only errors that CPI marks as
+// ok to retry are retried.
+func (p CPICallPolicy) IsRetryable(errorType string, okToRetry bool) bool {
+	if len(p.RetryableErrors) == 0 {
+		return okToRetry
+	}
+
+	for _, retryableError := range p.RetryableErrors {
+		if retryableError == errorType {
+			return true
+		}
+	}
+
+	return false
+}
+
 type SSHTunnel struct {
 	User       string
 	Host       string
@@ -0,0 +1,86 @@
+package manifest_test
+
+import (
+	"time"
+
+	. "github.com/cloudfoundry/bosh-cli/installation/manifest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CPIPolicies", func() {
+	intPtr := func(i int) *int { return &i }
+	durationPtr := func(d time.Duration) *time.Duration { return &d }
+
+	Describe("For", func() {
+		It("returns zero policy when nothing is configured", func() {
+			Expect(CPIPolicies{}.For("create_vm")).To(Equal(CPICallPolicy{}))
+		})
+
+		It("fills fields missing in method policy from default policy", func() {
+			policies := CPIPolicies{
+				"default": CPIPolicy{
+					MaxAttempts: intPtr(2),
+					Backoff:     durationPtr(time.Second),
+					Timeout:     durationPtr(time.Minute),
+				},
+				"create_vm": CPIPolicy{
+					MaxAttempts:     intPtr(5),
+					RetryableErrors: []string{"fake-error-type"},
+				},
+			}
+
+			Expect(policies.For("create_vm")).To(Equal(CPICallPolicy{
+				MaxAttempts:     5,
+				Backoff:         time.Second,
+				RetryableErrors: []string{"fake-error-type"},
+				Timeout:         time.Minute,
+			}))
+
+			Expect(policies.For("attach_disk")).To(Equal(CPICallPolicy{
+				MaxAttempts: 2,
+				Backoff:     time.Second,
+				Timeout:     time.Minute,
+			}))
+		})
+
+		It("allows method policy to reset default fields to zero values", func() {
+			policies := CPIPolicies{
+				"default": CPIPolicy{
+					MaxAttempts:     intPtr(2),
+					Backoff:         durationPtr(time.Second),
+					RetryableErrors: []string{"fake-error-type"},
+					Timeout:         durationPtr(time.Minute),
+				},
+				"create_vm": CPIPolicy{
+					Backoff:         durationPtr(0),
+					RetryableErrors: []string{},
+					Timeout:         durationPtr(0),
+				},
+			}
+
+			Expect(policies.For("create_vm")).To(Equal(CPICallPolicy{
+				MaxAttempts:     2,
+				RetryableErrors: []string{},
+			}))
+		})
+	})
+})
+
+var _ = Describe("CPICallPolicy", func() {
+	Describe("IsRetryable", func() {
+		It("retries errors marked ok to retry when retryable errors are not configured", func() {
+			policy := CPICallPolicy{}
+
+			Expect(policy.IsRetryable("fake-error-type", true)).To(BeTrue())
+			Expect(policy.IsRetryable("fake-error-type", false)).To(BeFalse())
+		})
+
+		It("retries only configured error types", func() {
+			policy := CPICallPolicy{RetryableErrors: []string{"fake-error-type"}}
+
+			Expect(policy.IsRetryable("fake-error-type", false)).To(BeTrue())
+			Expect(policy.IsRetryable("other-error-type", true)).To(BeFalse())
+		})
+	})
+})
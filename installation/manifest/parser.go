@@ -40,6 +40,8 @@ type installation struct {
 	SSHTunnel  SSHTunnel `yaml:"ssh_tunnel"`
 	Mbus       string
 	Cert       Certificate
+
+	CPIPolicies CPIPolicies `yaml:"cpi_policies"`
 }
 
 func (i installation) HasSSHTunnel() bool {
@@ -115,8 +117,9 @@ func (p *parser) Parse(path string, vars boshtpl.Variables, op patch.Op, release
 			Name:    comboManifest.CloudProvider.Template.Name,
 			Release: comboManifest.CloudProvider.Template.Release,
 		},
-		Mbus: comboManifest.CloudProvider.Mbus,
-		Cert: comboManifest.CloudProvider.Cert,
+		Mbus:        comboManifest.CloudProvider.Mbus,
+		Cert:        comboManifest.CloudProvider.Cert,
+		CPIPolicies: comboManifest.CloudProvider.CPIPolicies,
 	}
 
 	properties, err := biproperty.BuildMap(comboManifest.CloudProvider.Properties)
@@ -2,6 +2,7 @@ package manifest_test
 
 import (
 	"errors"
+	"time"
 
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 	biproperty "github.com/cloudfoundry/bosh-utils/property"
@@ -117,6 +118,49 @@ cloud_provider:
 			})
 		})
 
+		Context("when cpi policies are present", func() {
+			BeforeEach(func() {
+				fakeFs.WriteFileString(comboManifestPath, `
+---
+name: fake-deployment-name
+cloud_provider:
+  template:
+    name: fake-cpi-job-name
+    release: fake-cpi-release-name
+  cpi_policies:
+    default:
+      timeout: 10m
+    create_vm:
+      max_attempts: 3
+      backoff: 30s
+      retryable_errors:
+      - Bosh::Clouds::VMCreationFailed
+    attach_disk:
+      timeout: 0s
+`)
+			})
+
+			It("parses cpi policies", func() {
+				installationManifest, err := parser.Parse(comboManifestPath, boshtpl.StaticVariables{}, patch.Ops{}, releaseSetManifest)
+				Expect(err).ToNot(HaveOccurred())
+
+				maxAttempts := 3
+				backoff := 30 * time.Second
+				timeout := 10 * time.Minute
+				noTimeout := time.Duration(0)
+
+				Expect(installationManifest.CPIPolicies).To(Equal(manifest.CPIPolicies{
+					"default": manifest.CPIPolicy{Timeout: &timeout},
+					"create_vm": manifest.CPIPolicy{
+						MaxAttempts:     &maxAttempts,
+						Backoff:         &backoff,
+						RetryableErrors: []string{"Bosh::Clouds::VMCreationFailed"},
+					},
+					"attach_disk": manifest.CPIPolicy{Timeout: &noTimeout},
+				}))
+			})
+		})
+
 		Context("when ssh tunnel config is present", func() {
 			Context("with raw private key", func() {
 				Context("that is valid", func() {
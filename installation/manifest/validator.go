@@ -40,6 +40,20 @@ func (v *validator) Validate(manifest Manifest, releaseSetManifest birelsetmanif
 		errs = append(errs, bosherr.Errorf("cloud_provider.template.release '%s' must refer to a release in releases", cpiReleaseName))
 	}
 
+	for method, policy := range manifest.CPIPolicies {
+		if policy.MaxAttempts != nil && *policy.MaxAttempts < 0 {
+			errs = append(errs, bosherr.Errorf("cloud_provider.cpi_policies.%s.max_attempts must not be negative", method))
+		}
+
+		if policy.Backoff != nil && *policy.Backoff < 0 {
+			errs = append(errs, bosherr.Errorf("cloud_provider.cpi_policies.%s.backoff must not be negative", method))
+		}
+
+		if policy.Timeout != nil && *policy.Timeout < 0 {
+			errs = append(errs, bosherr.Errorf("cloud_provider.cpi_policies.%s.timeout must not be negative", method))
+		}
+	}
+
 	if len(errs) > 0 {
 		return bosherr.NewMultiError(errs...)
 	}
@@ -1,6 +1,8 @@
 package manifest_test
 
 import (
+	"time"
+
 	. "github.com/cloudfoundry/bosh-cli/installation/manifest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -101,5 +103,21 @@ var _ = Describe("Validator", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("cloud_provider.template.release 'not-provided-valid-release-name' must refer to a release in releases"))
 		})
+
+		It("validates cpi policies are not negative", func() {
+			maxAttempts := -1
+			duration := time.Duration(-1)
+
+			manifest := validManifest
+			manifest.CPIPolicies = CPIPolicies{
+				"create_vm": CPIPolicy{MaxAttempts: &maxAttempts, Backoff: &duration, Timeout: &duration},
+			}
+
+			err := validator.Validate(manifest, releaseSetManifest)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cloud_provider.cpi_policies.create_vm.max_attempts must not be negative"))
+			Expect(err.Error()).To(ContainSubstring("cloud_provider.cpi_policies.create_vm.backoff must not be negative"))
+			Expect(err.Error()).To(ContainSubstring("cloud_provider.cpi_policies.create_vm.timeout must not be negative"))
+		})
 	})
 })
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 
 	"github.com/cloudfoundry/bosh-cli/installation/blobextract"
+	bipkgcache "github.com/cloudfoundry/bosh-cli/installation/pkgcache"
 	birelpkg "github.com/cloudfoundry/bosh-cli/release/pkg"
 	bistatepkg "github.com/cloudfoundry/bosh-cli/state/pkg"
 	boshblob "github.com/cloudfoundry/bosh-utils/blobstore"
@@ -15,15 +16,16 @@ import (
 )
 
 type compiler struct {
-	runner              boshsys.CmdRunner
-	packagesDir         string
-	fileSystem          boshsys.FileSystem
-	compressor          boshcmd.Compressor
-	blobstore           boshblob.DigestBlobstore
-	compiledPackageRepo bistatepkg.CompiledPackageRepo
-	blobExtractor       blobextract.Extractor
-	logger              boshlog.Logger
-	logTag              string
+	runner               boshsys.CmdRunner
+	packagesDir          string
+	fileSystem           boshsys.FileSystem
+	compressor           boshcmd.Compressor
+	blobstore            boshblob.DigestBlobstore
+	compiledPackageRepo  bistatepkg.CompiledPackageRepo
+	compiledPackageCache bipkgcache.Cache
+	blobExtractor        blobextract.Extractor
+	logger               boshlog.Logger
+	logTag               string
 }
 
 func NewPackageCompiler(
@@ -33,19 +35,21 @@ func NewPackageCompiler(
 	compressor boshcmd.Compressor,
 	blobstore boshblob.DigestBlobstore,
 	compiledPackageRepo bistatepkg.CompiledPackageRepo,
+	compiledPackageCache bipkgcache.Cache,
 	blobExtractor blobextract.Extractor,
 	logger boshlog.Logger,
 ) bistatepkg.Compiler {
 	return &compiler{
-		runner:              runner,
-		packagesDir:         packagesDir,
-		fileSystem:          fileSystem,
-		compressor:          compressor,
-		blobstore:           blobstore,
-		compiledPackageRepo: compiledPackageRepo,
-		blobExtractor:       blobExtractor,
-		logger:              logger,
-		logTag:              "packageCompiler",
+		runner:               runner,
+		packagesDir:          packagesDir,
+		fileSystem:           fileSystem,
+		compressor:           compressor,
+		blobstore:            blobstore,
+		compiledPackageRepo:  compiledPackageRepo,
+		compiledPackageCache: compiledPackageCache,
+		blobExtractor:        blobExtractor,
+		logger:               logger,
+		logTag:               "packageCompiler",
 	}
 }
 
@@ -64,6 +68,17 @@ func (c *compiler) Compile(pkg birelpkg.Compilable) (bistatepkg.CompiledPackageR
 		return record, isCompiledPackage, nil
 	}
 
+	cachedTarball, found, err := c.compiledPackageCache.Get(pkg)
+	if err != nil {
+		c.logger.Warn(c.logTag, "Failed to find compiled package '%s' in cache: %s", pkg.Name(), err.Error())
+	} else if found {
+		c.logger.Debug(c.logTag, "Using cached compiled package '%s/%s'", pkg.Name(), pkg.Fingerprint())
+
+		record, err = c.saveCompiledPackage(pkg, cachedTarball)
+
+		return record, isCompiledPackage, err
+	}
+
 	c.logger.Debug(c.logTag, "Installing dependencies of package '%s/%s'", pkg.Name(), pkg.Fingerprint())
 
 	err = c.installPackages(pkg.Deps())
@@ -122,22 +137,36 @@ func (c *compiler) Compile(pkg birelpkg.Compilable) (bistatepkg.CompiledPackageR
 		}
 	}()
 
+	record, err = c.saveCompiledPackage(pkg, tarball)
+	if err != nil {
+		return record, isCompiledPackage, err
+	}
+
+	err = c.compiledPackageCache.Save(pkg, tarball, record.BlobSHA1)
+	if err != nil {
+		c.logger.Warn(c.logTag, "Failed to save compiled package '%s' in cache: %s", pkg.Name(), err.Error())
+	}
+
+	return record, isCompiledPackage, nil
+}
+
+func (c *compiler) saveCompiledPackage(pkg birelpkg.Compilable, tarball string) (bistatepkg.CompiledPackageRecord, error) {
 	blobID, digest, err := c.blobstore.Create(tarball)
 	if err != nil {
-		return record, isCompiledPackage, bosherr.WrapError(err, "Creating blob")
+		return bistatepkg.CompiledPackageRecord{}, bosherr.WrapError(err, "Creating blob")
 	}
 
-	record = bistatepkg.CompiledPackageRecord{
+	record := bistatepkg.CompiledPackageRecord{
 		BlobID:   blobID,
 		BlobSHA1: digest.String(),
 	}
 
 	err = c.compiledPackageRepo.Save(pkg, record)
 	if err != nil {
-		return record, isCompiledPackage, bosherr.WrapError(err, "Saving compiled package")
+		return record, bosherr.WrapError(err, "Saving compiled package")
 	}
 
-	return record, isCompiledPackage, nil
+	return record, nil
 }
 
 func (c *compiler) installPackages(packages []birelpkg.Compilable) error {
@@ -16,6 +16,7 @@ import (
 
 	"github.com/cloudfoundry/bosh-cli/installation/blobextract/fakeblobextract"
 	. "github.com/cloudfoundry/bosh-cli/installation/pkg"
+	fakepkgcache "github.com/cloudfoundry/bosh-cli/installation/pkgcache/fakepkgcache"
 	birelpkg "github.com/cloudfoundry/bosh-cli/release/pkg"
 	. "github.com/cloudfoundry/bosh-cli/release/resource"
 	bistatepkg "github.com/cloudfoundry/bosh-cli/state/pkg"
@@ -43,6 +44,7 @@ var _ = Describe("PackageCompiler", func() {
 		packagesDir             string
 		blobstore               *fakeblobstore.FakeDigestBlobstore
 		mockCompiledPackageRepo *mock_state_package.MockCompiledPackageRepo
+		compiledPackageCache    *fakepkgcache.FakeCache
 
 		fakeExtractor *fakeblobextract.FakeExtractor
 
@@ -65,6 +67,7 @@ var _ = Describe("PackageCompiler", func() {
 		blobstore.CreateReturns("fake-blob-id", digest, nil)
 
 		mockCompiledPackageRepo = mock_state_package.NewMockCompiledPackageRepo(mockCtrl)
+		compiledPackageCache = &fakepkgcache.FakeCache{}
 
 		dependency1 = birelpkg.NewPackage(NewResource("pkg-dep1-name", "", nil), nil)
 		dependency2 = birelpkg.NewPackage(NewResource("pkg-dep2-name", "", nil), nil)
@@ -78,6 +81,7 @@ var _ = Describe("PackageCompiler", func() {
 			compressor,
 			blobstore,
 			mockCompiledPackageRepo,
+			compiledPackageCache,
 			fakeExtractor,
 			logger,
 		)
@@ -143,6 +147,63 @@ var _ = Describe("PackageCompiler", func() {
 			})
 		})
 
+		Context("when the compiled package cache has the package", func() {
+			BeforeEach(func() {
+				compiledPackageCache.GetReturns("/cache/compiled_package.tgz", true, nil)
+			})
+
+			It("skips the compilation and stores cached package into the blobstore", func() {
+				expectSave.Times(1)
+
+				record, _, err := compiler.Compile(pkg)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(record).To(Equal(bistatepkg.CompiledPackageRecord{
+					BlobID:   "fake-blob-id",
+					BlobSHA1: "fakefingerprint",
+				}))
+
+				Expect(compiledPackageCache.GetArgsForCall(0)).To(Equal(pkg))
+				Expect(blobstore.CreateArgsForCall(0)).To(Equal("/cache/compiled_package.tgz"))
+
+				Expect(len(runner.RunComplexCommands)).To(Equal(0))
+				Expect(fakeExtractor.ExtractCallCount()).To(Equal(0))
+				Expect(compiledPackageCache.SaveCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when finding package in the compiled package cache fails", func() {
+			BeforeEach(func() {
+				compiledPackageCache.GetReturns("", false, errors.New("fake-err"))
+			})
+
+			It("compiles the package", func() {
+				_, _, err := compiler.Compile(pkg)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(runner.RunComplexCommands).To(HaveLen(1))
+			})
+		})
+
+		It("saves compiled package into the compiled package cache", func() {
+			_, _, err := compiler.Compile(pkg)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(compiledPackageCache.SaveCallCount()).To(Equal(1))
+
+			cachedPkg, tarballPath, digest := compiledPackageCache.SaveArgsForCall(0)
+			Expect(cachedPkg).To(Equal(pkg))
+			Expect(tarballPath).To(Equal(compiledPackageTarballPath))
+			Expect(digest).To(Equal("fakefingerprint"))
+		})
+
+		It("does not fail if saving into the compiled package cache fails", func() {
+			compiledPackageCache.SaveReturns(errors.New("fake-err"))
+
+			_, _, err := compiler.Compile(pkg)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
 		It("installs all the dependencies for the package", func() {
 			_, _, err := compiler.Compile(pkg)
 			Expect(err).ToNot(HaveOccurred())
@@ -0,0 +1,290 @@
+package pkgcache
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	birelpkg "github.com/cloudfoundry/bosh-cli/release/pkg"
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
+	bistatepkg "github.com/cloudfoundry/bosh-cli/state/pkg"
+)
+
+//go:generate counterfeiter -o fakepkgcache/fake_cache.go cache.go Cache
+
+// Cache keeps compiled package tarballs so that they can be shared between installations
+type Cache interface {
+	Get(pkg birelpkg.Compilable) (string, bool, error)
+	Save(pkg birelpkg.Compilable, tarballPath, digest string) error
+	List() ([]Entry, error)
+	Prune(unusedSince time.Time) ([]Entry, error)
+}
+
+type Entry struct {
+	Key string `json:"key"`
+
+	Name         string   `json:"name"`
+	Fingerprint  string   `json:"fingerprint"`
+	Dependencies []string `json:"dependencies"`
+
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+
+	SHA1 string `json:"sha1"`
+	Size int64  `json:"size"`
+
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+type EntriesByName []Entry
+
+func (s EntriesByName) Len() int      { return len(s) }
+func (s EntriesByName) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s EntriesByName) Less(i, j int) bool {
+	if s[i].Name == s[j].Name {
+		return s[i].Fingerprint < s[j].Fingerprint
+	}
+	return s[i].Name < s[j].Name
+}
+
+type fsCache struct {
+	basePath string
+	goos     string
+	goarch   string
+
+	fs          boshsys.FileSystem
+	timeService clock.Clock
+
+	logger boshlog.Logger
+	logTag string
+}
+
+func NewFSCache(
+	basePath string,
+	goos string,
+	goarch string,
+	fs boshsys.FileSystem,
+	timeService clock.Clock,
+	logger boshlog.Logger,
+) Cache {
+	return &fsCache{
+		basePath: basePath,
+		goos:     goos,
+		goarch:   goarch,
+
+		fs:          fs,
+		timeService: timeService,
+
+		logger: logger,
+		logTag: "compiledPackageCache",
+	}
+}
+
+func (c *fsCache) Get(pkg birelpkg.Compilable) (string, bool, error) {
+	key := c.key(pkg)
+
+	entry, found, err := c.readEntry(c.entryPath(key))
+	if err != nil || !found {
+		return "", false, err
+	}
+
+	tarballPath := c.tarballPath(key)
+
+	if !c.fs.FileExists(tarballPath) {
+		c.logger.Warn(c.logTag, "Ignoring cached package '%s/%s' without tarball", pkg.Name(), pkg.Fingerprint())
+		return "", false, nil
+	}
+
+	err = boshres.VerifyArchiveDigest(tarballPath, entry.SHA1, c.fs)
+	if err != nil {
+		c.logger.Warn(c.logTag, "Ignoring corrupted cached package '%s/%s': %s", pkg.Name(), pkg.Fingerprint(), err.Error())
+		return "", false, nil
+	}
+
+	entry.LastUsedAt = c.timeService.Now().UTC()
+
+	err = c.writeEntry(entry)
+	if err != nil {
+		c.logger.Warn(c.logTag, "Failed to update last use of cached package '%s/%s': %s", pkg.Name(), pkg.Fingerprint(), err.Error())
+	}
+
+	c.logger.Debug(c.logTag, "Found cached package '%s/%s' at '%s'", pkg.Name(), pkg.Fingerprint(), tarballPath)
+
+	return tarballPath, true, nil
+}
+
+func (c *fsCache) Save(pkg birelpkg.Compilable, tarballPath, digest string) error {
+	key := c.key(pkg)
+
+	err := c.fs.MkdirAll(filepath.Join(c.basePath, key), os.ModePerm)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Creating cache directory for package '%s'", pkg.Name())
+	}
+
+	err = c.fs.CopyFile(tarballPath, c.tarballPath(key))
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Copying compiled package '%s' into cache", pkg.Name())
+	}
+
+	stat, err := c.fs.Stat(tarballPath)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Checking compiled package '%s' size", pkg.Name())
+	}
+
+	now := c.timeService.Now().UTC()
+
+	entry := Entry{
+		Key: key,
+
+		Name:         pkg.Name(),
+		Fingerprint:  pkg.Fingerprint(),
+		Dependencies: c.dependencies(pkg),
+
+		OS:   c.goos,
+		Arch: c.goarch,
+
+		SHA1: digest,
+		Size: stat.Size(),
+
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+
+	// Entry is written last so that partially saved packages are never found
+	err = c.writeEntry(entry)
+	if err != nil {
+		return err
+	}
+
+	c.logger.Debug(c.logTag, "Saved package '%s/%s' in cache at '%s'", pkg.Name(), pkg.Fingerprint(), c.tarballPath(key))
+
+	return nil
+}
+
+func (c *fsCache) List() ([]Entry, error) {
+	paths, err := c.fs.Glob(c.entryPath("*"))
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Listing cached packages in '%s'", c.basePath)
+	}
+
+	var entries []Entry
+
+	for _, path := range paths {
+		entry, found, err := c.readEntry(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Sort(EntriesByName(entries))
+
+	return entries, nil
+}
+
+func (c *fsCache) Prune(unusedSince time.Time) ([]Entry, error) {
+	entries, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var prunedEntries []Entry
+
+	for _, entry := range entries {
+		if entry.LastUsedAt.After(unusedSince) {
+			continue
+		}
+
+		err := c.fs.RemoveAll(filepath.Join(c.basePath, entry.Key))
+		if err != nil {
+			return prunedEntries, bosherr.WrapErrorf(err, "Removing cached package '%s/%s'", entry.Name, entry.Fingerprint)
+		}
+
+		prunedEntries = append(prunedEntries, entry)
+	}
+
+	return prunedEntries, nil
+}
+
+// key captures package, its dependencies and platform it was compiled on
+// since compiled packages cannot be reused on a different OS or architecture
+func (c *fsCache) key(pkg birelpkg.Compilable) string {
+	pieces := []string{
+		pkg.Name(),
+		pkg.Fingerprint(),
+		strings.Join(c.dependencies(pkg), ","),
+		c.goos + "/" + c.goarch,
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(pieces, "\n"))))
+}
+
+func (c *fsCache) dependencies(pkg birelpkg.Compilable) []string {
+	deps := []string{}
+
+	for _, dep := range bistatepkg.ResolveDependencies(pkg) {
+		deps = append(deps, fmt.Sprintf("%s:%s", dep.Name(), dep.Fingerprint()))
+	}
+
+	sort.Strings(deps)
+
+	return deps
+}
+
+func (c *fsCache) tarballPath(key string) string {
+	return filepath.Join(c.basePath, key, "compiled_package.tgz")
+}
+
+func (c *fsCache) entryPath(key string) string {
+	return filepath.Join(c.basePath, key, "entry.json")
+}
+
+func (c *fsCache) readEntry(path string) (Entry, bool, error) {
+	var entry Entry
+
+	if !c.fs.FileExists(path) {
+		return entry, false, nil
+	}
+
+	bytes, err := c.fs.ReadFile(path)
+	if err != nil {
+		return entry, false, bosherr.WrapErrorf(err, "Reading cache entry '%s'", path)
+	}
+
+	err = json.Unmarshal(bytes, &entry)
+	if err != nil {
+		return entry, false, bosherr.WrapErrorf(err, "Unmarshalling cache entry '%s'", path)
+	}
+
+	return entry, true, nil
+}
+
+func (c *fsCache) writeEntry(entry Entry) error {
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		return bosherr.WrapError(err, "Marshalling cache entry")
+	}
+
+	path := c.entryPath(entry.Key)
+
+	err = c.fs.WriteFile(path, bytes)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing cache entry '%s'", path)
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package pkgcache_test
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/installation/pkgcache"
+	birelpkg "github.com/cloudfoundry/bosh-cli/release/pkg"
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
+)
+
+var _ = Describe("FSCache", func() {
+	var (
+		fs          boshsys.FileSystem
+		logger      boshlog.Logger
+		timeService *fakeclock.FakeClock
+		basePath    string
+		tarballPath string
+		digest      string
+		cache       Cache
+		pkg         *birelpkg.Package
+	)
+
+	newPkg := func(name, fp string, deps ...*birelpkg.Package) *birelpkg.Package {
+		var depNames []string
+		for _, dep := range deps {
+			depNames = append(depNames, dep.Name())
+		}
+
+		pkg := birelpkg.NewPackage(boshres.NewResource(name, fp, nil), depNames)
+		pkg.AttachDependencies(deps)
+
+		return pkg
+	}
+
+	BeforeEach(func() {
+		logger = boshlog.NewLogger(boshlog.LevelNone)
+		fs = boshsys.NewOsFileSystem(logger)
+
+		tmpDir, err := fs.TempDir("pkgcache-test")
+		Expect(err).ToNot(HaveOccurred())
+
+		basePath = filepath.Join(tmpDir, "cache")
+		tarballPath = filepath.Join(tmpDir, "compiled.tgz")
+
+		Expect(fs.WriteFileString(tarballPath, "compiled")).To(Succeed())
+		digest = fmt.Sprintf("%x", sha1.Sum([]byte("compiled")))
+
+		timeService = fakeclock.NewFakeClock(time.Date(2017, time.January, 2, 3, 4, 5, 0, time.UTC))
+
+		cache = NewFSCache(basePath, "linux", "amd64", fs, timeService, logger)
+
+		pkg = newPkg("app", "app-fp", newPkg("lib", "lib-fp"))
+	})
+
+	AfterEach(func() {
+		Expect(fs.RemoveAll(filepath.Dir(basePath))).To(Succeed())
+	})
+
+	Describe("Get", func() {
+		It("returns copy of saved tarball", func() {
+			Expect(cache.Save(pkg, tarballPath, digest)).To(Succeed())
+			Expect(fs.RemoveAll(tarballPath)).To(Succeed())
+
+			path, found, err := cache.Get(pkg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(path).To(HavePrefix(basePath))
+			Expect(fs.ReadFileString(path)).To(Equal("compiled"))
+		})
+
+		It("is a cache miss if nothing was saved", func() {
+			_, found, err := cache.Get(pkg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("is a cache miss for a package with different fingerprint", func() {
+			Expect(cache.Save(pkg, tarballPath, digest)).To(Succeed())
+
+			_, found, err := cache.Get(newPkg("app", "other-fp", newPkg("lib", "lib-fp")))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("is a cache miss for a package with different dependency fingerprint", func() {
+			Expect(cache.Save(pkg, tarballPath, digest)).To(Succeed())
+
+			_, found, err := cache.Get(newPkg("app", "app-fp", newPkg("lib", "other-fp")))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("is a cache miss for a package compiled on a different platform", func() {
+			Expect(cache.Save(pkg, tarballPath, digest)).To(Succeed())
+
+			otherCache := NewFSCache(basePath, "darwin", "amd64", fs, timeService, logger)
+
+			_, found, err := otherCache.Get(pkg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("is a cache miss if cached tarball does not match its digest", func() {
+			Expect(cache.Save(pkg, tarballPath, digest)).To(Succeed())
+
+			entries, err := cache.List()
+			Expect(err).ToNot(HaveOccurred())
+
+			cachedPath := filepath.Join(basePath, entries[0].Key, "compiled_package.tgz")
+			Expect(fs.WriteFileString(cachedPath, "tampered")).To(Succeed())
+
+			_, found, err := cache.Get(pkg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("records last use", func() {
+			Expect(cache.Save(pkg, tarballPath, digest)).To(Succeed())
+
+			timeService.Increment(time.Hour)
+
+			_, _, err := cache.Get(pkg)
+			Expect(err).ToNot(HaveOccurred())
+
+			entries, err := cache.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries[0].CreatedAt).To(Equal(time.Date(2017, time.January, 2, 3, 4, 5, 0, time.UTC)))
+			Expect(entries[0].LastUsedAt).To(Equal(time.Date(2017, time.January, 2, 4, 4, 5, 0, time.UTC)))
+		})
+	})
+
+	Describe("List", func() {
+		It("returns entries sorted by name", func() {
+			Expect(cache.Save(pkg, tarballPath, digest)).To(Succeed())
+			Expect(cache.Save(newPkg("lib", "lib-fp"), tarballPath, digest)).To(Succeed())
+
+			entries, err := cache.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(HaveLen(2))
+
+			Expect(entries[0].Name).To(Equal("app"))
+			Expect(entries[0].Fingerprint).To(Equal("app-fp"))
+			Expect(entries[0].Dependencies).To(Equal([]string{"lib:lib-fp"}))
+			Expect(entries[0].OS).To(Equal("linux"))
+			Expect(entries[0].Arch).To(Equal("amd64"))
+			Expect(entries[0].SHA1).To(Equal(digest))
+			Expect(entries[0].Size).To(Equal(int64(len("compiled"))))
+
+			Expect(entries[1].Name).To(Equal("lib"))
+			Expect(entries[1].Dependencies).To(BeEmpty())
+		})
+
+		It("returns no entries if cache does not exist", func() {
+			entries, err := cache.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(BeEmpty())
+		})
+	})
+
+	Describe("Prune", func() {
+		It("removes entries not used since given time", func() {
+			Expect(cache.Save(newPkg("lib", "lib-fp"), tarballPath, digest)).To(Succeed())
+
+			timeService.Increment(48 * time.Hour)
+
+			Expect(cache.Save(pkg, tarballPath, digest)).To(Succeed())
+
+			entries, err := cache.Prune(timeService.Now().Add(-24 * time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].Name).To(Equal("lib"))
+
+			Expect(fs.FileExists(filepath.Join(basePath, entries[0].Key))).To(BeFalse())
+
+			entries, err = cache.List()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].Name).To(Equal("app"))
+		})
+
+		It("removes all entries when given current time", func() {
+			Expect(cache.Save(pkg, tarballPath, digest)).To(Succeed())
+
+			entries, err := cache.Prune(timeService.Now())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+
+			_, found, err := cache.Get(pkg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
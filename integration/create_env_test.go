@@ -413,7 +413,6 @@ cloud_provider:
 				diskDeployer = bivm.NewDiskDeployer(diskManagerFactory, diskRepo, logger)
 				vmManagerFactory = bivm.NewManagerFactory(vmRepo, stemcellRepo, diskDeployer, fakeAgentIDGenerator, fs, logger)
 				deployer := bidepl.NewDeployer(
-					vmManagerFactory,
 					instanceManagerFactory,
 					deploymentFactory,
 					logger,
@@ -453,6 +452,15 @@ cloud_provider:
 
 				tempRootConfigurator := NewTempRootConfigurator(fs)
 
+				instanceEnvProviderFactory := bidepl.NewInstanceEnvProviderFactory(
+					deploymentStateService,
+					vmManagerFactory,
+					mockAgentClientFactory,
+					mockBlobstoreFactory,
+					fakeRepoUUIDGenerator,
+					logger,
+				)
+
 				return NewDeploymentPreparer(
 					ui,
 					logger,
@@ -463,9 +471,7 @@ cloud_provider:
 					deploymentRecord,
 					mockCloudFactory,
 					stemcellManagerFactory,
-					mockAgentClientFactory,
-					vmManagerFactory,
-					mockBlobstoreFactory,
+					instanceEnvProviderFactory,
 					deployer,
 					deploymentManifestPath,
 					deploymentVars,
@@ -0,0 +1,85 @@
+package integration_test
+
+import (
+	"encoding/pem"
+	"net/http"
+	"path/filepath"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/cloudfoundry/bosh-cli/cmd"
+	boshui "github.com/cloudfoundry/bosh-cli/ui"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+)
+
+var _ = Describe("--environments flag", func() {
+	var (
+		ui         *fakeui.FakeUI
+		fs         boshsys.FileSystem
+		cmdFactory Factory
+		configPath string
+	)
+
+	BeforeEach(func() {
+		ui = &fakeui.FakeUI{}
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		confUI := boshui.NewWrappingConfUI(ui, logger)
+
+		fs = boshsys.NewOsFileSystem(logger)
+		cmdFactory = NewFactory(NewBasicDepsWithFS(confUI, fs, logger))
+
+		tmpDir, err := fs.TempDir("environments-test")
+		Expect(err).ToNot(HaveOccurred())
+
+		configPath = filepath.Join(tmpDir, "config")
+	})
+
+	buildDirector := func(deploymentName string) (string, *ghttp.Server) {
+		director := ghttp.NewTLSServer()
+
+		caCert := string(pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: director.HTTPTestServer.Certificate().Raw,
+		}))
+
+		director.RouteToHandler("GET", "/info", ghttp.RespondWith(
+			http.StatusOK, `{"user_authentication":{"type":"basic","options":{}}}`))
+
+		director.RouteToHandler("GET", "/deployments", ghttp.RespondWith(
+			http.StatusOK, `[{"name":"`+deploymentName+`","releases":[],"stemcells":[],"teams":[],"cloud_config":"none"}]`))
+
+		return caCert, director
+	}
+
+	// Run with 'go test -race' to check that environments do not share mutable state
+	It("lists deployments from multiple environments in parallel", func() {
+		caCert, director1 := buildDirector("dep1")
+		defer director1.Close()
+
+		caCert2, director2 := buildDirector("dep2")
+		Expect(caCert2).To(Equal(caCert))
+		defer director2.Close()
+
+		cmd, err := cmdFactory.New([]string{
+			"deployments",
+			"--config", configPath,
+			"--ca-cert", caCert,
+			"--environments", director1.URL() + "," + director2.URL(),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = cmd.Execute()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ui.Tables).To(HaveLen(1))
+		Expect(ui.Tables[0].Rows).To(HaveLen(2))
+		Expect(ui.Tables[0].Rows[0][0].String()).To(Equal(director1.URL()))
+		Expect(ui.Tables[0].Rows[0][1].String()).To(Equal("dep1"))
+		Expect(ui.Tables[0].Rows[1][0].String()).To(Equal(director2.URL()))
+		Expect(ui.Tables[0].Rows[1][1].String()).To(Equal("dep2"))
+	})
+})
@@ -0,0 +1,145 @@
+package release
+
+import (
+	"os"
+	"path/filepath"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshcmd "github.com/cloudfoundry/bosh-utils/fileutil"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	bicrypto "github.com/cloudfoundry/bosh-cli/crypto"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+	boshpkg "github.com/cloudfoundry/bosh-cli/release/pkg"
+	. "github.com/cloudfoundry/bosh-cli/release/resource"
+)
+
+// FingerprintExplainer lists entries that make up job and package fingerprints
+// either from a release directory or from previously built archives.
+type FingerprintExplainer struct {
+	dirPath string
+
+	compressor       boshcmd.Compressor
+	digestCalculator bicrypto.DigestCalculator
+	fs               boshsys.FileSystem
+}
+
+func NewFingerprintExplainer(
+	dirPath string,
+	compressor boshcmd.Compressor,
+	digestCalculator bicrypto.DigestCalculator,
+	fs boshsys.FileSystem,
+) FingerprintExplainer {
+	return FingerprintExplainer{
+		dirPath:          dirPath,
+		compressor:       compressor,
+		digestCalculator: digestCalculator,
+		fs:               fs,
+	}
+}
+
+func (e FingerprintExplainer) DirJob(name string) (string, []FingerprintEntry, error) {
+	var args *ArchiveFactoryArgs
+
+	reader := boshjob.NewDirReaderImpl(e.recordingArchiveFactory(&args), e.fs)
+
+	job, err := reader.Read(filepath.Join(e.dirPath, "jobs", name))
+	if err != nil {
+		return "", nil, bosherr.WrapErrorf(err, "Reading job '%s'", name)
+	}
+
+	entries, err := e.entries(*args)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return job.Fingerprint(), entries, nil
+}
+
+func (e FingerprintExplainer) DirPackage(name string) (string, []FingerprintEntry, error) {
+	var args *ArchiveFactoryArgs
+
+	srcDirPath := filepath.Join(e.dirPath, "src")
+	blobsDirPath := filepath.Join(e.dirPath, "blobs")
+
+	reader := boshpkg.NewDirReaderImpl(e.recordingArchiveFactory(&args), srcDirPath, blobsDirPath, e.fs)
+
+	pkg, err := reader.Read(filepath.Join(e.dirPath, "packages", name))
+	if err != nil {
+		return "", nil, bosherr.WrapErrorf(err, "Reading package '%s'", name)
+	}
+
+	if args == nil {
+		return "", nil, bosherr.Errorf("Expected package '%s' to not be vendored", name)
+	}
+
+	entries, err := e.entries(*args)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return pkg.Fingerprint(), entries, nil
+}
+
+func (e FingerprintExplainer) ArchivedJob(job *boshjob.Job) ([]FingerprintEntry, error) {
+	return e.archiveEntries(job.ArchivePath(), func(file File) File { return file }, nil, true)
+}
+
+// ArchivedPackage does not include prep scripts since they are removed from built archives
+func (e FingerprintExplainer) ArchivedPackage(pkg *boshpkg.Package) ([]FingerprintEntry, error) {
+	excludeHookModes := func(file File) File {
+		if file.RelativePath == "packaging" || file.RelativePath == "pre_packaging" {
+			file.ExcludeMode = true
+		}
+		return file
+	}
+
+	return e.archiveEntries(pkg.ArchivePath(), excludeHookModes, pkg.DependencyNames(), false)
+}
+
+func (e FingerprintExplainer) recordingArchiveFactory(args **ArchiveFactoryArgs) ArchiveFunc {
+	return func(a ArchiveFactoryArgs) Archive {
+		*args = &a
+		fingerprinter := NewFingerprinterImpl(e.digestCalculator, e.fs, a.FollowSymlinks)
+		return NewArchiveImpl(a, e.dirPath, fingerprinter, e.compressor, e.digestCalculator, nil, e.fs)
+	}
+}
+
+func (e FingerprintExplainer) entries(args ArchiveFactoryArgs) ([]FingerprintEntry, error) {
+	fingerprinter := NewFingerprinterImpl(e.digestCalculator, e.fs, args.FollowSymlinks)
+	return fingerprinter.Entries(args.Files, args.Chunks)
+}
+
+func (e FingerprintExplainer) archiveEntries(path string, fileFunc func(File) File, chunks []string, followSymlinks bool) ([]FingerprintEntry, error) {
+	extractPath, err := e.fs.TempDir("bosh-fingerprint")
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Creating temp directory to extract archive '%s'", path)
+	}
+
+	defer func() {
+		_ = e.fs.RemoveAll(extractPath)
+	}()
+
+	err = e.compressor.DecompressFileToDir(path, extractPath, boshcmd.CompressorOptions{})
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Extracting archive '%s'", path)
+	}
+
+	var files []File
+
+	err = e.fs.Walk(extractPath, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		files = append(files, fileFunc(NewFile(filePath, extractPath)))
+		return nil
+	})
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Listing extracted archive '%s'", path)
+	}
+
+	return NewFingerprinterImpl(e.digestCalculator, e.fs, followSymlinks).Entries(files, chunks)
+}
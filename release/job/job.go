@@ -32,6 +32,8 @@ type Job struct {
 type PropertyDefinition struct {
 	Description string
 	Default     biproperty.Property
+	Type        string
+	Example     biproperty.Property
 }
 
 func NewJob(resource Resource) *Job {
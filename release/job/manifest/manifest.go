@@ -16,6 +16,8 @@ type Manifest struct {
 type PropertyDefinition struct {
 	Description string      `yaml:"description"`
 	Default     interface{} `yaml:"default"`
+	Type        string      `yaml:"type"`
+	Example     interface{} `yaml:"example"`
 }
 
 func NewManifestFromPath(path string, fs boshsys.FileSystem) (Manifest, error) {
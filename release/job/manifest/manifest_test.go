@@ -37,6 +37,9 @@ properties:
   prop1.prop2:
     description: prop2-desc
     default: prop2-default
+  prop3:
+    type: array
+    example: [a, b]
 `
 
 		fs.WriteFileString("/path", contents)
@@ -59,6 +62,10 @@ properties:
 					Description: "prop2-desc",
 					Default:     "prop2-default",
 				},
+				"prop3": PropertyDefinition{
+					Type:    "array",
+					Example: []interface{}{"a", "b"},
+				},
 			},
 		}))
 	})
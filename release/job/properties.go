@@ -0,0 +1,260 @@
+package job
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+
+	boshjobman "github.com/cloudfoundry/bosh-cli/release/job/manifest"
+)
+
+// PropertyProblem describes how properties given to a job do not match its spec
+type PropertyProblem struct {
+	Job      string
+	Property string
+	Problem  string
+}
+
+func (p PropertyProblem) Error() string {
+	return fmt.Sprintf("Job '%s' property '%s': %s", p.Job, p.Property, p.Problem)
+}
+
+func NewPropertyDefinitions(jobName string, rawPropertyDefs map[string]boshjobman.PropertyDefinition) (map[string]PropertyDefinition, error) {
+	properties := make(map[string]PropertyDefinition, len(rawPropertyDefs))
+
+	for propertyName, rawPropertyDef := range rawPropertyDefs {
+		defaultValue, err := biproperty.Build(rawPropertyDef.Default)
+		if err != nil {
+			errMsg := "Parsing job '%s' property '%s' default: %#v"
+			return nil, bosherr.WrapErrorf(err, errMsg, jobName, propertyName, rawPropertyDef.Default)
+		}
+
+		exampleValue, err := biproperty.Build(rawPropertyDef.Example)
+		if err != nil {
+			errMsg := "Parsing job '%s' property '%s' example: %#v"
+			return nil, bosherr.WrapErrorf(err, errMsg, jobName, propertyName, rawPropertyDef.Example)
+		}
+
+		properties[propertyName] = PropertyDefinition{
+			Description: rawPropertyDef.Description,
+			Default:     defaultValue,
+			Type:        rawPropertyDef.Type,
+			Example:     exampleValue,
+		}
+	}
+
+	return properties, nil
+}
+
+// HasPropertyTypes returns true if job spec opted into property type checking
+func (j Job) HasPropertyTypes() bool {
+	for _, propDef := range j.Properties {
+		if len(propDef.Type) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateProperties checks properties the same way templates see them:
+// job level properties are used when given, otherwise global properties merged with
+// instance group properties. Unknown properties are only reported for job level properties
+// since instance group and global properties are shared between jobs.
+// Supported types are string, integer, float, boolean, array and hash; other types are not checked.
+func ValidateProperties(
+	releaseJobs []Job,
+	releaseJobProperties map[string]*biproperty.Map,
+	jobProperties biproperty.Map,
+	globalProperties biproperty.Map,
+) []PropertyProblem {
+	var problems []PropertyProblem
+
+	for _, releaseJob := range releaseJobs {
+		properties := mergeProperties(globalProperties, jobProperties)
+
+		if releaseJobProps, found := releaseJobProperties[releaseJob.Name()]; found && releaseJobProps != nil {
+			properties = *releaseJobProps
+
+			for _, name := range unknownProperties(properties, "", releaseJob.Properties) {
+				problems = append(problems, PropertyProblem{
+					Job:      releaseJob.Name(),
+					Property: name,
+					Problem:  "Unknown property",
+				})
+			}
+		}
+
+		var names []string
+
+		for name, _ := range releaseJob.Properties {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			propDef := releaseJob.Properties[name]
+
+			value, found := lookupProperty(properties, name)
+			if !found || value == nil {
+				if propDef.Default == nil {
+					problems = append(problems, PropertyProblem{
+						Job:      releaseJob.Name(),
+						Property: name,
+						Problem:  "Missing required property",
+					})
+				}
+				continue
+			}
+
+			if !propertyMatchesType(value, propDef.Type) {
+				problems = append(problems, PropertyProblem{
+					Job:      releaseJob.Name(),
+					Property: name,
+					Problem:  fmt.Sprintf("Expected %s but got %s", propDef.Type, propertyType(value)),
+				})
+			}
+		}
+	}
+
+	return problems
+}
+
+func unknownProperties(properties biproperty.Map, prefix string, propDefs map[string]PropertyDefinition) []string {
+	var unknown []string
+
+	var keys []string
+
+	for key, _ := range properties {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name := prefix + key
+
+		if _, found := propDefs[name]; found {
+			continue
+		}
+
+		var nested bool
+
+		for propName, _ := range propDefs {
+			if strings.HasPrefix(propName, name+".") {
+				nested = true
+				break
+			}
+		}
+
+		if nested {
+			if nestedProps, ok := asPropertyMap(properties[key]); ok {
+				unknown = append(unknown, unknownProperties(nestedProps, name+".", propDefs)...)
+				continue
+			}
+		}
+
+		unknown = append(unknown, name)
+	}
+
+	return unknown
+}
+
+func lookupProperty(properties biproperty.Map, name string) (biproperty.Property, bool) {
+	var current biproperty.Property = properties
+
+	for _, key := range strings.Split(name, ".") {
+		currentMap, ok := asPropertyMap(current)
+		if !ok {
+			return nil, false
+		}
+
+		current, ok = currentMap[key]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+func mergeProperties(base, overrides biproperty.Map) biproperty.Map {
+	result := biproperty.Map{}
+
+	for key, value := range base {
+		result[key] = value
+	}
+
+	for key, value := range overrides {
+		baseMap, baseOk := asPropertyMap(result[key])
+		overrideMap, overrideOk := asPropertyMap(value)
+
+		if baseOk && overrideOk {
+			result[key] = mergeProperties(baseMap, overrideMap)
+		} else {
+			result[key] = value
+		}
+	}
+
+	return result
+}
+
+func asPropertyMap(value biproperty.Property) (biproperty.Map, bool) {
+	typedValue, ok := value.(biproperty.Map)
+	return typedValue, ok
+}
+
+func propertyMatchesType(value biproperty.Property, type_ string) bool {
+	kind := reflect.TypeOf(value).Kind()
+
+	switch type_ {
+	case "string":
+		return kind == reflect.String
+	case "integer":
+		return isIntKind(kind)
+	case "float":
+		return isIntKind(kind) || kind == reflect.Float32 || kind == reflect.Float64
+	case "boolean":
+		return kind == reflect.Bool
+	case "array":
+		return kind == reflect.Slice || kind == reflect.Array
+	case "hash":
+		return kind == reflect.Map
+	default:
+		return true
+	}
+}
+
+func propertyType(value biproperty.Property) string {
+	kind := reflect.TypeOf(value).Kind()
+
+	switch {
+	case kind == reflect.String:
+		return "string"
+	case isIntKind(kind):
+		return "integer"
+	case kind == reflect.Float32 || kind == reflect.Float64:
+		return "float"
+	case kind == reflect.Bool:
+		return "boolean"
+	case kind == reflect.Slice || kind == reflect.Array:
+		return "array"
+	case kind == reflect.Map:
+		return "hash"
+	default:
+		return kind.String()
+	}
+}
+
+func isIntKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
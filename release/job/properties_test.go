@@ -0,0 +1,141 @@
+package job_test
+
+import (
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/release/job"
+	boshjobman "github.com/cloudfoundry/bosh-cli/release/job/manifest"
+	. "github.com/cloudfoundry/bosh-cli/release/resource"
+)
+
+var _ = Describe("NewPropertyDefinitions", func() {
+	It("builds property definitions from job spec", func() {
+		defs, err := NewPropertyDefinitions("job", map[string]boshjobman.PropertyDefinition{
+			"prop1": boshjobman.PropertyDefinition{
+				Description: "desc",
+				Default:     map[interface{}]interface{}{"key": "val"},
+				Type:        "hash",
+				Example:     []interface{}{"a"},
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(defs).To(Equal(map[string]PropertyDefinition{
+			"prop1": PropertyDefinition{
+				Description: "desc",
+				Default:     biproperty.Map{"key": "val"},
+				Type:        "hash",
+				Example:     biproperty.List{"a"},
+			},
+		}))
+	})
+
+	It("returns error if default cannot be built", func() {
+		_, err := NewPropertyDefinitions("job", map[string]boshjobman.PropertyDefinition{
+			"prop1": boshjobman.PropertyDefinition{Default: map[interface{}]interface{}{1: "val"}},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Parsing job 'job' property 'prop1' default"))
+	})
+})
+
+var _ = Describe("ValidateProperties", func() {
+	var (
+		job *Job
+	)
+
+	BeforeEach(func() {
+		job = NewJob(NewResource("job", "fp", nil))
+		job.Properties = map[string]PropertyDefinition{
+			"port":         PropertyDefinition{Type: "integer"},
+			"ratio":        PropertyDefinition{Type: "float", Default: 0.5},
+			"tls.enabled":  PropertyDefinition{Type: "boolean", Default: false},
+			"tls.ciphers":  PropertyDefinition{Type: "array", Default: biproperty.List{}},
+			"users":        PropertyDefinition{Type: "hash", Default: biproperty.Map{}},
+			"name":         PropertyDefinition{Type: "string", Default: "name"},
+			"certificate":  PropertyDefinition{Type: "certificate", Default: ""},
+			"untyped":      PropertyDefinition{Default: "val"},
+			"nested.props": PropertyDefinition{Default: biproperty.Map{}},
+		}
+	})
+
+	It("returns no problems when job properties match spec", func() {
+		jobProps := biproperty.Map{
+			"port":  8080,
+			"ratio": 1,
+			"tls": biproperty.Map{
+				"enabled": true,
+				"ciphers": biproperty.List{"a"},
+			},
+			"users":       biproperty.Map{"admin": "pass"},
+			"certificate": biproperty.Map{"ca": "ca"},
+			"nested": biproperty.Map{
+				"props": biproperty.Map{"any": "value"},
+			},
+		}
+
+		problems := ValidateProperties([]Job{*job}, map[string]*biproperty.Map{"job": &jobProps}, nil, nil)
+		Expect(problems).To(BeEmpty())
+	})
+
+	It("reports unknown, missing and mismatched job properties", func() {
+		jobProps := biproperty.Map{
+			"prot":  8080,
+			"ratio": "half",
+			"tls": biproperty.Map{
+				"enabled": "yes",
+				"cipher":  "a",
+			},
+			"users": biproperty.List{},
+		}
+
+		problems := ValidateProperties([]Job{*job}, map[string]*biproperty.Map{"job": &jobProps}, nil, nil)
+		Expect(problems).To(Equal([]PropertyProblem{
+			{Job: "job", Property: "prot", Problem: "Unknown property"},
+			{Job: "job", Property: "tls.cipher", Problem: "Unknown property"},
+			{Job: "job", Property: "port", Problem: "Missing required property"},
+			{Job: "job", Property: "ratio", Problem: "Expected float but got string"},
+			{Job: "job", Property: "tls.enabled", Problem: "Expected boolean but got string"},
+			{Job: "job", Property: "users", Problem: "Expected hash but got array"},
+		}))
+	})
+
+	It("uses global properties merged with instance group properties when job properties are not given", func() {
+		globalProps := biproperty.Map{
+			"port": "8080",
+			"tls":  biproperty.Map{"enabled": true},
+		}
+
+		instanceGroupProps := biproperty.Map{
+			"port":    8080,
+			"tls":     biproperty.Map{"ciphers": "a"},
+			"unknown": "val",
+		}
+
+		problems := ValidateProperties([]Job{*job}, map[string]*biproperty.Map{}, instanceGroupProps, globalProps)
+		Expect(problems).To(Equal([]PropertyProblem{
+			{Job: "job", Property: "tls.ciphers", Problem: "Expected array but got string"},
+		}))
+	})
+
+	It("formats problems as errors", func() {
+		problem := PropertyProblem{Job: "job", Property: "port", Problem: "Missing required property"}
+		Expect(problem.Error()).To(Equal("Job 'job' property 'port': Missing required property"))
+	})
+})
+
+var _ = Describe("Job", func() {
+	Describe("HasPropertyTypes", func() {
+		It("returns true only if some property specifies type", func() {
+			job := NewJob(NewResource("job", "fp", nil))
+			Expect(job.HasPropertyTypes()).To(BeFalse())
+
+			job.Properties = map[string]PropertyDefinition{"prop": PropertyDefinition{Default: "val"}}
+			Expect(job.HasPropertyTypes()).To(BeFalse())
+
+			job.Properties["typed"] = PropertyDefinition{Type: "string"}
+			Expect(job.HasPropertyTypes()).To(BeTrue())
+		})
+	})
+})
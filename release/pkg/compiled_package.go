@@ -3,9 +3,8 @@ package pkg
 import (
 	"fmt"
 
-	"os"
-
 	"github.com/cloudfoundry/bosh-cli/crypto"
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
 	crypto2 "github.com/cloudfoundry/bosh-utils/crypto"
 	bosherr "github.com/cloudfoundry/bosh-utils/errors"
 )
@@ -98,17 +97,7 @@ func (p *CompiledPackage) Deps() []Compilable {
 func (p *CompiledPackage) IsCompiled() bool { return true }
 
 func (p *CompiledPackage) RehashWithCalculator(digestCalculator crypto.DigestCalculator, archiveFileReader crypto2.ArchiveDigestFilePathReader) (*CompiledPackage, error) {
-	pkgFile, err := archiveFileReader.OpenFile(p.archivePath, os.O_RDONLY, 0)
-	if err != nil {
-		return nil, err
-	}
-
-	digest, err := crypto2.ParseMultipleDigest(p.archiveDigest)
-	if err != nil {
-		return nil, err
-	}
-
-	err = digest.Verify(pkgFile)
+	err := boshres.VerifyArchiveDigest(p.archivePath, p.archiveDigest, archiveFileReader)
 	if err != nil {
 		return nil, err
 	}
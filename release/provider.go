@@ -77,6 +77,10 @@ func (p Provider) NewDirReader(dirPath string) DirReader {
 	return NewDirReader(jobDirReader, pkgDirReader, licDirReader, p.fs, p.logger)
 }
 
+func (p Provider) NewFingerprintExplainer(dirPath string) FingerprintExplainer {
+	return NewFingerprintExplainer(dirPath, p.compressor, p.digestCalculator, p.fs)
+}
+
 func (p Provider) NewManifestReader() ManifestReader {
 	return NewManifestReader(p.fs, p.logger)
 }
@@ -0,0 +1,228 @@
+package release
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshcmd "github.com/cloudfoundry/bosh-utils/fileutil"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+)
+
+// ReproducibleCompressor produces byte-identical tarballs for identical files
+// by normalizing entry ordering, modification times, ownership and modes.
+// Decompression is delegated to the wrapped compressor.
+type ReproducibleCompressor struct {
+	compressor boshcmd.Compressor
+	fs         boshsys.FileSystem
+}
+
+var reproducibleModTime = time.Unix(0, 0)
+
+func NewReproducibleCompressor(compressor boshcmd.Compressor, fs boshsys.FileSystem) ReproducibleCompressor {
+	return ReproducibleCompressor{compressor: compressor, fs: fs}
+}
+
+func (c ReproducibleCompressor) CompressFilesInDir(dir string) (string, error) {
+	return c.CompressSpecificFilesInDir(dir, []string{"."})
+}
+
+func (c ReproducibleCompressor) CompressSpecificFilesInDir(dir string, files []string) (string, error) {
+	paths, err := c.collectPaths(dir, files)
+	if err != nil {
+		return "", bosherr.WrapError(err, "Collecting files for tarball")
+	}
+
+	tarball, err := c.fs.TempFile("bosh-reproducible-tarball")
+	if err != nil {
+		return "", bosherr.WrapError(err, "Creating temporary file for tarball")
+	}
+
+	defer tarball.Close()
+
+	// Zero value gzip header does not include file name or modification time
+	gzipWriter := gzip.NewWriter(tarball)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	for _, path := range paths {
+		err := c.writeEntry(tarWriter, dir, path)
+		if err != nil {
+			_ = c.fs.RemoveAll(tarball.Name())
+			return "", bosherr.WrapErrorf(err, "Adding '%s' to tarball", path)
+		}
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		_ = c.fs.RemoveAll(tarball.Name())
+		return "", bosherr.WrapError(err, "Closing tarball")
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		_ = c.fs.RemoveAll(tarball.Name())
+		return "", bosherr.WrapError(err, "Closing tarball")
+	}
+
+	return tarball.Name(), nil
+}
+
+func (c ReproducibleCompressor) DecompressFileToDir(path string, dir string, options boshcmd.CompressorOptions) error {
+	return c.compressor.DecompressFileToDir(path, dir, options)
+}
+
+func (c ReproducibleCompressor) CleanUp(path string) error {
+	return c.fs.RemoveAll(path)
+}
+
+// collectPaths returns sorted relative paths of given files and directory contents
+func (c ReproducibleCompressor) collectPaths(dir string, files []string) ([]string, error) {
+	pathsSet := map[string]struct{}{}
+
+	for _, file := range files {
+		err := c.fs.Walk(filepath.Join(dir, file), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			relPath, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+
+			if relPath != "." {
+				pathsSet[filepath.ToSlash(relPath)] = struct{}{}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var paths []string
+
+	for path := range pathsSet {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+func (c ReproducibleCompressor) writeEntry(tarWriter *tar.Writer, dir, relPath string) error {
+	path := filepath.Join(dir, filepath.FromSlash(relPath))
+
+	info, err := c.fs.Lstat(path)
+	if err != nil {
+		return err
+	}
+
+	hdr := &tar.Header{
+		Name:    relPath,
+		ModTime: reproducibleModTime,
+	}
+
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		hdr.Typeflag = tar.TypeSymlink
+		hdr.Mode = 0777
+
+		hdr.Linkname, err = c.fs.Readlink(path)
+		if err != nil {
+			return err
+		}
+
+	case info.IsDir():
+		hdr.Typeflag = tar.TypeDir
+		hdr.Name += "/"
+		hdr.Mode = 0755
+
+	case info.Mode().IsRegular():
+		hdr.Typeflag = tar.TypeReg
+		hdr.Size = info.Size()
+
+		// Only executable bit is preserved similarly to fingerprinting
+		if info.Mode()&0111 != 0 {
+			hdr.Mode = 0755
+		} else {
+			hdr.Mode = 0644
+		}
+
+	default:
+		return bosherr.Errorf("Expected '%s' to be a regular file, directory or symlink", relPath)
+	}
+
+	err = tarWriter.WriteHeader(hdr)
+	if err != nil {
+		return err
+	}
+
+	if hdr.Typeflag != tar.TypeReg {
+		return nil
+	}
+
+	file, err := c.fs.OpenFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	_, err = io.Copy(tarWriter, file)
+
+	return err
+}
+
+// VerifyReproducibleArchive returns error if tarball was not produced with
+// normalized metadata, e.g. archives built before reproducible builds were used
+func VerifyReproducibleArchive(path string, fs boshsys.FileSystem) error {
+	file, err := fs.OpenFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Opening tarball '%s'", path)
+	}
+
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Reading tarball '%s'", path)
+	}
+
+	if len(gzipReader.Header.Name) > 0 || !gzipReader.Header.ModTime.IsZero() {
+		return bosherr.Errorf("Expected tarball '%s' to not record file name or modification time", path)
+	}
+
+	tarReader := tar.NewReader(gzipReader)
+	lastName := ""
+
+	for {
+		hdr, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return bosherr.WrapErrorf(err, "Reading tarball '%s'", path)
+		}
+
+		// Directory names are sorted without trailing slash
+		name := strings.TrimSuffix(hdr.Name, "/")
+
+		if len(lastName) > 0 && name <= lastName {
+			return bosherr.Errorf("Expected tarball '%s' entries to be sorted but found '%s' after '%s'", path, hdr.Name, lastName)
+		}
+
+		lastName = name
+
+		if !hdr.ModTime.Equal(reproducibleModTime) || hdr.Uid != 0 || hdr.Gid != 0 || len(hdr.Uname) > 0 || len(hdr.Gname) > 0 {
+			return bosherr.Errorf("Expected tarball '%s' entry '%s' to have normalized modification time and ownership", path, hdr.Name)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package release_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	boshcmd "github.com/cloudfoundry/bosh-utils/fileutil"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/release"
+)
+
+var _ = Describe("ReproducibleCompressor", func() {
+	var (
+		fs         boshsys.FileSystem
+		compressor ReproducibleCompressor
+		dirs       []string
+	)
+
+	BeforeEach(func() {
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		fs = boshsys.NewOsFileSystem(logger)
+		cmdRunner := boshsys.NewExecCmdRunner(logger)
+		compressor = NewReproducibleCompressor(boshcmd.NewTarballCompressor(cmdRunner, fs), fs)
+		dirs = nil
+	})
+
+	AfterEach(func() {
+		for _, dir := range dirs {
+			Expect(fs.RemoveAll(dir)).To(Succeed())
+		}
+	})
+
+	createDir := func(modTime time.Time, fileMode os.FileMode) string {
+		dir, err := fs.TempDir("reproducible-compressor-test")
+		Expect(err).ToNot(HaveOccurred())
+		dirs = append(dirs, dir)
+
+		Expect(fs.MkdirAll(filepath.Join(dir, "jobs"), 0700)).To(Succeed())
+		Expect(fs.WriteFileString(filepath.Join(dir, "release.MF"), "name: rel")).To(Succeed())
+		Expect(fs.WriteFileString(filepath.Join(dir, "jobs", "b.tgz"), "b")).To(Succeed())
+		Expect(fs.WriteFileString(filepath.Join(dir, "jobs", "a.tgz"), "a")).To(Succeed())
+		Expect(fs.Chmod(filepath.Join(dir, "jobs", "a.tgz"), fileMode)).To(Succeed())
+		Expect(fs.Symlink("a.tgz", filepath.Join(dir, "jobs", "link"))).To(Succeed())
+
+		for _, path := range []string{"release.MF", "jobs/a.tgz", "jobs/b.tgz", "jobs"} {
+			Expect(os.Chtimes(filepath.Join(dir, path), modTime, modTime)).To(Succeed())
+		}
+
+		return dir
+	}
+
+	readHeaders := func(path string) []tar.Header {
+		file, err := os.Open(path)
+		Expect(err).ToNot(HaveOccurred())
+		defer file.Close()
+
+		gzipReader, err := gzip.NewReader(file)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gzipReader.Header.ModTime.IsZero()).To(BeTrue())
+		Expect(gzipReader.Header.Name).To(BeEmpty())
+
+		var hdrs []tar.Header
+
+		tarReader := tar.NewReader(gzipReader)
+		for {
+			hdr, err := tarReader.Next()
+			if err == io.EOF {
+				break
+			}
+			Expect(err).ToNot(HaveOccurred())
+			hdrs = append(hdrs, *hdr)
+		}
+
+		return hdrs
+	}
+
+	Describe("CompressSpecificFilesInDir", func() {
+		It("produces byte-identical tarballs regardless of mtimes, permissions and file order", func() {
+			dir1 := createDir(time.Now(), 0700)
+			dir2 := createDir(time.Now().Add(-time.Hour), 0755)
+
+			path1, err := compressor.CompressSpecificFilesInDir(dir1, []string{"release.MF", "jobs"})
+			Expect(err).ToNot(HaveOccurred())
+			defer compressor.CleanUp(path1)
+
+			path2, err := compressor.CompressSpecificFilesInDir(dir2, []string{"jobs", "release.MF"})
+			Expect(err).ToNot(HaveOccurred())
+			defer compressor.CleanUp(path2)
+
+			bytes1, err := ioutil.ReadFile(path1)
+			Expect(err).ToNot(HaveOccurred())
+
+			bytes2, err := ioutil.ReadFile(path2)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(bytes1).To(Equal(bytes2))
+		})
+
+		It("writes sorted entries with normalized headers", func() {
+			dir := createDir(time.Now(), 0700)
+
+			path, err := compressor.CompressSpecificFilesInDir(dir, []string{"release.MF", "jobs"})
+			Expect(err).ToNot(HaveOccurred())
+			defer compressor.CleanUp(path)
+
+			hdrs := readHeaders(path)
+
+			var names []string
+			for _, hdr := range hdrs {
+				names = append(names, hdr.Name)
+				Expect(hdr.ModTime.Unix()).To(Equal(int64(0)))
+				Expect(hdr.Uid).To(Equal(0))
+				Expect(hdr.Gid).To(Equal(0))
+				Expect(hdr.Uname).To(BeEmpty())
+				Expect(hdr.Gname).To(BeEmpty())
+			}
+
+			Expect(names).To(Equal([]string{"jobs/", "jobs/a.tgz", "jobs/b.tgz", "jobs/link", "release.MF"}))
+
+			Expect(hdrs[0].Mode).To(Equal(int64(0755)))
+			Expect(hdrs[1].Mode).To(Equal(int64(0755)))
+			Expect(hdrs[2].Mode).To(Equal(int64(0644)))
+			Expect(hdrs[3].Linkname).To(Equal("a.tgz"))
+		})
+
+		It("produces tarballs that can be decompressed", func() {
+			dir := createDir(time.Now(), 0700)
+
+			path, err := compressor.CompressFilesInDir(dir)
+			Expect(err).ToNot(HaveOccurred())
+			defer compressor.CleanUp(path)
+
+			dstDir, err := fs.TempDir("reproducible-compressor-test")
+			Expect(err).ToNot(HaveOccurred())
+			dirs = append(dirs, dstDir)
+
+			err = compressor.DecompressFileToDir(path, dstDir, boshcmd.CompressorOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fs.ReadFileString(filepath.Join(dstDir, "jobs", "b.tgz"))).To(Equal("b"))
+			Expect(fs.ReadFileString(filepath.Join(dstDir, "release.MF"))).To(Equal("name: rel"))
+		})
+
+		It("returns error if given file does not exist", func() {
+			dir := createDir(time.Now(), 0700)
+
+			_, err := compressor.CompressSpecificFilesInDir(dir, []string{"missing"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Collecting files for tarball"))
+		})
+	})
+
+	Describe("VerifyReproducibleArchive", func() {
+		It("succeeds for tarballs produced by compressor", func() {
+			dir := createDir(time.Now(), 0700)
+
+			path, err := compressor.CompressFilesInDir(dir)
+			Expect(err).ToNot(HaveOccurred())
+			defer compressor.CleanUp(path)
+
+			Expect(VerifyReproducibleArchive(path, fs)).To(Succeed())
+		})
+
+		It("returns error for tarballs with original metadata", func() {
+			dir := createDir(time.Now(), 0700)
+
+			cmdRunner := boshsys.NewExecCmdRunner(boshlog.NewLogger(boshlog.LevelNone))
+			tarballCompressor := boshcmd.NewTarballCompressor(cmdRunner, fs)
+
+			path, err := tarballCompressor.CompressFilesInDir(dir)
+			Expect(err).ToNot(HaveOccurred())
+			defer tarballCompressor.CleanUp(path)
+
+			err = VerifyReproducibleArchive(path, fs)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected tarball"))
+		})
+
+		It("returns error if tarball cannot be read", func() {
+			dir := createDir(time.Now(), 0700)
+
+			err := VerifyReproducibleArchive(filepath.Join(dir, "release.MF"), fs)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Reading tarball"))
+		})
+	})
+})
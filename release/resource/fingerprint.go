@@ -27,21 +27,57 @@ func NewFingerprinterImpl(digestCalculator bicrypto.DigestCalculator, fs boshsys
 	}
 }
 
+// FingerprintEntry is a single chunk that goes into a fingerprint.
+// Additional chunks are combined into a single entry without a digest or mode.
+type FingerprintEntry struct {
+	Name   string
+	Digest string
+	Mode   string
+
+	AdditionalChunks bool
+}
+
+func (e FingerprintEntry) String() string { return e.Name + e.Digest + e.Mode }
+
 func (f FingerprinterImpl) Calculate(files []File, additionalChunks []string) (string, error) {
+	entries, err := f.Entries(files, additionalChunks)
+	if err != nil {
+		return "", err
+	}
+
 	chunks := []string{"v2"}
 
+	for _, entry := range entries {
+		chunks = append(chunks, entry.String())
+	}
+
+	digestStr := f.digestCalculator.CalculateString(strings.Join(chunks, ""))
+	trimmedDigestStr := strings.TrimPrefix(digestStr, "sha256:")
+
+	validID := regexp.MustCompile(`^[0-9A-Za-z]+$`)
+	if !validID.MatchString(trimmedDigestStr) {
+		return "", bosherr.Errorf("Generated fingerprint contains unexpected characters '%s'", trimmedDigestStr)
+	}
+
+	return trimmedDigestStr, nil
+}
+
+// Entries returns entries in the order they are used to calculate a fingerprint
+func (f FingerprinterImpl) Entries(files []File, additionalChunks []string) ([]FingerprintEntry, error) {
+	var entries []FingerprintEntry
+
 	// Ensure consistent ordering of files
 	sortedFiles := make([]File, len(files))
 	copy(sortedFiles, files)
 	sort.Sort(FileRelativePathSorting(sortedFiles))
 
 	for _, file := range sortedFiles {
-		chunk, err := f.fingerprintPath(file)
+		entry, err := f.fingerprintPath(file)
 		if err != nil {
-			return "", bosherr.WrapErrorf(err, "Fingerprinting file '%s'", file.Path)
+			return nil, bosherr.WrapErrorf(err, "Fingerprinting file '%s'", file.Path)
 		}
 
-		chunks = append(chunks, chunk)
+		entries = append(entries, entry)
 	}
 
 	if len(additionalChunks) > 0 {
@@ -50,18 +86,13 @@ func (f FingerprinterImpl) Calculate(files []File, additionalChunks []string) (s
 		copy(sortedAdditionalChunks, additionalChunks)
 		sort.Sort(AdditionalChunkSorting(sortedAdditionalChunks))
 
-		chunks = append(chunks, strings.Join(sortedAdditionalChunks, ","))
+		entries = append(entries, FingerprintEntry{
+			Name:             strings.Join(sortedAdditionalChunks, ","),
+			AdditionalChunks: true,
+		})
 	}
 
-	digestStr := f.digestCalculator.CalculateString(strings.Join(chunks, ""))
-	trimmedDigestStr := strings.TrimPrefix(digestStr, "sha256:")
-
-	validID := regexp.MustCompile(`^[0-9A-Za-z]+$`)
-	if !validID.MatchString(trimmedDigestStr) {
-		return "", bosherr.Errorf("Generated fingerprint contains unexpected characters '%s'", trimmedDigestStr)
-	}
-
-	return trimmedDigestStr, nil
+	return entries, nil
 }
 
 // fingerprintPath currently works with:
@@ -71,18 +102,18 @@ func (f FingerprinterImpl) Calculate(files []File, additionalChunks []string) (s
 //   - changes: rel_path, sorting
 // - lic: [File.basename(abs_path), digest]
 //   - changes: sorting
-func (f FingerprinterImpl) fingerprintPath(file File) (string, error) {
-	var result string
+func (f FingerprinterImpl) fingerprintPath(file File) (FingerprintEntry, error) {
+	var result FingerprintEntry
 
 	if file.UseBasename {
-		result += filepath.Base(file.Path)
+		result.Name = filepath.Base(file.Path)
 	} else {
-		result += file.RelativePath
+		result.Name = file.RelativePath
 	}
 
 	fileInfo, err := f.fs.Lstat(file.Path)
 	if err != nil {
-		return "", err
+		return FingerprintEntry{}, err
 	}
 
 	isSymlink := fileInfo.Mode()&os.ModeSymlink != 0
@@ -91,28 +122,28 @@ func (f FingerprinterImpl) fingerprintPath(file File) (string, error) {
 	if isSymlink && f.followSymlinks {
 		targetFilePath, err = f.fs.ReadAndFollowLink(file.Path)
 		if err != nil {
-			return "", err
+			return FingerprintEntry{}, err
 		}
 	}
 
 	if isSymlink && !f.followSymlinks {
 		symlinkTarget, err := f.fs.Readlink(file.Path)
 		if err != nil {
-			return "", err
+			return FingerprintEntry{}, err
 		}
 
 		//generation of digest string
 		sha1 := f.digestCalculator.CalculateString(symlinkTarget)
 
-		result += sha1
+		result.Digest = sha1
 	} else {
 		//generation of digest string
 		sha1, err := f.digestCalculator.Calculate(targetFilePath)
 		if err != nil {
-			return "", err
+			return FingerprintEntry{}, err
 		}
 
-		result += sha1
+		result.Digest = sha1
 	}
 
 	if !file.ExcludeMode {
@@ -134,7 +165,7 @@ func (f FingerprinterImpl) fingerprintPath(file File) (string, error) {
 			modeStr = "100644"
 		}
 
-		result += modeStr
+		result.Mode = modeStr
 	}
 
 	return result, nil
@@ -120,6 +120,26 @@ var _ = Describe("FingerprinterImpl", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(fp).To(Equal("asdfasdfasdfasdf"))
 		})
+
+		It("returns entries that make up a fingerprint in the same order", func() {
+			entries, err := fingerprinter.Entries(files, []string{"chunk2", "chunk1"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(entries).To(Equal([]FingerprintEntry{
+				{Name: "file1", Mode: "40755"},
+				{Name: "file2", Digest: "file2-sha1", Mode: "100644"},
+				{Name: "file3", Digest: "file3-sha1", Mode: "100755"},
+				{Name: "file5", Digest: "file5-sha1"},
+				{Name: "rel/file4", Digest: "file4-sha1", Mode: "100644"},
+				{Name: "file6", Digest: "file6-sha1", Mode: "100644"},
+				{Name: "chunk1,chunk2", AdditionalChunks: true},
+			}))
+
+			var entryChunks []string
+			for _, entry := range entries {
+				entryChunks = append(entryChunks, entry.String())
+			}
+			Expect("v2" + strings.Join(entryChunks, "")).To(Equal(strings.Join(chunks, "")))
+		})
 	})
 
 	It("returns an error when the resulting checksum contains unexpected content so it does not pass incompatible fingerprints to director", func() {
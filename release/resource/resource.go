@@ -124,18 +124,7 @@ func (r *ResourceImpl) Finalize(finalIndex ArchiveIndex) error {
 }
 
 func (r *ResourceImpl) RehashWithCalculator(calculator crypto.DigestCalculator, archiveFilePathReader crypto2.ArchiveDigestFilePathReader) (Resource, error) {
-	archiveFile, err := archiveFilePathReader.OpenFile(r.archivePath, os.O_RDONLY, 0)
-	if err != nil {
-		return &ResourceImpl{}, err
-	}
-	defer archiveFile.Close()
-
-	digest, err := crypto2.ParseMultipleDigest(r.archiveDigest)
-	if err != nil {
-		return &ResourceImpl{}, err
-	}
-	err = digest.Verify(archiveFile)
-
+	err := VerifyArchiveDigest(r.archivePath, r.archiveDigest, archiveFilePathReader)
 	if err != nil {
 		return &ResourceImpl{}, err
 	}
@@ -186,3 +175,19 @@ func (r *ResourceImpl) attachArchive(path, sha1 string) {
 func (r *ResourceImpl) hasArchive() bool {
 	return len(r.archivePath) > 0 && len(r.archiveDigest) > 0
 }
+
+// VerifyArchiveDigest returns error if archive contents do not match recorded digest
+func VerifyArchiveDigest(archivePath, archiveDigest string, archiveFilePathReader crypto2.ArchiveDigestFilePathReader) error {
+	archiveFile, err := archiveFilePathReader.OpenFile(archivePath, os.O_RDONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer archiveFile.Close()
+
+	digest, err := crypto2.ParseMultipleDigest(archiveDigest)
+	if err != nil {
+		return err
+	}
+
+	return digest.Verify(archiveFile)
+}
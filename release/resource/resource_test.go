@@ -531,3 +531,32 @@ var _ = Describe("NewResourceWithBuiltArchive", func() {
 		})
 	})
 })
+
+var _ = Describe("VerifyArchiveDigest", func() {
+	var (
+		fs *fakesfs.FakeFileSystem
+	)
+
+	BeforeEach(func() {
+		fs = fakesfs.NewFakeFileSystem()
+		fs.WriteFileString("/archive.tgz", "archive")
+	})
+
+	It("succeeds if archive matches digest", func() {
+		err := VerifyArchiveDigest("/archive.tgz", "ebfb55f4432b592119a10592e4f26272cc72359e", fs)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("returns error if archive does not match digest", func() {
+		err := VerifyArchiveDigest("/archive.tgz", "other-sha1", fs)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("returns error if archive cannot be opened", func() {
+		fs.OpenFileErr = errors.New("fake-err")
+
+		err := VerifyArchiveDigest("/archive.tgz", "ebfb55f4432b592119a10592e4f26272cc72359e", fs)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("fake-err"))
+	})
+})
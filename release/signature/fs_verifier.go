@@ -0,0 +1,90 @@
+package signature
+
+import (
+	"crypto"
+	"path/filepath"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+)
+
+type FSVerifier struct {
+	trustedKeysDir string
+	fs             boshsys.FileSystem
+}
+
+func NewFSVerifier(trustedKeysDir string, fs boshsys.FileSystem) FSVerifier {
+	return FSVerifier{trustedKeysDir: trustedKeysDir, fs: fs}
+}
+
+func (v FSVerifier) Verify(path string, release boshrel.Release) error {
+	keys, err := v.trustedKeys()
+	if err != nil {
+		return err
+	}
+
+	sigPath := Path(path)
+
+	if !v.fs.FileExists(sigPath) {
+		return bosherr.Errorf("Expected release '%s' to be signed but signature '%s' does not exist", path, sigPath)
+	}
+
+	sig, err := Read(sigPath, v.fs)
+	if err != nil {
+		return err
+	}
+
+	err = VerifyArchives(release, v.fs)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Verifying release '%s' contents", path)
+	}
+
+	payload, err := Payload(release)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if sig.Verify(payload, key) {
+			return nil
+		}
+	}
+
+	return bosherr.Errorf("Expected signature '%s' to be made by one of trusted keys in '%s'", sigPath, v.trustedKeysDir)
+}
+
+// trustedKeys loads PEM encoded public keys and certificates from all files in trusted keys directory
+func (v FSVerifier) trustedKeys() ([]crypto.PublicKey, error) {
+	if len(v.trustedKeysDir) == 0 {
+		return nil, bosherr.Error("Expected trusted keys directory to be specified")
+	}
+
+	paths, err := v.fs.Glob(filepath.Join(v.trustedKeysDir, "*"))
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Listing trusted keys in '%s'", v.trustedKeysDir)
+	}
+
+	var keys []crypto.PublicKey
+
+	for _, path := range paths {
+		bytes, err := v.fs.ReadFile(path)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Reading trusted key '%s'", path)
+		}
+
+		fileKeys, err := ParsePublicKeys(bytes)
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Parsing trusted key '%s'", path)
+		}
+
+		keys = append(keys, fileKeys...)
+	}
+
+	if len(keys) == 0 {
+		return nil, bosherr.Errorf("Expected to find at least one trusted key in '%s'", v.trustedKeysDir)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,127 @@
+package signature_test
+
+import (
+	"crypto/sha1"
+	"fmt"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+	boshman "github.com/cloudfoundry/bosh-cli/release/manifest"
+	boshpkg "github.com/cloudfoundry/bosh-cli/release/pkg"
+	fakerel "github.com/cloudfoundry/bosh-cli/release/releasefakes"
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
+	. "github.com/cloudfoundry/bosh-cli/release/signature"
+)
+
+var _ = Describe("FSVerifier", func() {
+	var (
+		fs       *fakesys.FakeFileSystem
+		release  *fakerel.FakeRelease
+		privPEM  []byte
+		verifier FSVerifier
+	)
+
+	sha1Of := func(contents string) string {
+		return fmt.Sprintf("%x", sha1.Sum([]byte(contents)))
+	}
+
+	sign := func() {
+		payload, err := Payload(release)
+		Expect(err).ToNot(HaveOccurred())
+
+		sig, err := Sign(payload, privPEM)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(sig.Write("/rel.tgz.sig", fs)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		fs = fakesys.NewFakeFileSystem()
+
+		var pubPEM []byte
+		privPEM, pubPEM = ed25519KeyPEMs()
+
+		fs.WriteFile("/keys/key.pem", pubPEM)
+		fs.WriteFileString("/keys/README", "")
+		fs.SetGlob("/keys/*", []string{"/keys/README", "/keys/key.pem"})
+
+		fs.WriteFileString("/extracted/web.tgz", "web")
+		fs.WriteFileString("/extracted/app.tgz", "app")
+
+		release = &fakerel.FakeRelease{}
+		release.JobsReturns([]*boshjob.Job{
+			boshjob.NewJob(boshres.NewResourceWithBuiltArchive("web", "web-fp", "/extracted/web.tgz", sha1Of("web"))),
+		})
+		release.PackagesReturns([]*boshpkg.Package{
+			boshpkg.NewPackage(boshres.NewResourceWithBuiltArchive("app", "app-fp", "/extracted/app.tgz", sha1Of("app")), nil),
+		})
+		release.ManifestReturns(boshman.Manifest{
+			Name:     "rel",
+			Version:  "1",
+			Jobs:     []boshman.JobRef{{Name: "web", Fingerprint: "web-fp", SHA1: sha1Of("web")}},
+			Packages: []boshman.PackageRef{{Name: "app", Fingerprint: "app-fp", SHA1: sha1Of("app")}},
+		})
+
+		verifier = NewFSVerifier("/keys", fs)
+	})
+
+	It("succeeds if release is signed by trusted key", func() {
+		sign()
+
+		err := verifier.Verify("/rel.tgz", release)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("returns error if release is not signed", func() {
+		err := verifier.Verify("/rel.tgz", release)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected release '/rel.tgz' to be signed but signature '/rel.tgz.sig' does not exist"))
+	})
+
+	It("returns error if release is signed by untrusted key", func() {
+		privPEM, _ = ed25519KeyPEMs()
+		sign()
+
+		err := verifier.Verify("/rel.tgz", release)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected signature '/rel.tgz.sig' to be made by one of trusted keys in '/keys'"))
+	})
+
+	It("returns error if signed manifest was changed", func() {
+		sign()
+
+		release.ManifestReturns(boshman.Manifest{Name: "rel", Version: "2"})
+
+		err := verifier.Verify("/rel.tgz", release)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Expected signature '/rel.tgz.sig' to be made by one of trusted keys"))
+	})
+
+	It("returns error if archive contents do not match their digests", func() {
+		sign()
+
+		fs.WriteFileString("/extracted/app.tgz", "tampered")
+
+		err := verifier.Verify("/rel.tgz", release)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Verifying release '/rel.tgz' contents"))
+		Expect(err.Error()).To(ContainSubstring("Verifying package 'app'"))
+	})
+
+	It("returns error if trusted keys directory is not specified", func() {
+		err := NewFSVerifier("", fs).Verify("/rel.tgz", release)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected trusted keys directory to be specified"))
+	})
+
+	It("returns error if trusted keys directory does not include keys", func() {
+		fs.SetGlob("/keys/*", []string{"/keys/README"})
+
+		err := verifier.Verify("/rel.tgz", release)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("Expected to find at least one trusted key in '/keys'"))
+	})
+})
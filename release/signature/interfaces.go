@@ -0,0 +1,14 @@
+package signature
+
+import (
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+)
+
+//go:generate counterfeiter . Verifier
+
+type Verifier interface {
+	// Verify checks that release read from given path has a valid
+	// detached signature made by one of trusted keys and that
+	// its jobs and packages match digests included in the signature.
+	Verify(path string, release boshrel.Release) error
+}
@@ -0,0 +1,321 @@
+package signature
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	"golang.org/x/crypto/ed25519"
+	"gopkg.in/yaml.v2"
+
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
+)
+
+const (
+	AlgorithmEd25519     = "ed25519"
+	AlgorithmRSASHA256   = "rsa-sha256"
+	AlgorithmECDSASHA256 = "ecdsa-sha256"
+)
+
+/*
+---
+algorithm: ed25519
+signature: 4nL1Vn2i...
+*/
+
+type Signature struct {
+	Algorithm string `yaml:"algorithm"`
+	Value     string `yaml:"signature"` // base64 encoded
+}
+
+// Path returns default location of a detached signature for a release tarball
+func Path(releasePath string) string {
+	return releasePath + ".sig"
+}
+
+func Read(path string, fs boshsys.FileSystem) (Signature, error) {
+	var sig Signature
+
+	bytes, err := fs.ReadFile(path)
+	if err != nil {
+		return sig, bosherr.WrapErrorf(err, "Reading signature '%s'", path)
+	}
+
+	err = yaml.Unmarshal(bytes, &sig)
+	if err != nil {
+		return sig, bosherr.WrapErrorf(err, "Unmarshalling signature '%s'", path)
+	}
+
+	return sig, nil
+}
+
+func (s Signature) Write(path string, fs boshsys.FileSystem) error {
+	bytes, err := yaml.Marshal(s)
+	if err != nil {
+		return bosherr.WrapError(err, "Marshalling signature")
+	}
+
+	err = fs.WriteFile(path, bytes)
+	if err != nil {
+		return bosherr.WrapErrorf(err, "Writing signature '%s'", path)
+	}
+
+	return nil
+}
+
+// Payload is a release manifest which includes digests of all jobs, packages and license
+func Payload(release boshrel.Release) ([]byte, error) {
+	bytes, err := yaml.Marshal(release.Manifest())
+	if err != nil {
+		return nil, bosherr.WrapError(err, "Marshalling release manifest")
+	}
+
+	return bytes, nil
+}
+
+// VerifyArchives checks that all job, package and license archives match their digests
+func VerifyArchives(release boshrel.Release, fs boshsys.FileSystem) error {
+	for _, job := range release.Jobs() {
+		err := boshres.VerifyArchiveDigest(job.ArchivePath(), job.ArchiveDigest(), fs)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Verifying job '%s'", job.Name())
+		}
+	}
+
+	for _, pkg := range release.Packages() {
+		err := boshres.VerifyArchiveDigest(pkg.ArchivePath(), pkg.ArchiveDigest(), fs)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Verifying package '%s'", pkg.Name())
+		}
+	}
+
+	for _, compiledPkg := range release.CompiledPackages() {
+		err := boshres.VerifyArchiveDigest(compiledPkg.ArchivePath(), compiledPkg.ArchiveDigest(), fs)
+		if err != nil {
+			return bosherr.WrapErrorf(err, "Verifying compiled package '%s'", compiledPkg.Name())
+		}
+	}
+
+	if lic := release.License(); lic != nil {
+		err := boshres.VerifyArchiveDigest(lic.ArchivePath(), lic.ArchiveDigest(), fs)
+		if err != nil {
+			return bosherr.WrapError(err, "Verifying license")
+		}
+	}
+
+	return nil
+}
+
+// Sign supports Ed25519, RSA and ECDSA private keys in PKCS #8, PKCS #1 or SEC 1 PEM encoding
+func Sign(payload, privateKeyPEM []byte) (Signature, error) {
+	key, err := parsePrivateKey(privateKeyPEM)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	var sig Signature
+	var value []byte
+
+	digest := sha256.Sum256(payload)
+
+	switch key := key.(type) {
+	case ed25519.PrivateKey:
+		sig.Algorithm = AlgorithmEd25519
+		value = ed25519.Sign(key, payload)
+
+	case *rsa.PrivateKey:
+		sig.Algorithm = AlgorithmRSASHA256
+		value, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+
+	case *ecdsa.PrivateKey:
+		sig.Algorithm = AlgorithmECDSASHA256
+		var ecdsaSig ecdsaSignature
+
+		ecdsaSig.R, ecdsaSig.S, err = ecdsa.Sign(rand.Reader, key, digest[:])
+		if err == nil {
+			value, err = asn1.Marshal(ecdsaSig)
+		}
+
+	default:
+		return Signature{}, bosherr.Errorf("Expected private key to be Ed25519, RSA or ECDSA key")
+	}
+
+	if err != nil {
+		return Signature{}, bosherr.WrapError(err, "Signing payload")
+	}
+
+	sig.Value = base64.StdEncoding.EncodeToString(value)
+
+	return sig, nil
+}
+
+// Verify returns true if signature was made by a private key matching given public key
+func (s Signature) Verify(payload []byte, publicKey crypto.PublicKey) bool {
+	value, err := base64.StdEncoding.DecodeString(s.Value)
+	if err != nil {
+		return false
+	}
+
+	digest := sha256.Sum256(payload)
+
+	switch key := publicKey.(type) {
+	case ed25519.PublicKey:
+		return s.Algorithm == AlgorithmEd25519 && ed25519.Verify(key, payload, value)
+
+	case *rsa.PublicKey:
+		return s.Algorithm == AlgorithmRSASHA256 && rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], value) == nil
+
+	case *ecdsa.PublicKey:
+		var ecdsaSig ecdsaSignature
+
+		rest, err := asn1.Unmarshal(value, &ecdsaSig)
+		if err != nil || len(rest) > 0 || ecdsaSig.R == nil || ecdsaSig.S == nil {
+			return false
+		}
+
+		return s.Algorithm == AlgorithmECDSASHA256 && ecdsa.Verify(key, digest[:], ecdsaSig.R, ecdsaSig.S)
+
+	default:
+		return false
+	}
+}
+
+func parsePrivateKey(bytes []byte) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode(bytes)
+	if block == nil {
+		return nil, bosherr.Error("Expected private key to be PEM encoded")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := parsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, bosherr.WrapError(err, "Parsing PKCS #8 private key")
+		}
+		return key, nil
+
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, bosherr.WrapError(err, "Parsing RSA private key")
+		}
+		return key, nil
+
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, bosherr.WrapError(err, "Parsing EC private key")
+		}
+		return key, nil
+
+	default:
+		return nil, bosherr.Errorf("Expected private key PEM block type '%s' to be a known private key type", block.Type)
+	}
+}
+
+// ParsePublicKeys returns public keys and certificate keys found in PEM encoded bytes
+func ParsePublicKeys(bytes []byte) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+
+	for {
+		var block *pem.Block
+
+		block, bytes = pem.Decode(bytes)
+		if block == nil {
+			return keys, nil
+		}
+
+		switch block.Type {
+		case "PUBLIC KEY":
+			key, err := parsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, bosherr.WrapError(err, "Parsing public key")
+			}
+			keys = append(keys, key)
+
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, bosherr.WrapError(err, "Parsing certificate")
+			}
+
+			key, err := parsePKIXPublicKey(cert.RawSubjectPublicKeyInfo)
+			if err != nil {
+				return nil, bosherr.WrapError(err, "Parsing certificate public key")
+			}
+			keys = append(keys, key)
+		}
+	}
+}
+
+// oidEd25519 identifies Ed25519 keys (RFC 8410) which are not known to x509 package
+var oidEd25519 = asn1.ObjectIdentifier{1, 3, 101, 112}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+type pkcs8PrivateKey struct {
+	Version    int
+	Algorithm  pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+type pkixPublicKey struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+func parsePKCS8PrivateKey(der []byte) (crypto.PrivateKey, error) {
+	var privKey pkcs8PrivateKey
+
+	_, err := asn1.Unmarshal(der, &privKey)
+	if err != nil || !privKey.Algorithm.Algorithm.Equal(oidEd25519) {
+		return x509.ParsePKCS8PrivateKey(der)
+	}
+
+	var seed []byte
+
+	_, err = asn1.Unmarshal(privKey.PrivateKey, &seed)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(seed) != 32 {
+		return nil, bosherr.Errorf("Expected Ed25519 private key seed to be 32 bytes, found %d", len(seed))
+	}
+
+	_, key, err := ed25519.GenerateKey(bytes.NewReader(seed))
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func parsePKIXPublicKey(der []byte) (crypto.PublicKey, error) {
+	var pubKey pkixPublicKey
+
+	_, err := asn1.Unmarshal(der, &pubKey)
+	if err != nil || !pubKey.Algorithm.Algorithm.Equal(oidEd25519) {
+		return x509.ParsePKIXPublicKey(der)
+	}
+
+	if len(pubKey.PublicKey.Bytes) != ed25519.PublicKeySize {
+		return nil, bosherr.Errorf("Expected Ed25519 public key to be %d bytes, found %d", ed25519.PublicKeySize, len(pubKey.PublicKey.Bytes))
+	}
+
+	return ed25519.PublicKey(pubKey.PublicKey.Bytes), nil
+}
@@ -0,0 +1,178 @@
+package signature_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ed25519"
+
+	. "github.com/cloudfoundry/bosh-cli/release/signature"
+)
+
+func pemEncode(type_ string, bytes []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: type_, Bytes: bytes})
+}
+
+func ed25519KeyPEMs() ([]byte, []byte) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+
+	algorithm := pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 3, 101, 112}}
+
+	seedBytes, err := asn1.Marshal(priv[:32])
+	Expect(err).ToNot(HaveOccurred())
+
+	privBytes, err := asn1.Marshal(struct {
+		Version    int
+		Algorithm  pkix.AlgorithmIdentifier
+		PrivateKey []byte
+	}{Algorithm: algorithm, PrivateKey: seedBytes})
+	Expect(err).ToNot(HaveOccurred())
+
+	pubBytes, err := asn1.Marshal(struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}{Algorithm: algorithm, PublicKey: asn1.BitString{Bytes: pub, BitLength: 8 * len(pub)}})
+	Expect(err).ToNot(HaveOccurred())
+
+	return pemEncode("PRIVATE KEY", privBytes), pemEncode("PUBLIC KEY", pubBytes)
+}
+
+var _ = Describe("Signature", func() {
+	payload := []byte("name: rel\n")
+
+	parseKey := func(pubPEM []byte) crypto.PublicKey {
+		keys, err := ParsePublicKeys(pubPEM)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(keys).To(HaveLen(1))
+		return keys[0]
+	}
+
+	Describe("Sign and Verify", func() {
+		It("signs with Ed25519 private key", func() {
+			privPEM, pubPEM := ed25519KeyPEMs()
+
+			sig, err := Sign(payload, privPEM)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sig.Algorithm).To(Equal("ed25519"))
+
+			Expect(sig.Verify(payload, parseKey(pubPEM))).To(BeTrue())
+			Expect(sig.Verify([]byte("name: other\n"), parseKey(pubPEM))).To(BeFalse())
+
+			_, otherPubPEM := ed25519KeyPEMs()
+			Expect(sig.Verify(payload, parseKey(otherPubPEM))).To(BeFalse())
+		})
+
+		It("signs with RSA private key", func() {
+			priv, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).ToNot(HaveOccurred())
+
+			sig, err := Sign(payload, pemEncode("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(priv)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sig.Algorithm).To(Equal("rsa-sha256"))
+
+			Expect(sig.Verify(payload, &priv.PublicKey)).To(BeTrue())
+			Expect(sig.Verify([]byte("name: other\n"), &priv.PublicKey)).To(BeFalse())
+		})
+
+		It("signs with ECDSA private key", func() {
+			priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+
+			privBytes, err := x509.MarshalECPrivateKey(priv)
+			Expect(err).ToNot(HaveOccurred())
+
+			sig, err := Sign(payload, pemEncode("EC PRIVATE KEY", privBytes))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sig.Algorithm).To(Equal("ecdsa-sha256"))
+
+			Expect(sig.Verify(payload, &priv.PublicKey)).To(BeTrue())
+			Expect(sig.Verify([]byte("name: other\n"), &priv.PublicKey)).To(BeFalse())
+
+			sig.Value = "c2ln"
+			Expect(sig.Verify(payload, &priv.PublicKey)).To(BeFalse())
+		})
+
+		It("does not verify signature with mismatching algorithm", func() {
+			privPEM, pubPEM := ed25519KeyPEMs()
+
+			sig, err := Sign(payload, privPEM)
+			Expect(err).ToNot(HaveOccurred())
+
+			sig.Algorithm = "rsa-sha256"
+			Expect(sig.Verify(payload, parseKey(pubPEM))).To(BeFalse())
+		})
+
+		It("returns error if private key is not PEM encoded", func() {
+			_, err := Sign(payload, []byte("key"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected private key to be PEM encoded"))
+		})
+
+		It("returns error if PEM block is not a private key", func() {
+			_, pubPEM := ed25519KeyPEMs()
+
+			_, err := Sign(payload, pubPEM)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected private key PEM block type 'PUBLIC KEY' to be a known private key type"))
+		})
+	})
+
+	Describe("ParsePublicKeys", func() {
+		It("returns public keys and certificate keys", func() {
+			priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+
+			tpl := &x509.Certificate{
+				SerialNumber: big.NewInt(1),
+				Subject:      pkix.Name{CommonName: "release-signer"},
+				NotBefore:    time.Now(),
+				NotAfter:     time.Now().Add(time.Hour),
+			}
+
+			certBytes, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &priv.PublicKey, priv)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, pubPEM := ed25519KeyPEMs()
+
+			keys, err := ParsePublicKeys(append(append(pubPEM, pemEncode("CERTIFICATE", certBytes)...), pemEncode("OTHER", nil)...))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(HaveLen(2))
+			Expect(keys[0]).To(BeAssignableToTypeOf(ed25519.PublicKey{}))
+			Expect(keys[1]).To(Equal(&priv.PublicKey))
+		})
+
+		It("returns error if public key cannot be parsed", func() {
+			_, err := ParsePublicKeys(pemEncode("PUBLIC KEY", []byte("invalid")))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Parsing public key"))
+		})
+	})
+
+	Describe("Read and Write", func() {
+		It("round trips signature through a file", func() {
+			fs := fakesys.NewFakeFileSystem()
+
+			err := Signature{Algorithm: "ed25519", Value: "c2ln"}.Write("/rel.tgz.sig", fs)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fs.ReadFileString("/rel.tgz.sig")).To(Equal("algorithm: ed25519\nsignature: c2ln\n"))
+
+			sig, err := Read("/rel.tgz.sig", fs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sig).To(Equal(Signature{Algorithm: "ed25519", Value: "c2ln"}))
+		})
+	})
+})
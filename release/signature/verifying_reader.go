@@ -0,0 +1,30 @@
+package signature
+
+import (
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+)
+
+// VerifyingReader only returns releases that pass signature verification
+type VerifyingReader struct {
+	reader   boshrel.Reader
+	verifier Verifier
+}
+
+func NewVerifyingReader(reader boshrel.Reader, verifier Verifier) VerifyingReader {
+	return VerifyingReader{reader: reader, verifier: verifier}
+}
+
+func (r VerifyingReader) Read(path string) (boshrel.Release, error) {
+	release, err := r.reader.Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.verifier.Verify(path, release)
+	if err != nil {
+		release.CleanUp()
+		return nil, err
+	}
+
+	return release, nil
+}
@@ -0,0 +1,58 @@
+package signature_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	fakerel "github.com/cloudfoundry/bosh-cli/release/releasefakes"
+	. "github.com/cloudfoundry/bosh-cli/release/signature"
+	fakesig "github.com/cloudfoundry/bosh-cli/release/signature/signaturefakes"
+)
+
+var _ = Describe("VerifyingReader", func() {
+	var (
+		innerReader *fakerel.FakeReader
+		verifier    *fakesig.FakeVerifier
+		release     *fakerel.FakeRelease
+		reader      VerifyingReader
+	)
+
+	BeforeEach(func() {
+		innerReader = &fakerel.FakeReader{}
+		verifier = &fakesig.FakeVerifier{}
+		release = &fakerel.FakeRelease{}
+		innerReader.ReadReturns(release, nil)
+		reader = NewVerifyingReader(innerReader, verifier)
+	})
+
+	It("returns verified release", func() {
+		rel, err := reader.Read("/rel.tgz")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rel).To(Equal(boshrel.Release(release)))
+
+		path, verifiedRel := verifier.VerifyArgsForCall(0)
+		Expect(path).To(Equal("/rel.tgz"))
+		Expect(verifiedRel).To(Equal(boshrel.Release(release)))
+	})
+
+	It("cleans up and returns error if verification fails", func() {
+		verifier.VerifyReturns(errors.New("fake-err"))
+
+		_, err := reader.Read("/rel.tgz")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("fake-err"))
+
+		Expect(release.CleanUpCallCount()).To(Equal(1))
+	})
+
+	It("returns error if reading fails", func() {
+		innerReader.ReadReturns(nil, errors.New("fake-err"))
+
+		_, err := reader.Read("/rel.tgz")
+		Expect(err).To(HaveOccurred())
+		Expect(verifier.VerifyCallCount()).To(Equal(0))
+	})
+})
@@ -0,0 +1,128 @@
+package releasedir
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+	semver "github.com/cppforlife/go-semi-semantic/version"
+
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+)
+
+type FSFingerprintExplainer struct {
+	explainer boshrel.FingerprintExplainer
+
+	config        Config
+	devReleases   ReleaseIndex
+	finalReleases ReleaseIndex
+	releaseReader boshrel.Reader
+
+	fs boshsys.FileSystem
+}
+
+func NewFSFingerprintExplainer(
+	explainer boshrel.FingerprintExplainer,
+	config Config,
+	devReleases ReleaseIndex,
+	finalReleases ReleaseIndex,
+	releaseReader boshrel.Reader,
+	fs boshsys.FileSystem,
+) FSFingerprintExplainer {
+	return FSFingerprintExplainer{
+		explainer: explainer,
+
+		config:        config,
+		devReleases:   devReleases,
+		finalReleases: finalReleases,
+		releaseReader: releaseReader,
+
+		fs: fs,
+	}
+}
+
+func (e FSFingerprintExplainer) ExplainJob(name string, version semver.Version) (FingerprintExplanation, error) {
+	expl := FingerprintExplanation{Name: name}
+
+	if version.Empty() {
+		fp, entries, err := e.explainer.DirJob(name)
+		if err != nil {
+			return expl, err
+		}
+
+		expl.Fingerprint = fp
+		expl.Entries = entries
+
+		return expl, nil
+	}
+
+	release, err := e.readRelease(version)
+	if err != nil {
+		return expl, err
+	}
+
+	defer release.CleanUp()
+
+	for _, job := range release.Jobs() {
+		if job.Name() == name {
+			expl.Fingerprint = job.Fingerprint()
+			expl.Entries, err = e.explainer.ArchivedJob(job)
+			return expl, err
+		}
+	}
+
+	return expl, bosherr.Errorf("Expected to find job '%s' in release version '%s'", name, version.AsString())
+}
+
+func (e FSFingerprintExplainer) ExplainPackage(name string, version semver.Version) (FingerprintExplanation, error) {
+	expl := FingerprintExplanation{Name: name}
+
+	if version.Empty() {
+		fp, entries, err := e.explainer.DirPackage(name)
+		if err != nil {
+			return expl, err
+		}
+
+		expl.Fingerprint = fp
+		expl.Entries = entries
+
+		return expl, nil
+	}
+
+	release, err := e.readRelease(version)
+	if err != nil {
+		return expl, err
+	}
+
+	defer release.CleanUp()
+
+	for _, pkg := range release.Packages() {
+		if pkg.Name() == name {
+			expl.Fingerprint = pkg.Fingerprint()
+			expl.Entries, err = e.explainer.ArchivedPackage(pkg)
+			return expl, err
+		}
+	}
+
+	return expl, bosherr.Errorf("Expected to find package '%s' in release version '%s'", name, version.AsString())
+}
+
+func (e FSFingerprintExplainer) readRelease(version semver.Version) (boshrel.Release, error) {
+	name, err := e.config.Name()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, relIndex := range []ReleaseIndex{e.devReleases, e.finalReleases} {
+		manifestPath := relIndex.ManifestPath(name, version.AsString())
+
+		if e.fs.FileExists(manifestPath) {
+			release, err := e.releaseReader.Read(manifestPath)
+			if err != nil {
+				return nil, bosherr.WrapErrorf(err, "Reading release version '%s'", version.AsString())
+			}
+
+			return release, nil
+		}
+	}
+
+	return nil, bosherr.Errorf("Expected to find dev or final release version '%s'", version.AsString())
+}
@@ -0,0 +1,177 @@
+package releasedir_test
+
+import (
+	"errors"
+
+	fakefu "github.com/cloudfoundry/bosh-utils/fileutil/fakes"
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	semver "github.com/cppforlife/go-semi-semantic/version"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	fakecrypto "github.com/cloudfoundry/bosh-cli/crypto/fakes"
+	boshrel "github.com/cloudfoundry/bosh-cli/release"
+	boshjob "github.com/cloudfoundry/bosh-cli/release/job"
+	boshpkg "github.com/cloudfoundry/bosh-cli/release/pkg"
+	fakerel "github.com/cloudfoundry/bosh-cli/release/releasefakes"
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
+	. "github.com/cloudfoundry/bosh-cli/releasedir"
+	fakereldir "github.com/cloudfoundry/bosh-cli/releasedir/releasedirfakes"
+)
+
+var _ = Describe("FSFingerprintExplainer", func() {
+	var (
+		config           *fakereldir.FakeConfig
+		devReleases      *fakereldir.FakeReleaseIndex
+		finalReleases    *fakereldir.FakeReleaseIndex
+		releaseReader    *fakerel.FakeReader
+		compressor       *fakefu.FakeCompressor
+		digestCalculator *fakecrypto.FakeDigestCalculator
+		fs               *fakesys.FakeFileSystem
+		explainer        FSFingerprintExplainer
+	)
+
+	BeforeEach(func() {
+		config = &fakereldir.FakeConfig{}
+		config.NameReturns("rel", nil)
+
+		devReleases = &fakereldir.FakeReleaseIndex{}
+		devReleases.ManifestPathStub = func(name, ver string) string { return "/dir/dev_releases/" + name + "-" + ver + ".yml" }
+
+		finalReleases = &fakereldir.FakeReleaseIndex{}
+		finalReleases.ManifestPathStub = func(name, ver string) string { return "/dir/releases/" + name + "-" + ver + ".yml" }
+
+		releaseReader = &fakerel.FakeReader{}
+		compressor = fakefu.NewFakeCompressor()
+		digestCalculator = fakecrypto.NewFakeDigestCalculator()
+		fs = fakesys.NewFakeFileSystem()
+
+		relExplainer := boshrel.NewFingerprintExplainer("/dir", compressor, digestCalculator, fs)
+		explainer = NewFSFingerprintExplainer(relExplainer, config, devReleases, finalReleases, releaseReader, fs)
+	})
+
+	Describe("ExplainJob", func() {
+		Context("when version is not specified", func() {
+			BeforeEach(func() {
+				fs.WriteFileString("/dir/jobs/job/spec", "name: job\ntemplates: {ctl.erb: bin/ctl}")
+				fs.WriteFileString("/dir/jobs/job/monit", "")
+				fs.WriteFileString("/dir/jobs/job/templates/ctl.erb", "")
+
+				digestCalculator.SetCalculateBehavior(map[string]fakecrypto.CalculateInput{
+					"/dir/jobs/job/spec":              {DigestStr: "spec-sha1"},
+					"/dir/jobs/job/monit":             {DigestStr: "monit-sha1"},
+					"/dir/jobs/job/templates/ctl.erb": {DigestStr: "ctl-sha1"},
+				})
+				digestCalculator.CalculateStringInputs = map[string]string{
+					"v2job.MFspec-sha1100644monitmonit-sha1100644templates/ctl.erbctl-sha1100644": "jobfp",
+				}
+			})
+
+			It("returns entries for job in release directory", func() {
+				expl, err := explainer.ExplainJob("job", semver.Version{})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(expl).To(Equal(FingerprintExplanation{
+					Name:        "job",
+					Fingerprint: "jobfp",
+					Entries: []boshres.FingerprintEntry{
+						{Name: "job.MF", Digest: "spec-sha1", Mode: "100644"},
+						{Name: "monit", Digest: "monit-sha1", Mode: "100644"},
+						{Name: "templates/ctl.erb", Digest: "ctl-sha1", Mode: "100644"},
+					},
+				}))
+				Expect(releaseReader.ReadCallCount()).To(Equal(0))
+			})
+		})
+
+		It("returns error if job cannot be found in given release version", func() {
+			fs.WriteFileString("/dir/releases/rel-1.yml", "")
+			releaseReader.ReadReturns(&fakerel.FakeRelease{}, nil)
+
+			_, err := explainer.ExplainJob("job", semver.MustNewVersionFromString("1"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected to find job 'job' in release version '1'"))
+		})
+	})
+
+	Describe("ExplainPackage", func() {
+		var (
+			release *fakerel.FakeRelease
+		)
+
+		BeforeEach(func() {
+			fs.TempDirDir = "/extracted"
+
+			pkg := boshpkg.NewPackage(boshres.NewResourceWithBuiltArchive("pkg", "pkg-fp", "/pkg.tgz", "sha1"), []string{"dep"})
+			release = &fakerel.FakeRelease{}
+			release.PackagesReturns([]*boshpkg.Package{pkg})
+			release.JobsReturns([]*boshjob.Job{})
+
+			compressor.DecompressFileToDirCallBack = func() {
+				fs.WriteFileString("/extracted/packaging", "")
+				fs.WriteFileString("/extracted/pkg/file", "")
+			}
+
+			digestCalculator.SetCalculateBehavior(map[string]fakecrypto.CalculateInput{
+				"/extracted/packaging": {DigestStr: "packaging-sha1"},
+				"/extracted/pkg/file":  {DigestStr: "file-sha1"},
+			})
+		})
+
+		It("returns entries for package extracted from dev release version", func() {
+			fs.WriteFileString("/dir/dev_releases/rel-1+dev.2.yml", "")
+			releaseReader.ReadReturns(release, nil)
+
+			expl, err := explainer.ExplainPackage("pkg", semver.MustNewVersionFromString("1+dev.2"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(expl).To(Equal(FingerprintExplanation{
+				Name:        "pkg",
+				Fingerprint: "pkg-fp",
+				Entries: []boshres.FingerprintEntry{
+					{Name: "packaging", Digest: "packaging-sha1"},
+					{Name: "pkg/file", Digest: "file-sha1", Mode: "100644"},
+					{Name: "dep", AdditionalChunks: true},
+				},
+			}))
+
+			Expect(releaseReader.ReadArgsForCall(0)).To(Equal("/dir/dev_releases/rel-1+dev.2.yml"))
+			Expect(compressor.DecompressFileToDirTarballPaths).To(Equal([]string{"/pkg.tgz"}))
+			Expect(release.CleanUpCallCount()).To(Equal(1))
+			Expect(fs.FileExists("/extracted")).To(BeFalse())
+		})
+
+		It("reads final release version if dev release version does not exist", func() {
+			fs.WriteFileString("/dir/releases/rel-1.yml", "")
+			releaseReader.ReadReturns(release, nil)
+
+			_, err := explainer.ExplainPackage("pkg", semver.MustNewVersionFromString("1"))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(releaseReader.ReadArgsForCall(0)).To(Equal("/dir/releases/rel-1.yml"))
+		})
+
+		It("returns error if release version cannot be found", func() {
+			_, err := explainer.ExplainPackage("pkg", semver.MustNewVersionFromString("1"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected to find dev or final release version '1'"))
+		})
+
+		It("returns error if reading release version fails", func() {
+			fs.WriteFileString("/dir/releases/rel-1.yml", "")
+			releaseReader.ReadReturns(nil, errors.New("fake-err"))
+
+			_, err := explainer.ExplainPackage("pkg", semver.MustNewVersionFromString("1"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+
+		It("returns error if extracting package fails", func() {
+			fs.WriteFileString("/dir/releases/rel-1.yml", "")
+			releaseReader.ReadReturns(release, nil)
+			compressor.DecompressFileToDirErr = errors.New("fake-err")
+
+			_, err := explainer.ExplainPackage("pkg", semver.MustNewVersionFromString("1"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+})
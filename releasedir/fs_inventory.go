@@ -0,0 +1,54 @@
+package releasedir
+
+import (
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+
+	boshidx "github.com/cloudfoundry/bosh-cli/releasedir/index"
+)
+
+type FSInventory struct {
+	config   Config
+	blobsDir BlobsDir
+
+	devPackages   boshidx.Index
+	finalPackages boshidx.Index
+}
+
+func NewFSInventory(
+	config Config,
+	blobsDir BlobsDir,
+	devPackages boshidx.Index,
+	finalPackages boshidx.Index,
+) FSInventory {
+	return FSInventory{
+		config:   config,
+		blobsDir: blobsDir,
+
+		devPackages:   devPackages,
+		finalPackages: finalPackages,
+	}
+}
+
+func (i FSInventory) Name() (string, error) {
+	return i.config.Name()
+}
+
+func (i FSInventory) Blobs() ([]Blob, error) {
+	return i.blobsDir.Blobs()
+}
+
+func (i FSInventory) PackageDigest(name, fingerprint string) (string, error) {
+	digest, err := i.finalPackages.FindDigest(name, fingerprint)
+	if err != nil {
+		return "", bosherr.WrapErrorf(err, "Finding final package '%s/%s'", name, fingerprint)
+	} else if len(digest) > 0 {
+		return digest, nil
+	}
+
+	digest, err = i.devPackages.FindDigest(name, fingerprint)
+	if err != nil {
+		return "", bosherr.WrapErrorf(err, "Finding dev package '%s/%s'", name, fingerprint)
+	}
+
+	return digest, nil
+}
@@ -0,0 +1,82 @@
+package releasedir_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/releasedir"
+	fakeidx "github.com/cloudfoundry/bosh-cli/releasedir/index/indexfakes"
+	fakereldir "github.com/cloudfoundry/bosh-cli/releasedir/releasedirfakes"
+)
+
+var _ = Describe("FSInventory", func() {
+	var (
+		config        *fakereldir.FakeConfig
+		blobsDir      *fakereldir.FakeBlobsDir
+		devPackages   *fakeidx.FakeIndex
+		finalPackages *fakeidx.FakeIndex
+		inventory     FSInventory
+	)
+
+	BeforeEach(func() {
+		config = &fakereldir.FakeConfig{}
+		blobsDir = &fakereldir.FakeBlobsDir{}
+		devPackages = &fakeidx.FakeIndex{}
+		finalPackages = &fakeidx.FakeIndex{}
+		inventory = NewFSInventory(config, blobsDir, devPackages, finalPackages)
+	})
+
+	Describe("Name", func() {
+		It("returns name from config", func() {
+			config.NameReturns("rel", nil)
+			Expect(inventory.Name()).To(Equal("rel"))
+		})
+	})
+
+	Describe("Blobs", func() {
+		It("returns blobs from blobs directory", func() {
+			blobsDir.BlobsReturns([]Blob{{Path: "src.tgz", SHA1: "sha1"}}, nil)
+			Expect(inventory.Blobs()).To(Equal([]Blob{{Path: "src.tgz", SHA1: "sha1"}}))
+		})
+	})
+
+	Describe("PackageDigest", func() {
+		It("returns digest from final index", func() {
+			finalPackages.FindDigestReturns("final-sha1", nil)
+
+			digest, err := inventory.PackageDigest("pkg", "fp")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(digest).To(Equal("final-sha1"))
+
+			name, fp := finalPackages.FindDigestArgsForCall(0)
+			Expect(name).To(Equal("pkg"))
+			Expect(fp).To(Equal("fp"))
+
+			Expect(devPackages.FindDigestCallCount()).To(Equal(0))
+		})
+
+		It("returns digest from dev index if package is not found in final index", func() {
+			devPackages.FindDigestReturns("dev-sha1", nil)
+
+			digest, err := inventory.PackageDigest("pkg", "fp")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(digest).To(Equal("dev-sha1"))
+		})
+
+		It("returns empty digest if package was never built", func() {
+			digest, err := inventory.PackageDigest("pkg", "fp")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(digest).To(BeEmpty())
+		})
+
+		It("returns error if finding in index fails", func() {
+			devPackages.FindDigestReturns("", errors.New("fake-err"))
+
+			_, err := inventory.PackageDigest("pkg", "fp")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+	})
+})
@@ -0,0 +1,442 @@
+package releasedir
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bmatcuk/doublestar"
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshsys "github.com/cloudfoundry/bosh-utils/system"
+
+	boshjobman "github.com/cloudfoundry/bosh-cli/release/job/manifest"
+	boshpkgman "github.com/cloudfoundry/bosh-cli/release/pkg/manifest"
+)
+
+const (
+	LintSeverityError   = "error"
+	LintSeverityWarning = "warning"
+
+	// Files above this size are expected to be tracked as blobs
+	lintMaxSrcFileSize = 10 * 1024 * 1024
+)
+
+var (
+	lintMonitProcessRegexp = regexp.MustCompile(`^\s*check\s+process\s+(\S+)`)
+	lintMonitProgramRegexp = regexp.MustCompile(`^\s*(?:start|stop)\s+program\s*=?\s*"([^"\s]+)`)
+)
+
+type FSLinter struct {
+	dirPath  string
+	blobsDir BlobsDir
+	fs       boshsys.FileSystem
+}
+
+type lintJob struct {
+	dirPath  string
+	manifest boshjobman.Manifest
+}
+
+func NewFSLinter(dirPath string, blobsDir BlobsDir, fs boshsys.FileSystem) FSLinter {
+	return FSLinter{dirPath: dirPath, blobsDir: blobsDir, fs: fs}
+}
+
+func (l FSLinter) Lint() ([]LintProblem, error) {
+	jobs, err := l.readJobs()
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs, err := l.readPackages()
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []LintProblem
+
+	for _, job := range jobs {
+		jobProblems, err := l.lintJob(job, pkgs)
+		if err != nil {
+			return nil, err
+		}
+
+		problems = append(problems, jobProblems...)
+	}
+
+	problems = append(problems, l.lintPackages(jobs, pkgs)...)
+
+	blobProblems, err := l.lintBlobs(pkgs)
+	if err != nil {
+		return nil, err
+	}
+
+	problems = append(problems, blobProblems...)
+
+	srcProblems, err := l.lintSrc()
+	if err != nil {
+		return nil, err
+	}
+
+	problems = append(problems, srcProblems...)
+
+	// Show errors before warnings
+	var errs, warnings []LintProblem
+
+	for _, problem := range problems {
+		if problem.Severity == LintSeverityError {
+			errs = append(errs, problem)
+		} else {
+			warnings = append(warnings, problem)
+		}
+	}
+
+	return append(errs, warnings...), nil
+}
+
+func (l FSLinter) readJobs() ([]lintJob, error) {
+	specPaths, err := l.fs.Glob(filepath.Join(l.dirPath, "jobs", "*", "spec"))
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Finding jobs")
+	}
+
+	sort.Strings(specPaths)
+
+	var jobs []lintJob
+
+	for _, specPath := range specPaths {
+		manifest, err := boshjobman.NewManifestFromPath(specPath, l.fs)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, lintJob{dirPath: filepath.Dir(specPath), manifest: manifest})
+	}
+
+	return jobs, nil
+}
+
+func (l FSLinter) readPackages() (map[string]boshpkgman.Manifest, error) {
+	specPaths, err := l.fs.Glob(filepath.Join(l.dirPath, "packages", "*", "spec"))
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Finding packages")
+	}
+
+	pkgs := map[string]boshpkgman.Manifest{}
+
+	for _, specPath := range specPaths {
+		manifest, err := boshpkgman.NewManifestFromPath(specPath, l.fs)
+		if err != nil {
+			return nil, err
+		}
+
+		pkgs[manifest.Name] = manifest
+	}
+
+	return pkgs, nil
+}
+
+func (l FSLinter) lintJob(job lintJob, pkgs map[string]boshpkgman.Manifest) ([]LintProblem, error) {
+	var problems []LintProblem
+
+	subject := "jobs/" + job.manifest.Name
+	templatesPath := filepath.Join(job.dirPath, "templates")
+
+	for _, src := range l.sortedKeys(job.manifest.Templates) {
+		if !l.fs.FileExists(filepath.Join(templatesPath, src)) {
+			problems = append(problems, LintProblem{
+				Severity: LintSeverityError,
+				Check:    "missing-template",
+				Subject:  subject,
+				Message:  fmt.Sprintf("Template '%s' is listed in spec but does not exist", src),
+			})
+		}
+	}
+
+	if l.fs.FileExists(templatesPath) {
+		err := l.fs.Walk(templatesPath, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if info.IsDir() {
+				return nil
+			}
+
+			src, err := filepath.Rel(templatesPath, path)
+			if err != nil {
+				return err
+			}
+
+			if _, found := job.manifest.Templates[filepath.ToSlash(src)]; !found {
+				problems = append(problems, LintProblem{
+					Severity: LintSeverityWarning,
+					Check:    "unused-template",
+					Subject:  subject,
+					Message:  fmt.Sprintf("Template '%s' exists but is not listed in spec", filepath.ToSlash(src)),
+				})
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, bosherr.WrapErrorf(err, "Listing job '%s' templates", job.manifest.Name)
+		}
+	}
+
+	for _, pkgName := range job.manifest.Packages {
+		if _, found := pkgs[pkgName]; !found {
+			problems = append(problems, LintProblem{
+				Severity: LintSeverityError,
+				Check:    "missing-package",
+				Subject:  subject,
+				Message:  fmt.Sprintf("Package '%s' is listed in spec but does not exist", pkgName),
+			})
+		}
+	}
+
+	monitProblems, err := l.lintMonit(job)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(problems, monitProblems...), nil
+}
+
+func (l FSLinter) lintMonit(job lintJob) ([]LintProblem, error) {
+	monitPath := filepath.Join(job.dirPath, "monit")
+
+	if !l.fs.FileExists(monitPath) {
+		return nil, nil
+	}
+
+	contents, err := l.fs.ReadFileString(monitPath)
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Reading job '%s' monit file", job.manifest.Name)
+	}
+
+	var (
+		problems  []LintProblem
+		processes int
+		process   string
+		nonEmpty  bool
+	)
+
+	subject := "jobs/" + job.manifest.Name
+
+	renderedPaths := map[string]struct{}{}
+
+	for _, dst := range job.manifest.Templates {
+		renderedPaths[filepath.ToSlash(dst)] = struct{}{}
+	}
+
+	jobPathPrefix := "/var/vcap/jobs/" + job.manifest.Name + "/"
+
+	scanner := bufio.NewScanner(strings.NewReader(contents))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		nonEmpty = true
+
+		if matches := lintMonitProcessRegexp.FindStringSubmatch(line); matches != nil {
+			processes++
+			process = matches[1]
+			continue
+		}
+
+		if matches := lintMonitProgramRegexp.FindStringSubmatch(line); matches != nil {
+			program := matches[1]
+
+			// Dynamic paths cannot be checked without rendering
+			if strings.Contains(program, "<%") || !strings.HasPrefix(program, jobPathPrefix) {
+				continue
+			}
+
+			if _, found := renderedPaths[strings.TrimPrefix(program, jobPathPrefix)]; !found {
+				problems = append(problems, LintProblem{
+					Severity: LintSeverityWarning,
+					Check:    "monit-process-mismatch",
+					Subject:  subject,
+					Message:  fmt.Sprintf("Monit process '%s' uses '%s' which is not rendered by any template", process, program),
+				})
+			}
+		}
+	}
+
+	if nonEmpty && processes == 0 {
+		problems = append(problems, LintProblem{
+			Severity: LintSeverityWarning,
+			Check:    "monit-process-mismatch",
+			Subject:  subject,
+			Message:  "Monit file does not check any process",
+		})
+	}
+
+	return problems, nil
+}
+
+func (l FSLinter) lintPackages(jobs []lintJob, pkgs map[string]boshpkgman.Manifest) []LintProblem {
+	var problems []LintProblem
+
+	used := map[string]struct{}{}
+
+	var markUsed func(string)
+
+	markUsed = func(name string) {
+		if _, found := used[name]; found {
+			return
+		}
+
+		used[name] = struct{}{}
+
+		for _, depName := range pkgs[name].Dependencies {
+			markUsed(depName)
+		}
+	}
+
+	for _, job := range jobs {
+		for _, pkgName := range job.manifest.Packages {
+			markUsed(pkgName)
+		}
+	}
+
+	for _, name := range l.sortedPkgNames(pkgs) {
+		subject := "packages/" + name
+
+		for _, depName := range pkgs[name].Dependencies {
+			if _, found := pkgs[depName]; !found {
+				problems = append(problems, LintProblem{
+					Severity: LintSeverityError,
+					Check:    "missing-dependency",
+					Subject:  subject,
+					Message:  fmt.Sprintf("Dependency '%s' does not exist", depName),
+				})
+			}
+		}
+
+		if _, found := used[name]; !found {
+			problems = append(problems, LintProblem{
+				Severity: LintSeverityWarning,
+				Check:    "unused-package",
+				Subject:  subject,
+				Message:  "Package is not used by any job",
+			})
+		}
+	}
+
+	return problems
+}
+
+func (l FSLinter) lintBlobs(pkgs map[string]boshpkgman.Manifest) ([]LintProblem, error) {
+	if !l.fs.FileExists(filepath.Join(l.dirPath, "config", "blobs.yml")) {
+		return nil, nil
+	}
+
+	blobs, err := l.blobsDir.Blobs()
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []LintProblem
+
+	for _, blob := range blobs {
+		var referenced bool
+
+		for _, name := range l.sortedPkgNames(pkgs) {
+			for _, glob := range pkgs[name].Files {
+				if matched, _ := doublestar.Match(glob, blob.Path); matched {
+					referenced = true
+					break
+				}
+			}
+		}
+
+		if !referenced {
+			problems = append(problems, LintProblem{
+				Severity: LintSeverityWarning,
+				Check:    "unused-blob",
+				Subject:  "blobs/" + blob.Path,
+				Message:  "Blob is not referenced by any package files",
+			})
+		}
+	}
+
+	return problems, nil
+}
+
+func (l FSLinter) lintSrc() ([]LintProblem, error) {
+	srcPath := filepath.Join(l.dirPath, "src")
+
+	if !l.fs.FileExists(srcPath) {
+		return nil, nil
+	}
+
+	var problems []LintProblem
+
+	err := l.fs.Walk(srcPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			// Git submodules or vendored repositories may include their own metadata
+			if filepath.Base(path) == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if info.Size() > lintMaxSrcFileSize {
+			relPath, err := filepath.Rel(srcPath, path)
+			if err != nil {
+				return err
+			}
+
+			problems = append(problems, LintProblem{
+				Severity: LintSeverityWarning,
+				Check:    "large-src-file",
+				Subject:  "src/" + filepath.ToSlash(relPath),
+				Message:  fmt.Sprintf("File is %d bytes; consider tracking it as a blob", info.Size()),
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, bosherr.WrapErrorf(err, "Listing src files")
+	}
+
+	return problems, nil
+}
+
+func (l FSLinter) sortedKeys(m map[string]string) []string {
+	var keys []string
+
+	for key, _ := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (l FSLinter) sortedPkgNames(pkgs map[string]boshpkgman.Manifest) []string {
+	var names []string
+
+	for name, _ := range pkgs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,230 @@
+package releasedir_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	fakesys "github.com/cloudfoundry/bosh-utils/system/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/releasedir"
+	fakereldir "github.com/cloudfoundry/bosh-cli/releasedir/releasedirfakes"
+)
+
+var _ = Describe("FSLinter", func() {
+	var (
+		blobsDir *fakereldir.FakeBlobsDir
+		fs       *fakesys.FakeFileSystem
+		linter   FSLinter
+	)
+
+	BeforeEach(func() {
+		blobsDir = &fakereldir.FakeBlobsDir{}
+		fs = fakesys.NewFakeFileSystem()
+		linter = NewFSLinter("/dir", blobsDir, fs)
+
+		fs.SetGlob("/dir/jobs/*/spec", []string{"/dir/jobs/web/spec"})
+		fs.SetGlob("/dir/packages/*/spec", []string{"/dir/packages/app/spec", "/dir/packages/golang/spec"})
+
+		fs.WriteFileString("/dir/jobs/web/spec", `---
+name: web
+templates:
+  ctl.erb: bin/ctl
+  config.yml.erb: config/config.yml
+packages: [app]
+`)
+		fs.MkdirAll("/dir/jobs/web/templates", os.ModePerm)
+		fs.WriteFileString("/dir/jobs/web/templates/ctl.erb", "")
+		fs.WriteFileString("/dir/jobs/web/templates/config.yml.erb", "")
+		fs.WriteFileString("/dir/jobs/web/monit", `
+check process web
+  with pidfile /var/vcap/sys/run/web/pid
+  start program "/var/vcap/jobs/web/bin/ctl start"
+  stop program "/var/vcap/jobs/web/bin/ctl stop"
+`)
+
+		fs.WriteFileString("/dir/packages/app/spec", `---
+name: app
+dependencies: [golang]
+files: [app/**/*]
+`)
+		fs.WriteFileString("/dir/packages/golang/spec", `---
+name: golang
+files: [golang/go*.tar.gz]
+`)
+
+		fs.WriteFileString("/dir/config/blobs.yml", "")
+		blobsDir.BlobsReturns([]Blob{{Path: "golang/go1.8.linux-amd64.tar.gz"}}, nil)
+
+		fs.MkdirAll("/dir/src", os.ModePerm)
+		fs.WriteFileString("/dir/src/app/main.go", "package main")
+	})
+
+	Describe("Lint", func() {
+		It("returns no problems for release without problems", func() {
+			problems, err := linter.Lint()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(BeEmpty())
+		})
+
+		It("returns problems for job templates, packages and monit files", func() {
+			fs.WriteFileString("/dir/jobs/web/spec", `---
+name: web
+templates:
+  ctl.erb: bin/ctl
+  missing.erb: config/missing.yml
+packages: [app, missing-pkg]
+`)
+			fs.WriteFileString("/dir/jobs/web/monit", `
+check process web
+  start program "/var/vcap/jobs/web/bin/start"
+  stop program "/var/vcap/jobs/web/bin/ctl stop"
+`)
+
+			problems, err := linter.Lint()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(Equal([]LintProblem{
+				{
+					Severity: "error",
+					Check:    "missing-template",
+					Subject:  "jobs/web",
+					Message:  "Template 'missing.erb' is listed in spec but does not exist",
+				},
+				{
+					Severity: "error",
+					Check:    "missing-package",
+					Subject:  "jobs/web",
+					Message:  "Package 'missing-pkg' is listed in spec but does not exist",
+				},
+				{
+					Severity: "warning",
+					Check:    "unused-template",
+					Subject:  "jobs/web",
+					Message:  "Template 'config.yml.erb' exists but is not listed in spec",
+				},
+				{
+					Severity: "warning",
+					Check:    "monit-process-mismatch",
+					Subject:  "jobs/web",
+					Message:  "Monit process 'web' uses '/var/vcap/jobs/web/bin/start' which is not rendered by any template",
+				},
+			}))
+		})
+
+		It("returns problem for monit file without processes", func() {
+			fs.WriteFileString("/dir/jobs/web/monit", "# comment\nset daemon 10\n")
+
+			problems, err := linter.Lint()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(Equal([]LintProblem{{
+				Severity: "warning",
+				Check:    "monit-process-mismatch",
+				Subject:  "jobs/web",
+				Message:  "Monit file does not check any process",
+			}}))
+		})
+
+		It("allows empty monit files and dynamic program paths", func() {
+			fs.WriteFileString("/dir/jobs/web/monit", "")
+
+			problems, err := linter.Lint()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(BeEmpty())
+
+			fs.WriteFileString("/dir/jobs/web/monit", `check process web
+  start program "/var/vcap/jobs/web/bin/<%= p('ctl') %>"
+`)
+
+			problems, err = linter.Lint()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(BeEmpty())
+		})
+
+		It("returns problems for missing dependencies and unused packages", func() {
+			fs.SetGlob("/dir/packages/*/spec", []string{
+				"/dir/packages/app/spec", "/dir/packages/golang/spec", "/dir/packages/unused/spec"})
+
+			fs.WriteFileString("/dir/packages/app/spec", `---
+name: app
+dependencies: [golang, missing-dep]
+files: [app/**/*]
+`)
+			fs.WriteFileString("/dir/packages/unused/spec", `---
+name: unused
+`)
+
+			problems, err := linter.Lint()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(Equal([]LintProblem{
+				{
+					Severity: "error",
+					Check:    "missing-dependency",
+					Subject:  "packages/app",
+					Message:  "Dependency 'missing-dep' does not exist",
+				},
+				{
+					Severity: "warning",
+					Check:    "unused-package",
+					Subject:  "packages/unused",
+					Message:  "Package is not used by any job",
+				},
+			}))
+		})
+
+		It("returns problems for blobs not referenced by packages", func() {
+			blobsDir.BlobsReturns([]Blob{
+				{Path: "golang/go1.8.linux-amd64.tar.gz"},
+				{Path: "ruby/ruby-2.4.tar.gz"},
+			}, nil)
+
+			problems, err := linter.Lint()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(Equal([]LintProblem{{
+				Severity: "warning",
+				Check:    "unused-blob",
+				Subject:  "blobs/ruby/ruby-2.4.tar.gz",
+				Message:  "Blob is not referenced by any package files",
+			}}))
+		})
+
+		It("does not check blobs if release does not track any", func() {
+			fs.RemoveAll("/dir/config/blobs.yml")
+
+			problems, err := linter.Lint()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(BeEmpty())
+			Expect(blobsDir.BlobsCallCount()).To(Equal(0))
+		})
+
+		It("returns problems for large files in src", func() {
+			fs.WriteFileString("/dir/src/app/vendor.tgz", strings.Repeat("a", 10*1024*1024+1))
+
+			problems, err := linter.Lint()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(problems).To(Equal([]LintProblem{{
+				Severity: "warning",
+				Check:    "large-src-file",
+				Subject:  "src/app/vendor.tgz",
+				Message:  "File is 10485761 bytes; consider tracking it as a blob",
+			}}))
+		})
+
+		It("returns error if blobs cannot be listed", func() {
+			blobsDir.BlobsReturns(nil, errors.New("fake-err"))
+
+			_, err := linter.Lint()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("fake-err"))
+		})
+
+		It("returns error if job spec cannot be read", func() {
+			fs.WriteFileString("/dir/jobs/web/spec", "-")
+
+			_, err := linter.Lint()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Unmarshalling job spec"))
+		})
+	})
+})
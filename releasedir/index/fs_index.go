@@ -142,6 +142,29 @@ func (i FSIndex) Find(name, fingerprint string) (string, string, error) {
 	return "", "", nil
 }
 
+func (i FSIndex) FindDigest(name, fingerprint string) (string, error) {
+	if len(name) == 0 {
+		return "", bosherr.Error("Expected non-empty name")
+	}
+
+	if len(fingerprint) == 0 {
+		return "", bosherr.Error("Expected non-empty fingerprint")
+	}
+
+	entries, err := i.entries(name)
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range entries {
+		if entry.Version == fingerprint {
+			return entry.SHA1, nil
+		}
+	}
+
+	return "", nil
+}
+
 func (i FSIndex) Add(name, fingerprint, path, sha1 string) (string, string, error) {
 	if len(name) == 0 {
 		return "", "", bosherr.Error("Expected non-empty name")
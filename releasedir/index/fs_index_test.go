@@ -190,6 +190,39 @@ format-version: "2"`)
 		})
 	})
 
+	Describe("FindDigest", func() {
+		It("returns nothing if entry with fingerprint is not found", func() {
+			digest, err := index.FindDigest("name", "fp")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(digest).To(BeEmpty())
+		})
+
+		It("returns digest without fetching blob if entry with fingerprint is found", func() {
+			fs.WriteFileString(filepath.Join("/", "dir", "name", "index.yml"), `---
+builds:
+  fp2: {version: fp2, sha1: fp2-sha1}
+  fp: {version: fp, sha1: "sha256:fp-sha256"}
+format-version: "2"`)
+
+			digest, err := index.FindDigest("name", "fp")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(digest).To(Equal("sha256:fp-sha256"))
+			Expect(blobs.GetCallCount()).To(Equal(0))
+		})
+
+		It("returns error if name is empty", func() {
+			_, err := index.FindDigest("", "fp")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected non-empty name"))
+		})
+
+		It("returns error if fingerprint is empty", func() {
+			_, err := index.FindDigest("name", "")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("Expected non-empty fingerprint"))
+		})
+	})
+
 	Describe("Add", func() {
 		It("adds new entry when no index file exists", func() {
 			blobs.AddStub = func(name, path, sha1 string) (string, string, error) {
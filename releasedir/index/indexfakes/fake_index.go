@@ -8,7 +8,7 @@ import (
 )
 
 type FakeIndex struct {
-	FindStub        func(name, version string) (string, string, error)
+	FindStub        func(name string, version string) (string, string, error)
 	findMutex       sync.RWMutex
 	findArgsForCall []struct {
 		name    string
@@ -24,7 +24,7 @@ type FakeIndex struct {
 		result2 string
 		result3 error
 	}
-	AddStub        func(name, version, path, sha1 string) (string, string, error)
+	AddStub        func(name string, version string, path string, sha1 string) (string, string, error)
 	addMutex       sync.RWMutex
 	addArgsForCall []struct {
 		name    string
@@ -42,6 +42,20 @@ type FakeIndex struct {
 		result2 string
 		result3 error
 	}
+	FindDigestStub        func(name string, version string) (string, error)
+	findDigestMutex       sync.RWMutex
+	findDigestArgsForCall []struct {
+		name    string
+		version string
+	}
+	findDigestReturns struct {
+		result1 string
+		result2 error
+	}
+	findDigestReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -158,6 +172,58 @@ func (fake *FakeIndex) AddReturnsOnCall(i int, result1 string, result2 string, r
 	}{result1, result2, result3}
 }
 
+func (fake *FakeIndex) FindDigest(name string, version string) (string, error) {
+	fake.findDigestMutex.Lock()
+	ret, specificReturn := fake.findDigestReturnsOnCall[len(fake.findDigestArgsForCall)]
+	fake.findDigestArgsForCall = append(fake.findDigestArgsForCall, struct {
+		name    string
+		version string
+	}{name, version})
+	fake.recordInvocation("FindDigest", []interface{}{name, version})
+	fake.findDigestMutex.Unlock()
+	if fake.FindDigestStub != nil {
+		return fake.FindDigestStub(name, version)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.findDigestReturns.result1, fake.findDigestReturns.result2
+}
+
+func (fake *FakeIndex) FindDigestCallCount() int {
+	fake.findDigestMutex.RLock()
+	defer fake.findDigestMutex.RUnlock()
+	return len(fake.findDigestArgsForCall)
+}
+
+func (fake *FakeIndex) FindDigestArgsForCall(i int) (string, string) {
+	fake.findDigestMutex.RLock()
+	defer fake.findDigestMutex.RUnlock()
+	return fake.findDigestArgsForCall[i].name, fake.findDigestArgsForCall[i].version
+}
+
+func (fake *FakeIndex) FindDigestReturns(result1 string, result2 error) {
+	fake.FindDigestStub = nil
+	fake.findDigestReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIndex) FindDigestReturnsOnCall(i int, result1 string, result2 error) {
+	fake.FindDigestStub = nil
+	if fake.findDigestReturnsOnCall == nil {
+		fake.findDigestReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.findDigestReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeIndex) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -165,6 +231,8 @@ func (fake *FakeIndex) Invocations() map[string][][]interface{} {
 	defer fake.findMutex.RUnlock()
 	fake.addMutex.RLock()
 	defer fake.addMutex.RUnlock()
+	fake.findDigestMutex.RLock()
+	defer fake.findDigestMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -5,6 +5,9 @@ package index
 type Index interface {
 	Find(name, version string) (string, string, error)
 	Add(name, version, path, sha1 string) (string, string, error)
+
+	// FindDigest returns recorded digest without fetching archive
+	FindDigest(name, version string) (string, error)
 }
 
 //go:generate counterfeiter . IndexBlobs
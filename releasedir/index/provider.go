@@ -28,30 +28,48 @@ func NewProvider(
 }
 
 func (p Provider) DevAndFinalIndicies(dirPath string) (boshrel.ArchiveIndicies, boshrel.ArchiveIndicies) {
-	cachePath := filepath.Join("~", ".bosh", "cache")
-
-	devBlobsCache := NewFSIndexBlobs(cachePath, p.reporter, nil, p.fs)
-	finalBlobsCache := NewFSIndexBlobs(cachePath, p.reporter, p.blobstore, p.fs)
+	devBlobsCache, finalBlobsCache := p.blobsCaches()
 
 	devJobsPath := filepath.Join(dirPath, ".dev_builds", "jobs")
-	devPkgsPath := filepath.Join(dirPath, ".dev_builds", "packages")
 	devLicPath := filepath.Join(dirPath, ".dev_builds", "license")
 
 	finalJobsPath := filepath.Join(dirPath, ".final_builds", "jobs")
-	finalPkgsPath := filepath.Join(dirPath, ".final_builds", "packages")
 	finalLicPath := filepath.Join(dirPath, ".final_builds", "license")
 
+	devPkgs, finalPkgs := p.DevAndFinalPackageIndicies(dirPath)
+
 	devIndicies := boshrel.ArchiveIndicies{
 		Jobs:     NewFSIndex("job", devJobsPath, true, false, p.reporter, devBlobsCache, p.fs),
-		Packages: NewFSIndex("package", devPkgsPath, true, false, p.reporter, devBlobsCache, p.fs),
+		Packages: devPkgs,
 		Licenses: NewFSIndex("license", devLicPath, false, false, p.reporter, devBlobsCache, p.fs),
 	}
 
 	finalIndicies := boshrel.ArchiveIndicies{
 		Jobs:     NewFSIndex("job", finalJobsPath, true, true, p.reporter, finalBlobsCache, p.fs),
-		Packages: NewFSIndex("package", finalPkgsPath, true, true, p.reporter, finalBlobsCache, p.fs),
+		Packages: finalPkgs,
 		Licenses: NewFSIndex("license", finalLicPath, false, true, p.reporter, finalBlobsCache, p.fs),
 	}
 
 	return devIndicies, finalIndicies
 }
+
+func (p Provider) DevAndFinalPackageIndicies(dirPath string) (FSIndex, FSIndex) {
+	devBlobsCache, finalBlobsCache := p.blobsCaches()
+
+	devPkgsPath := filepath.Join(dirPath, ".dev_builds", "packages")
+	finalPkgsPath := filepath.Join(dirPath, ".final_builds", "packages")
+
+	devPkgs := NewFSIndex("package", devPkgsPath, true, false, p.reporter, devBlobsCache, p.fs)
+	finalPkgs := NewFSIndex("package", finalPkgsPath, true, true, p.reporter, finalBlobsCache, p.fs)
+
+	return devPkgs, finalPkgs
+}
+
+func (p Provider) blobsCaches() (FSIndexBlobs, FSIndexBlobs) {
+	cachePath := filepath.Join("~", ".bosh", "cache")
+
+	devBlobsCache := NewFSIndexBlobs(cachePath, p.reporter, nil, p.fs)
+	finalBlobsCache := NewFSIndexBlobs(cachePath, p.reporter, p.blobstore, p.fs)
+
+	return devBlobsCache, finalBlobsCache
+}
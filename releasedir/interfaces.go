@@ -8,6 +8,7 @@ import (
 	boshrel "github.com/cloudfoundry/bosh-cli/release"
 	boshrelman "github.com/cloudfoundry/bosh-cli/release/manifest"
 	boshpkg "github.com/cloudfoundry/bosh-cli/release/pkg"
+	boshres "github.com/cloudfoundry/bosh-cli/release/resource"
 	boshcrypto "github.com/cloudfoundry/bosh-utils/crypto"
 )
 
@@ -57,6 +58,46 @@ type Generator interface {
 	GeneratePackage(string) error
 }
 
+//go:generate counterfeiter . Linter
+
+type Linter interface {
+	Lint() ([]LintProblem, error)
+}
+
+type LintProblem struct {
+	Severity string
+	Check    string
+	Subject  string
+	Message  string
+}
+
+//go:generate counterfeiter . FingerprintExplainer
+
+type FingerprintExplainer interface {
+	// ExplainJob and ExplainPackage return entries that make up a fingerprint
+	// of a job or a package found in the release directory or,
+	// if version is not empty, in that dev or final release version.
+	ExplainJob(name string, version semver.Version) (FingerprintExplanation, error)
+	ExplainPackage(name string, version semver.Version) (FingerprintExplanation, error)
+}
+
+type FingerprintExplanation struct {
+	Name        string
+	Fingerprint string
+	Entries     []boshres.FingerprintEntry
+}
+
+//go:generate counterfeiter . Inventory
+
+type Inventory interface {
+	Name() (string, error)
+	Blobs() ([]Blob, error)
+
+	// PackageDigest returns digest of a built package recorded
+	// in final or dev package index or empty string if it was never built.
+	PackageDigest(name, fingerprint string) (string, error)
+}
+
 //go:generate counterfeiter . GitRepo
 
 type GitRepo interface {
@@ -95,6 +95,33 @@ func (p Provider) NewFSBlobsDir(dirPath string) FSBlobsDir {
 	return NewFSBlobsDir(dirPath, p.blobsReporter, p.newBlobstore(dirPath), p.digestCalculator, p.fs, p.logger)
 }
 
+func (p Provider) NewFSLinter(dirPath string) FSLinter {
+	return NewFSLinter(dirPath, p.NewFSBlobsDir(dirPath), p.fs)
+}
+
+func (p Provider) NewFSFingerprintExplainer(dirPath string, parallel int) FSFingerprintExplainer {
+	devRelsPath := filepath.Join(dirPath, "dev_releases")
+	devReleases := NewFSReleaseIndex("dev", devRelsPath, p.releaseIndexReporter, p.uuidGen, p.fs)
+
+	finalRelsPath := filepath.Join(dirPath, "releases")
+	finalReleases := NewFSReleaseIndex("final", finalRelsPath, p.releaseIndexReporter, p.uuidGen, p.fs)
+
+	return NewFSFingerprintExplainer(
+		p.releaseProvider.NewFingerprintExplainer(dirPath),
+		p.newConfig(dirPath),
+		devReleases,
+		finalReleases,
+		p.NewReleaseReader(dirPath, parallel),
+		p.fs,
+	)
+}
+
+func (p Provider) NewFSInventory(dirPath string) FSInventory {
+	indiciesProvider := boshidx.NewProvider(p.indexReporter, p.newBlobstore(dirPath), p.fs)
+	devPkgs, finalPkgs := indiciesProvider.DevAndFinalPackageIndicies(dirPath)
+	return NewFSInventory(p.newConfig(dirPath), p.NewFSBlobsDir(dirPath), devPkgs, finalPkgs)
+}
+
 func (p Provider) NewReleaseReader(dirPath string, parallel int) boshrel.BuiltReader {
 	multiReader := p.releaseProvider.NewMultiReader(dirPath)
 	indiciesProvider := boshidx.NewProvider(p.indexReporter, p.newBlobstore(dirPath), p.fs)
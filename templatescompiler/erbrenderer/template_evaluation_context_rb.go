@@ -43,6 +43,7 @@ class TemplateEvaluationContext
 
     @properties = openstruct(properties)
     @raw_properties = properties
+    @links = spec['links'] || {}
     @spec = openstruct(spec)
   end
 
@@ -73,12 +74,31 @@ class TemplateEvaluationContext
     InactiveElseBlock.new
   end
 
+  def link(name)
+    link_spec = @links[name]
+    raise UnknownLink.new(name) if link_spec.nil?
+
+    create_evaluation_link(link_spec)
+  end
+
   def if_link(name)
-    false
+    link_spec = @links[name]
+    return ActiveElseBlock.new(self) if link_spec.nil?
+
+    yield create_evaluation_link(link_spec)
+    InactiveElseBlock.new
   end
 
   private
 
+  def create_evaluation_link(link_spec)
+    instances = (link_spec['instances'] || []).map do |i|
+      EvaluationLinkInstance.new(i['name'], i['index'], i['id'], i['az'], i['address'], i['bootstrap'])
+    end
+
+    EvaluationLink.new(instances, link_spec['properties'] || {}, link_spec['address'])
+  end
+
   def copy_property(dst, src, name, default = nil)
     keys = name.split(".")
     src_ref = src
@@ -131,6 +151,12 @@ class TemplateEvaluationContext
     end
   end
 
+  class UnknownLink < StandardError
+    def initialize(name)
+      super("Can't find link '#{name}'")
+    end
+  end
+
   class ActiveElseBlock
     def initialize(template)
       @context = template
@@ -154,6 +180,66 @@ class TemplateEvaluationContext
   end
 end
 
+class EvaluationLinkInstance
+  attr_reader :name, :index, :id, :az, :address, :bootstrap
+
+  def initialize(name, index, id, az, address, bootstrap)
+    @name = name
+    @index = index
+    @id = id
+    @az = az
+    @address = address
+    @bootstrap = bootstrap
+  end
+end
+
+class EvaluationLink
+  attr_reader :instances, :properties, :address
+
+  def initialize(instances, properties, address)
+    @instances = instances
+    @properties = properties
+    @address = address
+  end
+
+  def p(*args)
+    names = Array(args[0])
+
+    names.each do |name|
+      result = lookup_property(@properties, name)
+      return result unless result.nil?
+    end
+
+    return args[1] if args.length == 2
+    raise TemplateEvaluationContext::UnknownProperty.new(names)
+  end
+
+  def if_p(*names)
+    values = names.map do |name|
+      value = lookup_property(@properties, name)
+      return TemplateEvaluationContext::ActiveElseBlock.new(self) if value.nil?
+      value
+    end
+
+    yield *values
+    TemplateEvaluationContext::InactiveElseBlock.new
+  end
+
+  private
+
+  def lookup_property(collection, name)
+    keys = name.split(".")
+    ref = collection
+
+    keys.each do |key|
+      ref = ref[key]
+      return nil if ref.nil?
+    end
+
+    ref
+  end
+end
+
 # todo do not use JSON in releases
 class << JSON
   alias dump_array_or_hash dump
@@ -0,0 +1,168 @@
+package templatescompiler
+
+import (
+	"encoding/json"
+
+	bosherr "github.com/cloudfoundry/bosh-utils/errors"
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	boshuuid "github.com/cloudfoundry/bosh-utils/uuid"
+
+	bireljob "github.com/cloudfoundry/bosh-cli/release/job"
+	bierbrenderer "github.com/cloudfoundry/bosh-cli/templatescompiler/erbrenderer"
+)
+
+// InstanceSpec describes instance that job templates are rendered for
+// when there is no director to provide it (e.g. when testing templates)
+type InstanceSpec struct {
+	Index      int
+	ID         string
+	AZ         string
+	Bootstrap  bool
+	Deployment string
+	Address    string
+
+	Networks map[string]NetworkSpec
+	Links    map[string]LinkSpec
+}
+
+type NetworkSpec struct {
+	IP      string
+	Netmask string
+	Gateway string
+}
+
+type LinkSpec struct {
+	Address    string
+	Properties biproperty.Map
+	Instances  []LinkInstanceSpec
+}
+
+type LinkInstanceSpec struct {
+	Name      string
+	Index     int
+	ID        string
+	AZ        string
+	Address   string
+	Bootstrap bool
+}
+
+type instanceEvaluationContext struct {
+	releaseJob    bireljob.Job
+	jobProperties biproperty.Map
+	instance      InstanceSpec
+	uuidGen       boshuuid.Generator
+	logger        boshlog.Logger
+	logTag        string
+}
+
+func NewInstanceEvaluationContext(
+	releaseJob bireljob.Job,
+	jobProperties biproperty.Map,
+	instance InstanceSpec,
+	uuidGen boshuuid.Generator,
+	logger boshlog.Logger,
+) bierbrenderer.TemplateEvaluationContext {
+	return instanceEvaluationContext{
+		releaseJob:    releaseJob,
+		jobProperties: jobProperties,
+		instance:      instance,
+		uuidGen:       uuidGen,
+		logTag:        "instanceEvaluationContext",
+		logger:        logger,
+	}
+}
+
+func (ec instanceEvaluationContext) MarshalJSON() ([]byte, error) {
+	defaultProperties := biproperty.Map{}
+
+	for propertyKey, property := range ec.releaseJob.Properties {
+		defaultProperties[propertyKey] = property.Default
+	}
+
+	jobProperties := ec.jobProperties
+	if jobProperties == nil {
+		jobProperties = biproperty.Map{}
+	}
+
+	context := RootContext{
+		Index:             ec.instance.Index,
+		ID:                ec.instance.ID,
+		AZ:                ec.instance.AZ,
+		Bootstrap:         ec.instance.Bootstrap,
+		JobContext:        jobContext{Name: ec.releaseJob.Name()},
+		Deployment:        ec.instance.Deployment,
+		Address:           ec.instance.Address,
+		NetworkContexts:   ec.buildNetworkContexts(),
+		Links:             ec.buildLinkContexts(),
+		GlobalProperties:  biproperty.Map{},
+		ClusterProperties: biproperty.Map{},
+		JobProperties:     &jobProperties,
+		DefaultProperties: defaultProperties,
+	}
+
+	if len(context.AZ) == 0 {
+		context.AZ = "unknown"
+	}
+
+	if len(context.ID) == 0 {
+		id, err := ec.uuidGen.Generate()
+		if err != nil {
+			return []byte{}, bosherr.WrapErrorf(err, "Setting instance eval context's ID to UUID: %#v", context)
+		}
+
+		context.ID = id
+	}
+
+	ec.logger.Debug(ec.logTag, "Marshalling context %#v", context)
+
+	jsonBytes, err := json.Marshal(context)
+	if err != nil {
+		return []byte{}, bosherr.WrapErrorf(err, "Marshalling instance eval context: %#v", context)
+	}
+
+	return jsonBytes, nil
+}
+
+func (ec instanceEvaluationContext) buildNetworkContexts() map[string]networkContext {
+	if len(ec.instance.Networks) == 0 {
+		return map[string]networkContext{"default": networkContext{}}
+	}
+
+	networks := map[string]networkContext{}
+
+	for name, network := range ec.instance.Networks {
+		networks[name] = networkContext{
+			IP:      network.IP,
+			Netmask: network.Netmask,
+			Gateway: network.Gateway,
+		}
+	}
+
+	return networks
+}
+
+func (ec instanceEvaluationContext) buildLinkContexts() map[string]linkContext {
+	links := map[string]linkContext{}
+
+	for name, link := range ec.instance.Links {
+		properties := link.Properties
+		if properties == nil {
+			properties = biproperty.Map{}
+		}
+
+		linkCtx := linkContext{
+			Address:    link.Address,
+			Properties: properties,
+			Instances:  []linkInstanceContext{},
+		}
+
+		for _, inst := range link.Instances {
+			linkCtx.Instances = append(linkCtx.Instances, linkInstanceContext(inst))
+		}
+
+		links[name] = linkCtx
+	}
+
+	return links
+}
@@ -0,0 +1,116 @@
+package templatescompiler_test
+
+import (
+	"encoding/json"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	biproperty "github.com/cloudfoundry/bosh-utils/property"
+	fakeuuid "github.com/cloudfoundry/bosh-utils/uuid/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	boshreljob "github.com/cloudfoundry/bosh-cli/release/job"
+	. "github.com/cloudfoundry/bosh-cli/release/resource"
+	. "github.com/cloudfoundry/bosh-cli/templatescompiler"
+)
+
+var _ = Describe("InstanceEvaluationContext", func() {
+	var (
+		releaseJob    *boshreljob.Job
+		jobProperties biproperty.Map
+		instance      InstanceSpec
+		uuidGen       *fakeuuid.FakeGenerator
+	)
+
+	BeforeEach(func() {
+		releaseJob = boshreljob.NewJob(NewResource("fake-job-name", "", nil))
+		releaseJob.Properties = map[string]boshreljob.PropertyDefinition{
+			"port": boshreljob.PropertyDefinition{Default: 80},
+		}
+
+		jobProperties = biproperty.Map{"port": 8080}
+		instance = InstanceSpec{}
+
+		uuidGen = fakeuuid.NewFakeGenerator()
+		uuidGen.GeneratedUUID = "fake-uuid"
+	})
+
+	act := func() map[string]interface{} {
+		logger := boshlog.NewLogger(boshlog.LevelNone)
+		context := NewInstanceEvaluationContext(*releaseJob, jobProperties, instance, uuidGen, logger)
+
+		generatedJSON, err := context.MarshalJSON()
+		Expect(err).ToNot(HaveOccurred())
+
+		var generatedContext map[string]interface{}
+
+		err = json.Unmarshal(generatedJSON, &generatedContext)
+		Expect(err).ToNot(HaveOccurred())
+
+		return generatedContext
+	}
+
+	It("uses defaults for instance values that are not specified", func() {
+		context := act()
+		Expect(context["index"]).To(Equal(float64(0)))
+		Expect(context["id"]).To(Equal("fake-uuid"))
+		Expect(context["az"]).To(Equal("unknown"))
+		Expect(context["job"]).To(Equal(map[string]interface{}{"name": "fake-job-name"}))
+		Expect(context["networks"]).To(Equal(map[string]interface{}{
+			"default": map[string]interface{}{"ip": "", "netmask": "", "gateway": ""},
+		}))
+		Expect(context).ToNot(HaveKey("links"))
+	})
+
+	It("includes job properties and spec defaults", func() {
+		context := act()
+		Expect(context["job_properties"]).To(Equal(map[string]interface{}{"port": float64(8080)}))
+		Expect(context["default_properties"]).To(Equal(map[string]interface{}{"port": float64(80)}))
+	})
+
+	It("includes given instance values, networks and links", func() {
+		instance = InstanceSpec{
+			Index:      2,
+			ID:         "fake-id",
+			AZ:         "z1",
+			Bootstrap:  true,
+			Deployment: "fake-dep",
+			Address:    "fake-address",
+			Networks: map[string]NetworkSpec{
+				"private": NetworkSpec{IP: "10.0.0.5", Netmask: "255.255.255.0", Gateway: "10.0.0.1"},
+			},
+			Links: map[string]LinkSpec{
+				"db": LinkSpec{
+					Address:    "db.bosh",
+					Properties: biproperty.Map{"port": 5432},
+					Instances: []LinkInstanceSpec{
+						{Name: "db", Index: 0, ID: "db-id", AZ: "z1", Address: "10.0.0.6", Bootstrap: true},
+					},
+				},
+			},
+		}
+
+		context := act()
+		Expect(context["index"]).To(Equal(float64(2)))
+		Expect(context["id"]).To(Equal("fake-id"))
+		Expect(context["az"]).To(Equal("z1"))
+		Expect(context["bootstrap"]).To(BeTrue())
+		Expect(context["deployment"]).To(Equal("fake-dep"))
+		Expect(context["address"]).To(Equal("fake-address"))
+		Expect(context["networks"]).To(Equal(map[string]interface{}{
+			"private": map[string]interface{}{"ip": "10.0.0.5", "netmask": "255.255.255.0", "gateway": "10.0.0.1"},
+		}))
+		Expect(context["links"]).To(Equal(map[string]interface{}{
+			"db": map[string]interface{}{
+				"address":    "db.bosh",
+				"properties": map[string]interface{}{"port": float64(5432)},
+				"instances": []interface{}{
+					map[string]interface{}{
+						"name": "db", "index": float64(0), "id": "db-id",
+						"az": "z1", "address": "10.0.0.6", "bootstrap": true,
+					},
+				},
+			},
+		}))
+	})
+})
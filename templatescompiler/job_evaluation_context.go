@@ -37,6 +37,9 @@ type RootContext struct {
 	// Usually is accessed with <%= spec.networks.default.ip %>
 	NetworkContexts map[string]networkContext `json:"networks"`
 
+	// Usually is accessed with <%= link("name").p("property") %>
+	Links map[string]linkContext `json:"links,omitempty"`
+
 	//TODO: this should be a map[string]interface{}
 	GlobalProperties  biproperty.Map  `json:"global_properties"`  // values from manifest's top-level properties
 	ClusterProperties biproperty.Map  `json:"cluster_properties"` // values from instance group (deployment job) properties
@@ -54,6 +57,21 @@ type networkContext struct {
 	Gateway string `json:"gateway"`
 }
 
+type linkContext struct {
+	Address    string                `json:"address,omitempty"`
+	Properties biproperty.Map        `json:"properties"`
+	Instances  []linkInstanceContext `json:"instances"`
+}
+
+type linkInstanceContext struct {
+	Name      string `json:"name"`
+	Index     int    `json:"index"`
+	ID        string `json:"id"`
+	AZ        string `json:"az"`
+	Address   string `json:"address"`
+	Bootstrap bool   `json:"bootstrap"`
+}
+
 func NewJobEvaluationContext(
 	releaseJob bireljob.Job,
 	releaseJobProperties *biproperty.Map,
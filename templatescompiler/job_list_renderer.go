@@ -46,6 +46,26 @@ func (r *jobListRenderer) Render(
 	r.logger.Debug(r.logTag, "Rendering job list: deploymentName='%s' jobProperties=%#v globalProperties=%#v", deploymentName, jobProperties, globalProperties)
 	renderedJobList := NewRenderedJobList()
 
+	// only jobs that specify property types opt into validation
+	var typedJobs []bireljob.Job
+
+	for _, releaseJob := range releaseJobs {
+		if releaseJob.HasPropertyTypes() {
+			typedJobs = append(typedJobs, releaseJob)
+		}
+	}
+
+	problems := bireljob.ValidateProperties(typedJobs, releaseJobProperties, jobProperties, globalProperties)
+	if len(problems) > 0 {
+		var errs []error
+
+		for _, problem := range problems {
+			errs = append(errs, problem)
+		}
+
+		return renderedJobList, bosherr.WrapError(bosherr.NewMultiError(errs...), "Validating job properties")
+	}
+
 	// render all the jobs' templates
 	for _, releaseJob := range releaseJobs {
 		renderedJob, err := r.jobRenderer.Render(releaseJob, releaseJobProperties[releaseJob.Name()], jobProperties, globalProperties, deploymentName, address)
@@ -80,12 +80,12 @@ var _ = Describe("JobListRenderer", func() {
 		jobListRenderer = NewJobListRenderer(mockJobRenderer, logger)
 	})
 
-	JustBeforeEach(func() {
-		mockJobRenderer.EXPECT().Render(releaseJobs[0], releaseJobProperties[releaseJobs[0].Name()], jobProperties, globalProperties, deploymentName, address).Return(renderedJobs[0], nil)
-		expectRender1 = mockJobRenderer.EXPECT().Render(releaseJobs[1], releaseJobProperties[releaseJobs[1].Name()], jobProperties, globalProperties, deploymentName, address).Return(renderedJobs[1], nil)
-	})
-
 	Describe("Render", func() {
+		JustBeforeEach(func() {
+			mockJobRenderer.EXPECT().Render(releaseJobs[0], releaseJobProperties[releaseJobs[0].Name()], jobProperties, globalProperties, deploymentName, address).Return(renderedJobs[0], nil)
+			expectRender1 = mockJobRenderer.EXPECT().Render(releaseJobs[1], releaseJobProperties[releaseJobs[1].Name()], jobProperties, globalProperties, deploymentName, address).Return(renderedJobs[1], nil)
+		})
+
 		It("returns a new RenderedJobList with all the RenderedJobs", func() {
 			renderedJobList, err := jobListRenderer.Render(releaseJobs, releaseJobProperties, jobProperties, globalProperties, deploymentName, address)
 			Expect(err).ToNot(HaveOccurred())
@@ -110,4 +110,18 @@ var _ = Describe("JobListRenderer", func() {
 		})
 	})
 
+	Describe("Render with job specs that specify property types", func() {
+		BeforeEach(func() {
+			releaseJobs[0].Properties = map[string]boshreljob.PropertyDefinition{
+				"fake-template-property": boshreljob.PropertyDefinition{Type: "integer"},
+			}
+		})
+
+		It("returns an error without rendering jobs when properties do not match spec", func() {
+			_, err := jobListRenderer.Render(releaseJobs, releaseJobProperties, jobProperties, globalProperties, deploymentName, address)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Validating job properties"))
+			Expect(err.Error()).To(ContainSubstring("Job 'fake-release-job-name-0' property 'fake-template-property': Expected integer but got string"))
+		})
+	})
 })
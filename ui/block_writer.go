@@ -0,0 +1,10 @@
+package ui
+
+type blockWriter struct {
+	ui UI
+}
+
+func (w blockWriter) Write(block []byte) (int, error) {
+	w.ui.PrintBlock(block)
+	return len(block), nil
+}
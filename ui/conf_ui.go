@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"io"
+
 	boshlog "github.com/cloudfoundry/bosh-utils/logger"
 
 	. "github.com/cloudfoundry/bosh-cli/ui/table"
@@ -8,6 +10,7 @@ import (
 
 type ConfUI struct {
 	parent      UI
+	blockUI     UI
 	isTTY       bool
 	logger      boshlog.Logger
 	showColumns []Header
@@ -48,6 +51,21 @@ func (ui *ConfUI) EnableJSON() {
 	ui.parent = NewJSONUI(ui.parent, ui.logger)
 }
 
+func (ui *ConfUI) EnableCompactJSON() {
+	ui.blockUI = ui.parent
+	ui.parent = NewCompactJSONUI(ui.parent, ui.logger)
+}
+
+// BlockWriter prints written bytes as blocks right away
+// even when the rest of output is collected into a JSON response
+func (ui *ConfUI) BlockWriter() io.Writer {
+	if ui.blockUI != nil {
+		return blockWriter{ui: ui.blockUI}
+	}
+
+	return blockWriter{ui: ui.parent}
+}
+
 func (ui *ConfUI) ShowColumns(columns []Header) {
 	ui.showColumns = columns
 }
@@ -0,0 +1,46 @@
+package ui_test
+
+import (
+	"fmt"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/cloudfoundry/bosh-cli/ui"
+	fakeui "github.com/cloudfoundry/bosh-cli/ui/fakes"
+)
+
+var _ = Describe("ConfUI", func() {
+	var (
+		parentUI *fakeui.FakeUI
+		ui       *ConfUI
+	)
+
+	BeforeEach(func() {
+		parentUI = &fakeui.FakeUI{}
+		ui = NewWrappingConfUI(parentUI, boshlog.NewLogger(boshlog.LevelNone))
+	})
+
+	Describe("BlockWriter", func() {
+		It("prints written bytes as blocks", func() {
+			fmt.Fprint(ui.BlockWriter(), "block1")
+			fmt.Fprint(ui.BlockWriter(), "block2")
+
+			Expect(parentUI.Blocks).To(Equal([]string{"block1", "block2"}))
+		})
+
+		It("prints blocks right away when output is collected as compact JSON", func() {
+			ui.EnableCompactJSON()
+
+			fmt.Fprint(ui.BlockWriter(), "{\"kind\":\"task_started\"}\n")
+			Expect(parentUI.Blocks).To(Equal([]string{"{\"kind\":\"task_started\"}\n"}))
+
+			ui.PrintBlock([]byte("block"))
+			Expect(parentUI.Blocks).To(HaveLen(1))
+
+			ui.Flush()
+			Expect(parentUI.Blocks).To(HaveLen(2))
+		})
+	})
+})
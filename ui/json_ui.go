@@ -15,6 +15,9 @@ type jsonUI struct {
 	parent UI
 	uiResp uiResp
 
+	// compact prints response on a single line (e.g. to follow NDJSON task events)
+	compact bool
+
 	logTag string
 	logger boshlog.Logger
 }
@@ -36,6 +39,10 @@ func NewJSONUI(parent UI, logger boshlog.Logger) UI {
 	return &jsonUI{parent: parent, logTag: "JSONUI", logger: logger}
 }
 
+func NewCompactJSONUI(parent UI, logger boshlog.Logger) UI {
+	return &jsonUI{parent: parent, compact: true, logTag: "JSONUI", logger: logger}
+}
+
 func (ui *jsonUI) ErrorLinef(pattern string, args ...interface{}) {
 	ui.addLine(pattern, args)
 }
@@ -124,7 +131,16 @@ func (ui *jsonUI) Flush() {
 	defer ui.parent.Flush()
 
 	if !reflect.DeepEqual(ui.uiResp, uiResp{}) {
-		bytes, err := json.MarshalIndent(ui.uiResp, "", "    ")
+		var bytes []byte
+		var err error
+
+		if ui.compact {
+			bytes, err = json.Marshal(ui.uiResp)
+			bytes = append(bytes, '\n')
+		} else {
+			bytes, err = json.MarshalIndent(ui.uiResp, "", "    ")
+		}
+
 		if err != nil {
 			ui.logger.Error(ui.logTag, "Failed to marshal UI response")
 			return
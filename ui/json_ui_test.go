@@ -343,5 +343,12 @@ var _ = Describe("JSONUI", func() {
     ]
 }`))
 		})
+
+		It("outputs everything on a single line when compact", func() {
+			ui = NewCompactJSONUI(parentUI, boshlog.NewLogger(boshlog.LevelNone))
+			ui.PrintLinef("fake-line1")
+			ui.Flush()
+			Expect(parentUI.Blocks[0]).To(Equal(`{"Tables":null,"Blocks":null,"Lines":["fake-line1"]}` + "\n"))
+		})
 	})
 })
@@ -18,34 +18,34 @@ const (
 )
 
 type Event struct {
-	TaskID   int
+	TaskID   int   `json:"-"`
 	UnixTime int64 `json:"time"` // e.g 1451020321
 
-	Type    string // e.g. "deprecation"
-	Message string
+	Type    string `json:"type,omitempty"` // e.g. "deprecation"
+	Message string `json:"message,omitempty"`
 
-	State string   // e.g. "started"
-	Stage string   // e.g. "Preparing deployment"
-	Task  string   // e.g. "Binding deployment"
-	Tags  []string // e.g. ["api"]
+	State string   `json:"state,omitempty"` // e.g. "started"
+	Stage string   `json:"stage,omitempty"` // e.g. "Preparing deployment"
+	Task  string   `json:"task,omitempty"`  // e.g. "Binding deployment"
+	Tags  []string `json:"tags,omitempty"`  // e.g. ["api"]
 
-	Total    int // e.g. 0
-	Index    int // e.g. 0
-	Progress int // e.g. 0
+	Total    int `json:"total"`    // e.g. 0
+	Index    int `json:"index"`    // e.g. 0
+	Progress int `json:"progress"` // e.g. 0
 
-	Data  EventData
-	Error *EventError
+	Data  EventData   `json:"data"`
+	Error *EventError `json:"error,omitempty"`
 
-	StartEvent *Event
+	StartEvent *Event `json:"-"`
 }
 
 type EventData struct {
-	Error string // e.g. "'api2/2' is not running after update"
+	Error string `json:"error,omitempty"` // e.g. "'api2/2' is not running after update"
 }
 
 type EventError struct {
-	Code    int    // e.g. 100
-	Message string // e.g. "Bosh::Director::Lock::TimeoutError"
+	Code    int    `json:"code"`    // e.g. 100
+	Message string `json:"message"` // e.g. "Bosh::Director::Lock::TimeoutError"
 }
 
 func (e Event) IsSame(other Event) bool {
@@ -0,0 +1,115 @@
+package task
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+)
+
+const (
+	NDJSONKindTaskStarted  = "task_started"
+	NDJSONKindEvent        = "event"
+	NDJSONKindOutput       = "output"
+	NDJSONKindTaskFinished = "task_finished"
+)
+
+// NDJSONReporter writes each task event as a single JSON line as soon as it arrives
+type NDJSONReporter struct {
+	w           io.Writer
+	isForEvents bool
+	logger      boshlog.Logger
+	logTag      string
+
+	outputRest map[int]string
+	sync.Mutex
+}
+
+type NDJSONLine struct {
+	Kind   string `json:"kind"`
+	TaskID int    `json:"task_id"`
+
+	State  string `json:"state,omitempty"`  // set for task_finished
+	Event  *Event `json:"event,omitempty"`  // set for event
+	Output string `json:"output,omitempty"` // set for output
+}
+
+func NewNDJSONReporter(w io.Writer, isForEvents bool, logger boshlog.Logger) *NDJSONReporter {
+	return &NDJSONReporter{
+		w:           w,
+		isForEvents: isForEvents,
+		logger:      logger,
+		logTag:      "NDJSONReporter",
+		outputRest:  map[int]string{},
+	}
+}
+
+func (r *NDJSONReporter) TaskStarted(id int) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.writeLine(NDJSONLine{Kind: NDJSONKindTaskStarted, TaskID: id})
+}
+
+func (r *NDJSONReporter) TaskFinished(id int, state string) {
+	r.Lock()
+	defer r.Unlock()
+
+	if len(r.outputRest[id]) > 0 {
+		r.writeChunkLine(id, r.outputRest[id])
+		r.outputRest[id] = ""
+	}
+
+	r.writeLine(NDJSONLine{Kind: NDJSONKindTaskFinished, TaskID: id, State: state})
+}
+
+func (r *NDJSONReporter) TaskOutputChunk(id int, chunk []byte) {
+	r.Lock()
+	defer r.Unlock()
+
+	r.outputRest[id] += string(chunk)
+
+	for {
+		idx := strings.Index(r.outputRest[id], "\n")
+		if idx == -1 {
+			break
+		}
+		if len(r.outputRest[id][0:idx]) > 0 {
+			r.writeChunkLine(id, r.outputRest[id][0:idx])
+		}
+		r.outputRest[id] = r.outputRest[id][idx+1:]
+	}
+}
+
+func (r *NDJSONReporter) writeChunkLine(id int, str string) {
+	if !r.isForEvents {
+		r.writeLine(NDJSONLine{Kind: NDJSONKindOutput, TaskID: id, Output: str})
+		return
+	}
+
+	event := Event{TaskID: id}
+
+	err := json.Unmarshal([]byte(str), &event)
+	if err != nil {
+		// Pass through lines that are not events instead of losing them
+		r.writeLine(NDJSONLine{Kind: NDJSONKindOutput, TaskID: id, Output: str})
+		return
+	}
+
+	r.writeLine(NDJSONLine{Kind: NDJSONKindEvent, TaskID: id, Event: &event})
+}
+
+func (r *NDJSONReporter) writeLine(line NDJSONLine) {
+	bytes, err := json.Marshal(line)
+	if err != nil {
+		r.logger.Error(r.logTag, "Marshaling '%s' line for task '%d': %s", line.Kind, line.TaskID, err)
+		return
+	}
+
+	_, err = r.w.Write(append(bytes, '\n'))
+	if err != nil {
+		r.logger.Error(r.logTag, "Writing '%s' line for task '%d': %s", line.Kind, line.TaskID, err)
+	}
+}
@@ -0,0 +1,98 @@
+package task_test
+
+import (
+	"bytes"
+	"errors"
+
+	boshlog "github.com/cloudfoundry/bosh-utils/logger"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	boshuit "github.com/cloudfoundry/bosh-cli/ui/task"
+)
+
+type failingWriter struct {
+	writes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return 0, errors.New("fake-write-err")
+}
+
+var _ = Describe("NDJSONReporter", func() {
+	var (
+		outBuf *bytes.Buffer
+		logger boshlog.Logger
+	)
+
+	BeforeEach(func() {
+		outBuf = bytes.NewBufferString("")
+		logger = boshlog.NewLogger(boshlog.LevelNone)
+	})
+
+	Context("for events", func() {
+		var (
+			reporter boshuit.Reporter
+		)
+
+		BeforeEach(func() {
+			reporter = boshuit.NewNDJSONReporter(outBuf, true, logger)
+		})
+
+		It("writes one line per task lifecycle change and event as soon as it arrives", func() {
+			reporter.TaskStarted(123)
+			Expect(outBuf.String()).To(Equal(`{"kind":"task_started","task_id":123}` + "\n"))
+
+			reporter.TaskOutputChunk(123, []byte(`{"time":1454017208,"stage":"Updating instance","tags":["api"],"total":2,"task":"api/0 (canary)","index":1,"state":"started","progress":0}`))
+			Expect(outBuf.String()).To(Equal(`{"kind":"task_started","task_id":123}` + "\n"))
+
+			reporter.TaskOutputChunk(123, []byte("\n"+`{"time":1454017210,"stage":"Updating instance","tags":["api"],"total":2,"task":"api/0 (canary)","index":1,"state":"failed","progress":100,"data":{"error":"err-msg"}}`+"\n"))
+			reporter.TaskOutputChunk(123, []byte(`{"time":1454017211,"error":{"code":100,"message":"Bosh::Director::Lock::TimeoutError"}}`+"\n"))
+			reporter.TaskFinished(123, "error")
+
+			Expect(outBuf.String()).To(Equal(
+				`{"kind":"task_started","task_id":123}` + "\n" +
+					`{"kind":"event","task_id":123,"event":{"time":1454017208,"state":"started","stage":"Updating instance","task":"api/0 (canary)","tags":["api"],"total":2,"index":1,"progress":0,"data":{}}}` + "\n" +
+					`{"kind":"event","task_id":123,"event":{"time":1454017210,"state":"failed","stage":"Updating instance","task":"api/0 (canary)","tags":["api"],"total":2,"index":1,"progress":100,"data":{"error":"err-msg"}}}` + "\n" +
+					`{"kind":"event","task_id":123,"event":{"time":1454017211,"total":0,"index":0,"progress":0,"data":{},"error":{"code":100,"message":"Bosh::Director::Lock::TimeoutError"}}}` + "\n" +
+					`{"kind":"task_finished","task_id":123,"state":"error"}` + "\n",
+			))
+		})
+
+		It("writes lines that are not events as output", func() {
+			reporter.TaskOutputChunk(123, []byte("not-json\n"))
+			Expect(outBuf.String()).To(Equal(`{"kind":"output","task_id":123,"output":"not-json"}` + "\n"))
+		})
+	})
+
+	Context("not for events", func() {
+		It("writes each line of output", func() {
+			reporter := boshuit.NewNDJSONReporter(outBuf, false, logger)
+
+			reporter.TaskStarted(123)
+			reporter.TaskOutputChunk(123, []byte("line1\nline"))
+			reporter.TaskOutputChunk(123, []byte("2\nline3"))
+			reporter.TaskFinished(123, "done")
+
+			Expect(outBuf.String()).To(Equal(
+				`{"kind":"task_started","task_id":123}` + "\n" +
+					`{"kind":"output","task_id":123,"output":"line1"}` + "\n" +
+					`{"kind":"output","task_id":123,"output":"line2"}` + "\n" +
+					`{"kind":"output","task_id":123,"output":"line3"}` + "\n" +
+					`{"kind":"task_finished","task_id":123,"state":"done"}` + "\n",
+			))
+		})
+	})
+
+	It("continues reporting when writing fails", func() {
+		writer := &failingWriter{}
+		reporter := boshuit.NewNDJSONReporter(writer, false, logger)
+
+		reporter.TaskStarted(123)
+		reporter.TaskOutputChunk(123, []byte("line1\n"))
+		reporter.TaskFinished(123, "done")
+
+		Expect(writer.writes).To(Equal(3))
+	})
+})